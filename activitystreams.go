@@ -0,0 +1,192 @@
+package gofeedx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ActivityStreamsMIMEType is the Content-Type for serving ToActivityStreams output.
+const ActivityStreamsMIMEType = "application/activity+json"
+
+const (
+	activityStreamsContext = "https://www.w3.org/ns/activitystreams"
+	activityStreamsPublic  = "https://www.w3.org/ns/activitystreams#Public"
+)
+
+// ActivityStreamsOptions configures ToActivityStreams.
+type ActivityStreamsOptions struct {
+	ActorID    string // absolute id of the publishing actor (required)
+	OutboxID   string // id of the collection; defaults to ActorID + "/outbox"
+	ObjectType string // "Note" (default) or "Article"
+}
+
+// ASCollection is an ActivityStreams 2.0 OrderedCollection.
+type ASCollection struct {
+	Context      string        `json:"@context"`
+	ID           string        `json:"id"`
+	Type         string        `json:"type"`
+	TotalItems   int           `json:"totalItems"`
+	OrderedItems []*ASActivity `json:"orderedItems"`
+}
+
+// ASActivity is a Create activity wrapping one item.
+type ASActivity struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	Actor     string    `json:"actor"`
+	Published string    `json:"published,omitempty"`
+	To        []string  `json:"to"`
+	Object    *ASObject `json:"object"`
+}
+
+// ASObject is the Note or Article created for an item.
+type ASObject struct {
+	ID           string          `json:"id"`
+	Type         string          `json:"type"`
+	AttributedTo string          `json:"attributedTo"`
+	Name         string          `json:"name,omitempty"`
+	Summary      string          `json:"summary,omitempty"`
+	Content      string          `json:"content,omitempty"`
+	MediaType    string          `json:"mediaType,omitempty"`
+	URL          string          `json:"url,omitempty"`
+	Published    string          `json:"published,omitempty"`
+	Updated      string          `json:"updated,omitempty"`
+	To           []string        `json:"to"`
+	Attachment   []*ASAttachment `json:"attachment,omitempty"`
+}
+
+// ASAttachment is a media attachment (the item enclosure or image).
+type ASAttachment struct {
+	Type      string `json:"type"` // "Audio", "Video", "Image" or "Document"
+	MediaType string `json:"mediaType,omitempty"`
+	URL       string `json:"url"`
+}
+
+// ToActivityStreams renders feed as an ActivityStreams 2.0 outbox: an OrderedCollection of
+// public Create activities, newest first, each wrapping a Note or Article for one item.
+// Object ids are the item ID or link when absolute, otherwise derived from ActorID. HTML
+// content runs through the feed's HTMLSanitizer (see WithHTMLSanitizer).
+func ToActivityStreams(feed *Feed, opts ActivityStreamsOptions) (string, error) {
+	c, err := NewActivityStreamsCollection(feed, opts)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// NewActivityStreamsCollection returns the structured outbox that ToActivityStreams encodes.
+func NewActivityStreamsCollection(feed *Feed, opts ActivityStreamsOptions) (*ASCollection, error) {
+	if feed == nil {
+		return nil, errors.New("nil feed")
+	}
+	feed = feed.renderView(profilePage)
+	actor := strings.TrimRight(strings.TrimSpace(opts.ActorID), "/")
+	if !isAbsoluteURL(actor) {
+		return nil, fmt.Errorf("activitystreams: actor id %q must be absolute", opts.ActorID)
+	}
+	objType := strings.TrimSpace(opts.ObjectType)
+	switch objType {
+	case "":
+		objType = "Note"
+	case "Note", "Article":
+	default:
+		return nil, fmt.Errorf("activitystreams: unsupported object type %q", objType)
+	}
+	c := &ASCollection{
+		Context:      activityStreamsContext,
+		ID:           firstNonEmpty(strings.TrimSpace(opts.OutboxID), actor+"/outbox"),
+		Type:         "OrderedCollection",
+		OrderedItems: []*ASActivity{},
+	}
+	var items []*Item
+	for _, it := range feed.Items {
+		if it != nil {
+			items = append(items, it)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return maxTime(items[i].Created, items[i].Updated).After(maxTime(items[j].Created, items[j].Updated))
+	})
+	for _, it := range items {
+		obj := newASObject(it, actor, objType)
+		c.OrderedItems = append(c.OrderedItems, &ASActivity{
+			ID:        obj.ID + "#create",
+			Type:      "Create",
+			Actor:     actor,
+			Published: obj.Published,
+			To:        obj.To,
+			Object:    obj,
+		})
+	}
+	c.TotalItems = len(c.OrderedItems)
+	return c, nil
+}
+
+func newASObject(it *Item, actor, objType string) *ASObject {
+	link := ""
+	if it.Link != nil {
+		link = strings.TrimSpace(it.Link.Href)
+	}
+	id := strings.TrimSpace(it.ID)
+	if !isAbsoluteURL(id) {
+		if isAbsoluteURL(link) {
+			id = link
+		} else {
+			id = actor + "/objects/" + url.PathEscape(firstNonEmpty(id, fallbackItemGuid(it)))
+		}
+	}
+	asTime := func(t time.Time) string { return anyTimeFormat(time.RFC3339, t.UTC()) }
+	o := &ASObject{
+		ID:           id,
+		Type:         objType,
+		AttributedTo: actor,
+		MediaType:    "text/html",
+		URL:          link,
+		Published:    asTime(it.Created),
+		To:           []string{activityStreamsPublic},
+	}
+	if !it.Updated.IsZero() && it.Updated.After(it.Created) {
+		o.Updated = asTime(it.Updated)
+	}
+	title := strings.TrimSpace(it.Title)
+	if objType == "Article" {
+		o.Name = title
+		o.Summary = strings.TrimSpace(it.Description)
+		o.Content = firstNonEmpty(strings.TrimSpace(it.Content), strings.TrimSpace(it.Description))
+	} else {
+		o.Content = firstNonEmpty(strings.TrimSpace(it.Content), strings.TrimSpace(it.Description))
+		if o.Content == "" && title != "" {
+			o.Content = "<p>" + html.EscapeString(title) + "</p>"
+		}
+	}
+	if e := it.Enclosure; e != nil && strings.TrimSpace(e.Url) != "" {
+		o.Attachment = append(o.Attachment, &ASAttachment{Type: asAttachmentType(e.Type), MediaType: strings.TrimSpace(e.Type), URL: strings.TrimSpace(e.Url)})
+	}
+	if img := strings.TrimSpace(it.ImageURL); img != "" {
+		o.Attachment = append(o.Attachment, &ASAttachment{Type: "Image", URL: img})
+	}
+	return o
+}
+
+// asAttachmentType maps a MIME type to an ActivityStreams object type.
+func asAttachmentType(mime string) string {
+	switch strings.ToLower(strings.TrimSpace(strings.SplitN(mime, "/", 2)[0])) {
+	case "audio":
+		return "Audio"
+	case "video":
+		return "Video"
+	case "image":
+		return "Image"
+	}
+	return "Document"
+}
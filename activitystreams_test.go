@@ -0,0 +1,55 @@
+package gofeedx_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestToActivityStreams(t *testing.T) {
+	base := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	f := &gofeedx.Feed{Title: "Blog", Items: []*gofeedx.Item{
+		{Title: "First", ID: "urn:first", Link: &gofeedx.Link{Href: "https://example.org/first"}, Description: "<p>one</p>", Created: base},
+		{Title: "Second & more", ID: "second", Created: base.Add(time.Hour),
+			Enclosure: &gofeedx.Enclosure{Url: "https://example.org/2.mp3", Type: "audio/mpeg", Length: 1}},
+	}}
+	out, err := gofeedx.ToActivityStreams(f, gofeedx.ActivityStreamsOptions{ActorID: "https://example.org/actor/"})
+	mustNoErr(t, err, "ToActivityStreams failed")
+	var c gofeedx.ASCollection
+	mustNoErr(t, json.Unmarshal([]byte(out), &c), "invalid JSON")
+	if c.Context != "https://www.w3.org/ns/activitystreams" || c.Type != "OrderedCollection" ||
+		c.ID != "https://example.org/actor/outbox" || c.TotalItems != 2 {
+		t.Fatalf("unexpected collection %+v", c)
+	}
+	newest := c.OrderedItems[0]
+	if newest.Type != "Create" || newest.Actor != "https://example.org/actor" || newest.Object.Type != "Note" {
+		t.Errorf("unexpected activity %+v", newest)
+	}
+	if newest.Object.ID != "https://example.org/actor/objects/second" || newest.ID != newest.Object.ID+"#create" {
+		t.Errorf("unexpected ids %q / %q", newest.ID, newest.Object.ID)
+	}
+	if newest.Object.Content != "<p>Second &amp; more</p>" {
+		t.Errorf("expected escaped title as content, got %q", newest.Object.Content)
+	}
+	if len(newest.Object.Attachment) != 1 || newest.Object.Attachment[0].Type != "Audio" {
+		t.Errorf("expected audio attachment, got %+v", newest.Object.Attachment)
+	}
+	older := c.OrderedItems[1].Object
+	if older.ID != "https://example.org/first" || older.Content != "<p>one</p>" || older.Published != "2024-05-01T08:00:00Z" {
+		t.Errorf("unexpected object %+v", older)
+	}
+
+	c2, err := gofeedx.NewActivityStreamsCollection(f, gofeedx.ActivityStreamsOptions{ActorID: "https://example.org/actor", ObjectType: "Article"})
+	mustNoErr(t, err, "NewActivityStreamsCollection failed")
+	if a := c2.OrderedItems[1].Object; a.Name != "First" || a.Summary != "<p>one</p>" {
+		t.Errorf("unexpected article %+v", a)
+	}
+
+	for _, opts := range []gofeedx.ActivityStreamsOptions{{}, {ActorID: "actor"}, {ActorID: "https://example.org/a", ObjectType: "Event"}} {
+		if _, err := gofeedx.ToActivityStreams(f, opts); err == nil {
+			t.Errorf("expected error for %+v", opts)
+		}
+	}
+}
@@ -2,14 +2,18 @@ package gofeedx
 
 import (
 	"encoding/xml"
-	"errors"
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
 	"time"
 )
 
 const atomNS = "http://www.w3.org/2005/Atom"
 
+// xhtmlNS is the namespace of the <div> wrapping xhtml text constructs (RFC 4287 3.1.1.3).
+const xhtmlNS = "http://www.w3.org/1999/xhtml"
+
 type AtomPerson struct {
 	Name  string `xml:"name,omitempty"`
 	Uri   string `xml:"uri,omitempty"`
@@ -39,17 +43,41 @@ type AtomContributor struct {
 }
 
 type AtomLink struct {
-	XMLName xml.Name `xml:"link"`
-	Href    string   `xml:"href,attr"`
-	Rel     string   `xml:"rel,attr,omitempty"`
-	Type    string   `xml:"type,attr,omitempty"`
-	Length  string   `xml:"length,attr,omitempty"`
+	XMLName  xml.Name `xml:"link"`
+	Href     string   `xml:"href,attr"`
+	Rel      string   `xml:"rel,attr,omitempty"`
+	Type     string   `xml:"type,attr,omitempty"`
+	Length   string   `xml:"length,attr,omitempty"`
+	Hreflang string   `xml:"hreflang,attr,omitempty"`
+	Title    string   `xml:"title,attr,omitempty"`
+
+	// RFC 4685 attributes for rel="replies" links
+	ThrCount   string `xml:"thr:count,attr,omitempty"`
+	ThrUpdated string `xml:"thr:updated,attr,omitempty"`
+}
+
+// AtomCategory is an Atom <category> (RFC 4287 4.2.2); term is required.
+type AtomCategory struct {
+	XMLName xml.Name `xml:"category"`
+	Term    string   `xml:"term,attr"`
+	Scheme  string   `xml:"scheme,attr,omitempty"`
+	Label   string   `xml:"label,attr,omitempty"`
+}
+
+// AtomSource is the <source> element of a republished entry (RFC 4287 4.2.11): metadata
+// of the feed the entry was copied from.
+type AtomSource struct {
+	XMLName xml.Name   `xml:"source"`
+	Id      string     `xml:"id,omitempty"`
+	Title   string     `xml:"title,omitempty"`
+	Updated string     `xml:"updated,omitempty"`
+	Links   []AtomLink // alternate and self links of the source feed
 }
 
 type AtomEntry struct {
 	Title       CData `xml:"title"` // required
 	Links       []AtomLink
-	Source      string      `xml:"source,omitempty"`
+	Source      *AtomSource
 	Author      *AtomAuthor // required if feed lacks an author
 	Summary     *AtomSummary
 	Content     *AtomContent
@@ -58,28 +86,40 @@ type AtomEntry struct {
 	Published   string   `xml:"published,omitempty"`
 	XMLName     xml.Name `xml:"entry"`
 	Xmlns       string   `xml:"xmlns,attr,omitempty"`
-	Category    CData    `xml:"category,omitempty"`
-	Rights      CData    `xml:"rights,omitempty"`
+	Lang        string   `xml:"xml:lang,attr,omitempty"`
+	Base        string   `xml:"xml:base,attr,omitempty"`
+	Categories  []AtomCategory
+	Rights      CData `xml:"rights,omitempty"`
 	Contributor *AtomContributor
+	Thumbnail   *MediaThumbnail // media:thumbnail from Item.ImageURL
 	Extra       []ExtensionNode `xml:",any"` // custom extension nodes
 }
 
 type AtomFeed struct {
 	Title       CData `xml:"title"` // required
 	Link        *AtomLink
+	Links       []AtomLink   // additional links (self, next, previous, hub, ...)
 	Subtitle    CData        `xml:"subtitle,omitempty"`
 	Author      *AtomAuthor  `xml:"author,omitempty"`
 	Updated     string       `xml:"updated"` // required
 	Id          string       `xml:"id"`      // required
 	Entries     []*AtomEntry `xml:"entry"`
-	Category    CData        `xml:"category,omitempty"`
-	Rights      CData        `xml:"rights,omitempty"` // copyright used
-	Logo        string       `xml:"logo,omitempty"`
-	XMLName     xml.Name     `xml:"feed"`
-	Xmlns       string       `xml:"xmlns,attr"`
-	Icon        string       `xml:"icon,omitempty"`
+	Categories  []AtomCategory
+	Rights      CData    `xml:"rights,omitempty"` // copyright used
+	Logo        string   `xml:"logo,omitempty"`
+	XMLName     xml.Name `xml:"feed"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	XmlnsGeoRSS string   `xml:"xmlns:georss,attr,omitempty"`
+	XmlnsThr    string   `xml:"xmlns:thr,attr,omitempty"`
+	XmlnsMedia  string   `xml:"xmlns:media,attr,omitempty"`
+	Lang        string   `xml:"xml:lang,attr,omitempty"`
+	Base        string   `xml:"xml:base,attr,omitempty"`
+	Icon        string   `xml:"icon,omitempty"`
+	Generator   *AtomGenerator
 	Contributor *AtomContributor
 	Extra       []ExtensionNode `xml:",any"` // custom extension nodes
+
+	entriesPlaceholder string // set by parallel encoding in place of Entries (see writeXMLParallel)
 }
 
 type Atom struct {
@@ -98,10 +138,7 @@ func (a *AtomFeed) FeedXml() interface{} {
 
 // ToAtom renders the feed to an Atom 1.0 string after validating ProfileAtom.
 func ToAtom(feed *Feed) (string, error) {
-	if feed == nil {
-		return "", errors.New("nil feed")
-	}
-	return ToXML(&Atom{feed})
+	return ToAtomWithOptions(feed, RenderOptions{})
 }
 
 // encodeAtomTypedElement encodes an element with a 'type' attribute.
@@ -109,11 +146,18 @@ func ToAtom(feed *Feed) (string, error) {
 // - When useCDATA is true and the value contains markup, emit CDATA.
 // - Otherwise, for non-empty values, emit raw inner XML (unescaped) so tests expecting <p>...</p> pass.
 // - For empty values, emit an empty element with the type attribute.
+// - type="text" is always written as escaped character data.
+// - type="xhtml" is written as markup inside a single XHTML <div>, never as CDATA.
 func encodeAtomTypedElement(e *xml.Encoder, name, typ, value string, useCDATA bool) error {
 	val := UnwrapCDATA(strings.TrimSpace(value))
-	start := xml.StartElement{
-		Name: xml.Name{Local: name},
-		Attr: []xml.Attr{{Name: xml.Name{Local: "type"}, Value: typ}},
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	switch typ {
+	case "text":
+		start.Attr = []xml.Attr{{Name: xml.Name{Local: "type"}, Value: typ}}
+		return e.EncodeElement(val, start)
+	case "xhtml":
+		useCDATA = false
+		val = wrapXHTMLDiv(val)
 	}
 	// CDATA path
 	if useCDATA && val != "" && strings.ContainsAny(val, "<&") {
@@ -142,6 +186,7 @@ func encodeAtomTypedElement(e *xml.Encoder, name, typ, value string, useCDATA bo
 		return e.Encode(tmp)
 	}
 	// Empty value: emit empty tag with type attr
+	start.Attr = []xml.Attr{{Name: xml.Name{Local: "type"}, Value: typ}}
 	if err := e.EncodeToken(start); err != nil {
 		return err
 	}
@@ -156,6 +201,16 @@ func (f *AtomFeed) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	if s := strings.TrimSpace(f.Xmlns); s != "" {
 		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xmlns"}, Value: s})
 	}
+	if s := strings.TrimSpace(f.XmlnsGeoRSS); s != "" {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xmlns:georss"}, Value: s})
+	}
+	if s := strings.TrimSpace(f.XmlnsThr); s != "" {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xmlns:thr"}, Value: s})
+	}
+	if s := strings.TrimSpace(f.XmlnsMedia); s != "" {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xmlns:media"}, Value: s})
+	}
+	start.Attr = appendXMLLangBase(start.Attr, f.Lang, f.Base)
 	use := UseCDATAFromExtensions(f.Extra)
 	if err := e.EncodeToken(start); err != nil {
 		return err
@@ -167,6 +222,11 @@ func (f *AtomFeed) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 			return err
 		}
 	}
+	for _, l := range f.Links {
+		if err := e.Encode(l); err != nil {
+			return err
+		}
+	}
 	_ = encodeElementCDATA(e, "subtitle", string(f.Subtitle), use)
 	if f.Author != nil {
 		if err := e.Encode(f.Author); err != nil {
@@ -180,18 +240,22 @@ func (f *AtomFeed) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		return err
 	}
 	// Entries with cascaded CDATA preference
+	if f.entriesPlaceholder != "" {
+		if err := e.EncodeToken(xml.Comment(f.entriesPlaceholder)); err != nil {
+			return err
+		}
+	}
 	for _, en := range f.Entries {
 		if en == nil {
 			continue
 		}
-		entryUse := CDATAUseForItem(use, en.Extra)
-		tmp := *en
-		tmp.Extra = WithCDATAOverride(en.Extra, entryUse)
-		if err := tmp.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "entry"}}); err != nil {
+		if err := en.marshalXML(e, xml.StartElement{}, CDATAUseForItem(use, en.Extra)); err != nil {
 			return err
 		}
 	}
-	_ = encodeElementCDATA(e, "category", string(f.Category), use)
+	if err := encodeAtomCategories(e, f.Categories); err != nil {
+		return err
+	}
 	_ = encodeElementCDATA(e, "rights", string(f.Rights), use)
 	if err := encodeElementIfSet(e, "logo", f.Logo); err != nil {
 		return err
@@ -199,6 +263,11 @@ func (f *AtomFeed) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	if err := encodeElementIfSet(e, "icon", f.Icon); err != nil {
 		return err
 	}
+	if f.Generator != nil {
+		if err := e.Encode(f.Generator); err != nil {
+			return err
+		}
+	}
 	if f.Contributor != nil {
 		if err := e.Encode(f.Contributor); err != nil {
 			return err
@@ -208,25 +277,31 @@ func (f *AtomFeed) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		if IsInternalExtensionName(n.Name) {
 			continue
 		}
-		if err := e.Encode(n); err != nil {
+		if err := n.MarshalXML(e, xml.StartElement{}); err != nil {
 			return err
 		}
 	}
 	if err := e.EncodeToken(start.End()); err != nil {
 		return err
 	}
-	return e.Flush()
+	return nil
 }
 
 // MarshalXML customizes Atom entry encoding to control CDATA for title/summary/content.
 func (en *AtomEntry) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return en.marshalXML(e, start, UseCDATAFromExtensions(en.Extra))
+}
+
+// marshalXML encodes the entry with an explicit CDATA preference, so the feed can cascade its
+// preference without copying the entry's extensions.
+func (en *AtomEntry) marshalXML(e *xml.Encoder, start xml.StartElement, use bool) error {
 	// Force correct element name
 	start.Name.Local = "entry"
 	// Preserve xmlns attribute when set
 	if s := strings.TrimSpace(en.Xmlns); s != "" {
 		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xmlns"}, Value: s})
 	}
-	use := UseCDATAFromExtensions(en.Extra)
+	start.Attr = appendXMLLangBase(start.Attr, en.Lang, en.Base)
 	if err := e.EncodeToken(start); err != nil {
 		return err
 	}
@@ -239,8 +314,10 @@ func (en *AtomEntry) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		}
 	}
 	// Source
-	if err := encodeElementIfSet(e, "source", en.Source); err != nil {
-		return err
+	if en.Source != nil {
+		if err := e.Encode(en.Source); err != nil {
+			return err
+		}
 	}
 	// Author
 	if en.Author != nil {
@@ -269,8 +346,10 @@ func (en *AtomEntry) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	if err := encodeElementIfSet(e, "published", en.Published); err != nil {
 		return err
 	}
-	// Category, Rights
-	_ = encodeElementCDATA(e, "category", string(en.Category), use)
+	// Categories, Rights
+	if err := encodeAtomCategories(e, en.Categories); err != nil {
+		return err
+	}
 	_ = encodeElementCDATA(e, "rights", string(en.Rights), use)
 	// Contributor
 	if en.Contributor != nil {
@@ -278,19 +357,79 @@ func (en *AtomEntry) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 			return err
 		}
 	}
+	if en.Thumbnail != nil {
+		if err := e.Encode(en.Thumbnail); err != nil {
+			return err
+		}
+	}
 	// Extra nodes
 	for _, n := range en.Extra {
 		if IsInternalExtensionName(n.Name) {
 			continue
 		}
-		if err := e.Encode(n); err != nil {
+		if err := n.MarshalXML(e, xml.StartElement{}); err != nil {
 			return err
 		}
 	}
 	if err := e.EncodeToken(start.End()); err != nil {
 		return err
 	}
-	return e.Flush()
+	return nil
+}
+
+// wrapXHTMLDiv wraps markup in the XHTML <div> required for type="xhtml" unless it already is one.
+func wrapXHTMLDiv(s string) string {
+	if strings.HasPrefix(s, "<div") && strings.Contains(s, xhtmlNS) {
+		return s
+	}
+	return `<div xmlns="` + xhtmlNS + `">` + s + `</div>`
+}
+
+// wellFormedXML reports whether s parses as a sequence of balanced XML tokens.
+func wellFormedXML(s string) bool {
+	d := xml.NewDecoder(strings.NewReader(s))
+	for {
+		_, err := d.Token()
+		if err == io.EOF {
+			return true
+		}
+		if err != nil {
+			return false
+		}
+	}
+}
+
+// atomTextType validates a text construct type, accepting "text", "html" and "xhtml".
+func atomTextType(t string) (string, bool) {
+	switch t = textLowerTrim(t); t {
+	case "text", "html", "xhtml":
+		return t, true
+	}
+	return "", false
+}
+
+// encodeAtomCategories writes categories that have a term.
+func encodeAtomCategories(e *xml.Encoder, cats []AtomCategory) error {
+	for _, c := range cats {
+		if strings.TrimSpace(c.Term) == "" {
+			continue
+		}
+		if err := e.Encode(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendXMLLangBase adds the xml:lang and xml:base attributes when set.
+func appendXMLLangBase(attrs []xml.Attr, lang, base string) []xml.Attr {
+	if s := strings.TrimSpace(lang); s != "" {
+		attrs = append(attrs, xml.Attr{Name: xml.Name{Local: "xml:lang"}, Value: s})
+	}
+	if s := strings.TrimSpace(base); s != "" {
+		attrs = append(attrs, xml.Attr{Name: xml.Name{Local: "xml:base"}, Value: s})
+	}
+	return attrs
 }
 
 // Helpers to reduce cyclomatic complexity
@@ -302,25 +441,24 @@ func atomFeedBaseFromFeed(a *Atom) *AtomFeed {
 		link = &Link{}
 	}
 	return &AtomFeed{
-		Xmlns:    atomNS,
-		Title:    CData(a.Title),
-		Link:     &AtomLink{Href: link.Href, Rel: "alternate"},
-		Subtitle: CData(a.Description),
-		Id:       firstNonEmpty(a.ID, link.Href),
-		Updated:  updated,
-		Rights:   CData(a.Copyright),
+		Xmlns:     atomNS,
+		Title:     CData(a.Title),
+		Link:      &AtomLink{Href: link.Href, Rel: "alternate"},
+		Subtitle:  CData(a.Description),
+		Id:        firstNonEmpty(a.ID, link.Href),
+		Updated:   updated,
+		Rights:    CData(feedRights(a.Feed)),
+		Generator: atomGeneratorFrom(a.Generator),
+		Lang:      strings.TrimSpace(a.Language),
 	}
 }
 
-func applyAtomImage(feed *AtomFeed, img *Image) {
-	if img == nil || img.Url == "" {
-		return
-	}
+func applyAtomImage(feed *AtomFeed, f *Feed) {
 	if feed.Logo == "" {
-		feed.Logo = img.Url
+		feed.Logo = feedLogoURL(f)
 	}
 	if feed.Icon == "" {
-		feed.Icon = img.Url
+		feed.Icon = feedIconURL(f)
 	}
 }
 
@@ -331,9 +469,16 @@ func setAtomAuthorFromFeed(feed *AtomFeed, author *Author) {
 	feed.Author = &AtomAuthor{AtomPerson: AtomPerson{Name: author.Name, Email: author.Email}}
 }
 
-func setFirstCategory(feed *AtomFeed, cats []*Category) {
-	if len(cats) > 0 && cats[0] != nil && cats[0].Text != "" {
-		feed.Category = CData(cats[0].Text)
+func setAtomCategories(feed *AtomFeed, cats []*Category) {
+	for _, c := range cats {
+		if c == nil || strings.TrimSpace(c.Text) == "" {
+			continue
+		}
+		feed.Categories = append(feed.Categories, AtomCategory{
+			Term:   strings.TrimSpace(c.Text),
+			Scheme: strings.TrimSpace(c.Scheme),
+			Label:  strings.TrimSpace(c.Label),
+		})
 	}
 }
 
@@ -343,6 +488,41 @@ func addEntriesToFeed(feed *AtomFeed, items []*Item) {
 	}
 }
 
+func applyAtomGeoRSSNamespace(feed *AtomFeed) {
+	for _, en := range feed.Entries {
+		if hasGeoRSSNodes(en.Extra) {
+			feed.XmlnsGeoRSS = xmlnsGeoRSS
+			return
+		}
+	}
+}
+
+// applyAtomMediaNamespace declares xmlns:media when an entry carries a media:thumbnail.
+func applyAtomMediaNamespace(feed *AtomFeed) {
+	for _, en := range feed.Entries {
+		if en.Thumbnail != nil {
+			feed.XmlnsMedia = xmlnsMedia
+			return
+		}
+	}
+}
+
+// applyAtomThreadNamespace declares xmlns:thr when entries carry threading elements or replies links.
+func applyAtomThreadNamespace(feed *AtomFeed) {
+	for _, en := range feed.Entries {
+		if usesThreadNamespace(en.Extra) {
+			feed.XmlnsThr = xmlnsThr
+			return
+		}
+		for _, l := range en.Links {
+			if l.ThrCount != "" || l.ThrUpdated != "" {
+				feed.XmlnsThr = xmlnsThr
+				return
+			}
+		}
+	}
+}
+
 func ensureAtomAuthorRequirement(feed *AtomFeed, items []*Item) {
 	if feed.Author != nil {
 		return
@@ -365,6 +545,13 @@ func mapAtomFeedExtensions(feed *AtomFeed, exts []ExtensionNode) {
 	}
 	type handler func(*AtomFeed, ExtensionNode) bool
 	handlers := map[string]handler{
+		"_atom:base": func(f *AtomFeed, n ExtensionNode) bool {
+			if s := strings.TrimSpace(n.Text); s != "" {
+				f.Base = s
+				return true
+			}
+			return false
+		},
 		"_atom:icon": func(f *AtomFeed, n ExtensionNode) bool {
 			if s := strings.TrimSpace(n.Text); s != "" {
 				f.Icon = s
@@ -406,12 +593,20 @@ func mapAtomFeedExtensions(feed *AtomFeed, exts []ExtensionNode) {
 				l.Rel = strings.TrimSpace(n.Attrs["rel"])
 				l.Type = strings.TrimSpace(n.Attrs["type"])
 				l.Length = strings.TrimSpace(n.Attrs["length"])
+				l.Title = strings.TrimSpace(n.Attrs["title"])
+				l.Hreflang = strings.TrimSpace(n.Attrs["hreflang"])
 			}
-			if l.Href != "" {
+			if l.Href == "" {
+				return false
+			}
+			// A plain alternate link replaces the default one; language or titled alternates
+			// and other relations are added alongside it
+			if (l.Rel == "" || strings.EqualFold(l.Rel, "alternate")) && l.Hreflang == "" && l.Title == "" {
 				f.Link = &l
-				return true
+			} else {
+				f.Links = append(f.Links, l)
 			}
-			return false
+			return true
 		},
 	}
 	var extras []ExtensionNode
@@ -434,16 +629,34 @@ func mapAtomFeedExtensions(feed *AtomFeed, exts []ExtensionNode) {
 }
 
 func (a *Atom) AtomFeed() *AtomFeed {
+	a = &Atom{a.renderView(ProfileAtom)}
 	feed := atomFeedBaseFromFeed(a)
-	applyAtomImage(feed, a.Image)
+	applyAtomImage(feed, a.Feed)
 	setAtomAuthorFromFeed(feed, a.Author)
-	setFirstCategory(feed, a.Categories)
+	setAtomCategories(feed, a.Categories)
 	addEntriesToFeed(feed, a.Items)
 	ensureAtomAuthorRequirement(feed, a.Items)
-	mapAtomFeedExtensions(feed, a.Extensions)
+	mapAtomFeedExtensions(feed, a.allExtensions())
+	applyAtomWebSubLinks(feed, a.allExtensions(), a.FeedURL)
+	applyAtomGeoRSSNamespace(feed)
+	applyAtomThreadNamespace(feed)
+	applyAtomMediaNamespace(feed)
 	return feed
 }
 
+// applyAtomWebSubLinks adds rel="hub" links and, unless one exists, a rel="self" link from feedURL.
+func applyAtomWebSubLinks(feed *AtomFeed, exts []ExtensionNode, feedURL string) {
+	for _, l := range feed.Links {
+		if strings.EqualFold(l.Rel, "self") {
+			feedURL = ""
+			break
+		}
+	}
+	for _, n := range websubLinkNodes(exts, feedURL) {
+		feed.Links = append(feed.Links, AtomLink{Href: n.Attrs["href"], Rel: n.Attrs["rel"]})
+	}
+}
+
 func atomEntryBase(i *Item) *AtomEntry {
 	id := strings.TrimSpace(i.ID)
 	if id == "" {
@@ -459,7 +672,9 @@ func atomEntryBase(i *Item) *AtomEntry {
 		Id:      id,
 		Updated: anyTimeFormat(time.RFC3339, i.Updated, i.Created),
 		Xmlns:   atomNS,
+		Lang:    strings.TrimSpace(i.Language),
 	}
+	x.Thumbnail = newMediaThumbnail(strings.TrimSpace(i.ImageURL))
 	// Published maps to item Created timestamp when available
 	if !i.Created.IsZero() {
 		x.Published = i.Created.Format(time.RFC3339)
@@ -488,67 +703,121 @@ func addEnclosureAndRelatedLinks(x *AtomEntry, i *Item) {
 	if i.Source != nil && i.Source.Href != "" {
 		x.Links = append(x.Links, AtomLink{Href: i.Source.Href, Rel: "related"})
 	}
+	addCommentLinks(x, i)
+}
+
+// addCommentLinks maps the item's comments page and feed to rel="replies" links (RFC 4685);
+// the comment count goes on the first of them as thr:count.
+func addCommentLinks(x *AtomEntry, i *Item) {
+	count := ""
+	if i.CommentCount != nil {
+		count = strconv.Itoa(*i.CommentCount)
+	}
+	if href := strings.TrimSpace(i.CommentsURL); href != "" {
+		x.Links = append(x.Links, AtomLink{Href: href, Rel: "replies", Type: "text/html", ThrCount: count})
+		count = ""
+	}
+	if href := strings.TrimSpace(i.CommentsFeedURL); href != "" {
+		x.Links = append(x.Links, AtomLink{Href: href, Rel: "replies", ThrCount: count})
+	}
+}
+
+// atomEntryExtensionHandlers maps entry-level markers (keyed by lowercased name) into the entry.
+// Each handler reports whether the node was valid and consumed. It is shared by all entries so
+// rendering large feeds does not rebuild the table per entry.
+var atomEntryExtensionHandlers = map[string]func(*AtomEntry, ExtensionNode) bool{
+	"_atom:base": func(en *AtomEntry, n ExtensionNode) bool {
+		if s := strings.TrimSpace(n.Text); s != "" {
+			en.Base = s
+			return true
+		}
+		return false
+	},
+	"_atom:summarytype": func(en *AtomEntry, n ExtensionNode) bool {
+		t, ok := atomTextType(n.Text)
+		if ok && en.Summary != nil {
+			en.Summary.Type = t
+		}
+		return ok
+	},
+	"_atom:contenttype": func(en *AtomEntry, n ExtensionNode) bool {
+		t, ok := atomTextType(n.Text)
+		if ok && en.Content != nil {
+			en.Content.Type = t
+		}
+		return ok
+	},
+	"_atom:category": func(en *AtomEntry, n ExtensionNode) bool {
+		if s := strings.TrimSpace(n.Text); s != "" {
+			en.Categories = append(en.Categories, AtomCategory{Term: s, Scheme: attrTrim(n.Attrs, "scheme"), Label: attrTrim(n.Attrs, "label")})
+			return true
+		}
+		return false
+	},
+	"_atom:rights": func(en *AtomEntry, n ExtensionNode) bool {
+		if s := strings.TrimSpace(n.Text); s != "" {
+			en.Rights = CData(s)
+			return true
+		}
+		return false
+	},
+	"_atom:contributor": func(en *AtomEntry, n ExtensionNode) bool {
+		var ap AtomPerson
+		if n.Attrs != nil {
+			ap.Name = strings.TrimSpace(n.Attrs["name"])
+			ap.Email = strings.TrimSpace(n.Attrs["email"])
+			ap.Uri = strings.TrimSpace(n.Attrs["uri"])
+		}
+		if ap.Name != "" || ap.Email != "" || ap.Uri != "" {
+			en.Contributor = &AtomContributor{AtomPerson: ap}
+			return true
+		}
+		return false
+	},
+	"_atom:link": func(en *AtomEntry, n ExtensionNode) bool {
+		var l AtomLink
+		if n.Attrs != nil {
+			l.Href = strings.TrimSpace(n.Attrs["href"])
+			l.Rel = strings.TrimSpace(n.Attrs["rel"])
+			l.Type = strings.TrimSpace(n.Attrs["type"])
+			l.Length = strings.TrimSpace(n.Attrs["length"])
+			l.Title = strings.TrimSpace(n.Attrs["title"])
+			l.Hreflang = strings.TrimSpace(n.Attrs["hreflang"])
+		}
+		if l.Href != "" {
+			en.Links = append(en.Links, l)
+			return true
+		}
+		return false
+	},
+	threadRepliesMarker: func(en *AtomEntry, n ExtensionNode) bool {
+		l, ok := repliesAtomLink(n)
+		if ok {
+			en.Links = append(en.Links, l)
+		}
+		return ok
+	},
+	"_atom:source": func(en *AtomEntry, n ExtensionNode) bool {
+		s := strings.TrimSpace(n.Text)
+		if s == "" {
+			return false
+		}
+		if en.Source == nil {
+			en.Source = &AtomSource{}
+		}
+		en.Source.Title = s
+		return true
+	},
 }
 
 func mapAtomEntryExtensions(x *AtomEntry, exts []ExtensionNode) {
 	if len(exts) == 0 {
 		return
 	}
-	type handler func(*AtomEntry, ExtensionNode) bool
-	handlers := map[string]handler{
-		"_atom:category": func(en *AtomEntry, n ExtensionNode) bool {
-			if s := strings.TrimSpace(n.Text); s != "" {
-				en.Category = CData(s)
-				return true
-			}
-			return false
-		},
-		"_atom:rights": func(en *AtomEntry, n ExtensionNode) bool {
-			if s := strings.TrimSpace(n.Text); s != "" {
-				en.Rights = CData(s)
-				return true
-			}
-			return false
-		},
-		"_atom:contributor": func(en *AtomEntry, n ExtensionNode) bool {
-			var ap AtomPerson
-			if n.Attrs != nil {
-				ap.Name = strings.TrimSpace(n.Attrs["name"])
-				ap.Email = strings.TrimSpace(n.Attrs["email"])
-				ap.Uri = strings.TrimSpace(n.Attrs["uri"])
-			}
-			if ap.Name != "" || ap.Email != "" || ap.Uri != "" {
-				en.Contributor = &AtomContributor{AtomPerson: ap}
-				return true
-			}
-			return false
-		},
-		"_atom:link": func(en *AtomEntry, n ExtensionNode) bool {
-			var l AtomLink
-			if n.Attrs != nil {
-				l.Href = strings.TrimSpace(n.Attrs["href"])
-				l.Rel = strings.TrimSpace(n.Attrs["rel"])
-				l.Type = strings.TrimSpace(n.Attrs["type"])
-				l.Length = strings.TrimSpace(n.Attrs["length"])
-			}
-			if l.Href != "" {
-				en.Links = append(en.Links, l)
-				return true
-			}
-			return false
-		},
-		"_atom:source": func(en *AtomEntry, n ExtensionNode) bool {
-			if s := strings.TrimSpace(n.Text); s != "" {
-				en.Source = s
-				return true
-			}
-			return false
-		},
-	}
 	var extras []ExtensionNode
 	for _, n := range exts {
 		name := strings.TrimSpace(strings.ToLower(n.Name))
-		if h, ok := handlers[name]; ok {
+		if h, ok := atomEntryExtensionHandlers[name]; ok {
 			if h(x, n) {
 				continue
 			}
@@ -564,10 +833,30 @@ func mapAtomEntryExtensions(x *AtomEntry, exts []ExtensionNode) {
 	}
 }
 
+// newAtomSource maps Item.SourceFeed to an Atom <source> element.
+func newAtomSource(src *SourceFeed) *AtomSource {
+	if src == nil {
+		return nil
+	}
+	s := &AtomSource{
+		Id:      strings.TrimSpace(src.ID),
+		Title:   strings.TrimSpace(src.Title),
+		Updated: anyTimeFormat(time.RFC3339, src.Updated),
+	}
+	if href := strings.TrimSpace(src.Link); href != "" {
+		s.Links = append(s.Links, AtomLink{Href: href, Rel: "alternate"})
+	}
+	if href := strings.TrimSpace(src.FeedURL); href != "" {
+		s.Links = append(s.Links, AtomLink{Href: href, Rel: "self"})
+	}
+	return s
+}
+
 func newAtomEntry(i *Item) *AtomEntry {
 	x := atomEntryBase(i)
+	x.Source = newAtomSource(i.SourceFeed)
 	addEnclosureAndRelatedLinks(x, i)
-	mapAtomEntryExtensions(x, i.Extensions)
+	mapAtomEntryExtensions(x, i.allExtensions())
 	return x
 }
 
@@ -587,44 +876,69 @@ func ValidateAtom(f *Feed) error {
 	if err := validateAtomEntries(f); err != nil {
 		return err
 	}
-	return validateAtomAuthorRequirement(f)
+	return validateAtomAuthorRequirement(ProfileAtom, f)
 }
 
 func validateAtomFeedLevel(f *Feed) error {
 	if strings.TrimSpace(f.Title) == "" {
-		return errors.New("atom: feed title required")
+		return feedError(ProfileAtom, "Title", ErrMissingTitle, "atom: feed title required")
 	}
 	if f.Updated.IsZero() && f.Created.IsZero() {
-		return errors.New("atom: feed updated timestamp required (use Feed.Updated or Feed.Created)")
+		return feedError(ProfileAtom, "Updated", ErrMissingUpdated, "atom: feed updated timestamp required (use Feed.Updated or Feed.Created)")
 	}
 	if strings.TrimSpace(f.ID) == "" && (f.Link == nil || strings.TrimSpace(f.Link.Href) == "") {
-		return errors.New("atom: feed id required (set Feed.ID or Link.Href)")
+		return feedError(ProfileAtom, "ID", ErrMissingID, "atom: feed id required (set Feed.ID or Link.Href)")
 	}
-	return nil
+	return validateLanguageIfSet(ProfileAtom, -1, f.Language)
 }
 
 func validateAtomEntries(f *Feed) error {
 	if len(f.Items) == 0 {
-		return errors.New("atom: at least one entry required")
+		return feedError(ProfileAtom, "Items", ErrMissingItems, "atom: at least one entry required")
 	}
 	for i, it := range f.Items {
 		if strings.TrimSpace(it.Title) == "" {
-			return fmt.Errorf("atom: entry[%d] title required", i)
+			return itemError(ProfileAtom, i, "Title", ErrMissingTitle, "atom: entry[%d] title required", i)
 		}
 		if it.Updated.IsZero() && it.Created.IsZero() {
-			return fmt.Errorf("atom: entry[%d] updated timestamp required (use Item.Updated or Item.Created)", i)
+			return itemError(ProfileAtom, i, "Updated", ErrMissingUpdated, "atom: entry[%d] updated timestamp required (use Item.Updated or Item.Created)", i)
+		}
+		if err := validateLanguageIfSet(ProfileAtom, i, it.Language); err != nil {
+			return err
+		}
+		if err := validateAtomXHTML(i, it); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
-func validateAtomAuthorRequirement(f *Feed) error {
+// validateAtomXHTML checks that summary/content selected as type="xhtml" is well-formed XML.
+func validateAtomXHTML(i int, it *Item) error {
+	for _, n := range it.allExtensions() {
+		var field, itemField, value string
+		switch textLowerTrim(n.Name) {
+		case "_atom:summarytype":
+			field, itemField, value = "summary", "Description", it.Description
+		case "_atom:contenttype":
+			field, itemField, value = "content", "Content", it.Content
+		default:
+			continue
+		}
+		if textLowerTrim(n.Text) == "xhtml" && !wellFormedXML(wrapXHTMLDiv(UnwrapCDATA(strings.TrimSpace(value)))) {
+			return itemError(ProfileAtom, i, itemField, ErrInvalidContent, "atom: entry[%d] xhtml %s is not well-formed XML", i, field)
+		}
+	}
+	return nil
+}
+
+func validateAtomAuthorRequirement(p Profile, f *Feed) error {
 	if f.Author != nil && (strings.TrimSpace(f.Author.Name) != "" || strings.TrimSpace(f.Author.Email) != "") {
 		return nil
 	}
 	for _, it := range f.Items {
 		if it.Author == nil || (strings.TrimSpace(it.Author.Name) == "" && strings.TrimSpace(it.Author.Email) == "") {
-			return errors.New("atom: feed must contain an author or all entries must contain an author (RFC 4287 4.2.1)")
+			return feedError(p, "Author", ErrMissingAuthor, "atom: feed must contain an author or all entries must contain an author (RFC 4287 4.2.1)")
 		}
 	}
 	return nil
@@ -639,7 +953,7 @@ func (b *FeedBuilder) WithAtomIcon(url string) *FeedBuilder {
 	if url == "" {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_atom:icon", Text: url})
+	return b.withOption(ExtensionNode{Name: "_atom:icon", Text: url})
 }
 
 // WithAtomLogo sets feed-level logo override.
@@ -648,7 +962,7 @@ func (b *FeedBuilder) WithAtomLogo(url string) *FeedBuilder {
 	if url == "" {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_atom:logo", Text: url})
+	return b.withOption(ExtensionNode{Name: "_atom:logo", Text: url})
 }
 
 // WithAtomRights sets feed-level rights text (copyright override).
@@ -657,7 +971,7 @@ func (b *FeedBuilder) WithAtomRights(text string) *FeedBuilder {
 	if text == "" {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_atom:rights", Text: text})
+	return b.withOption(ExtensionNode{Name: "_atom:rights", Text: text})
 }
 
 // WithAtomContributor sets a feed-level contributor.
@@ -675,39 +989,107 @@ func (b *FeedBuilder) WithAtomContributor(name, email, uri string) *FeedBuilder
 	if len(attrs) == 0 {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_atom:contributor", Attrs: attrs})
+	return b.withOption(ExtensionNode{Name: "_atom:contributor", Attrs: attrs})
 }
 
-// WithAtomFeedLink overrides/adds the primary feed link with attributes.
+// WithAtomFeedLink overrides the primary feed link when rel is empty or "alternate";
+// links with any other rel (e.g. "self", "next") are added alongside it.
 func (b *FeedBuilder) WithAtomFeedLink(href, rel, typ, length string) *FeedBuilder {
-	attrs := map[string]string{}
-	if s := strings.TrimSpace(href); s != "" {
-		attrs["href"] = s
-	}
-	if s := strings.TrimSpace(rel); s != "" {
-		attrs["rel"] = s
-	}
-	if s := strings.TrimSpace(typ); s != "" {
-		attrs["type"] = s
+	return b.WithAtomFeedLinkAttrs(AtomLink{Href: href, Rel: rel, Type: typ, Length: length})
+}
+
+// WithAtomFeedLinkAttrs is WithAtomFeedLink with every link attribute, including the
+// optional title and hreflang (RFC 4287 4.2.7). An alternate link with a title or hreflang
+// is added alongside the primary link, so one call per language yields multilingual
+// alternate links.
+func (b *FeedBuilder) WithAtomFeedLinkAttrs(l AtomLink) *FeedBuilder {
+	attrs := atomLinkAttrs(l)
+	if len(attrs) == 0 {
+		return b
 	}
-	if s := strings.TrimSpace(length); s != "" {
-		attrs["length"] = s
+	return b.withOption(ExtensionNode{Name: "_atom:link", Attrs: attrs})
+}
+
+// atomLinkAttrs returns the non-empty attributes of l for an _atom:link option.
+func atomLinkAttrs(l AtomLink) map[string]string {
+	attrs := map[string]string{}
+	for name, v := range map[string]string{"href": l.Href, "rel": l.Rel, "type": l.Type, "length": l.Length, "title": l.Title, "hreflang": l.Hreflang} {
+		if s := strings.TrimSpace(v); s != "" {
+			attrs[name] = s
+		}
 	}
-	if len(attrs) == 0 {
+	return attrs
+}
+
+// WithAtomBase sets xml:base on the Atom feed element so relative hrefs in links and in
+// HTML summary/content resolve against uri in readers. uri must be absolute; anything else
+// is reported by Build in strict mode.
+func (b *FeedBuilder) WithAtomBase(uri string) *FeedBuilder {
+	uri = strings.TrimSpace(uri)
+	if !isAbsoluteURL(uri) {
+		b.errs = append(b.errs, fmt.Errorf("atom: xml:base %q must be an absolute URL", uri))
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_atom:link", Attrs: attrs})
+	return b.withOption(ExtensionNode{Name: "_atom:base", Text: uri})
 }
 
 // Item-level helpers:
 
-// WithAtomCategory sets entry category.
+// WithAtomBase sets xml:base on the entry; relative references resolve against the feed's
+// xml:base, so uri may be relative when the feed declares one.
+func (b *ItemBuilder) WithAtomBase(uri string) *ItemBuilder {
+	uri = strings.TrimSpace(uri)
+	if uri == "" {
+		return b
+	}
+	return b.withOption(ExtensionNode{Name: "_atom:base", Text: uri})
+}
+
+// WithAtomSummaryType selects the Atom text construct type of the entry summary (from
+// Description): "text", "html" (the default) or "xhtml". xhtml summaries are wrapped in an
+// XHTML <div> and must be well-formed XML. Other values are reported by Build in strict mode.
+func (b *ItemBuilder) WithAtomSummaryType(t string) *ItemBuilder {
+	return b.withAtomTextType("_atom:summaryType", t)
+}
+
+// WithAtomContentType selects the Atom text construct type of the entry content (from
+// Content); see WithAtomSummaryType.
+func (b *ItemBuilder) WithAtomContentType(t string) *ItemBuilder {
+	return b.withAtomTextType("_atom:contentType", t)
+}
+
+func (b *ItemBuilder) withAtomTextType(marker, t string) *ItemBuilder {
+	typ, ok := atomTextType(t)
+	if !ok {
+		b.errs = append(b.errs, fmt.Errorf("atom: text type %q must be \"text\", \"html\" or \"xhtml\"", t))
+		return b
+	}
+	return b.withOption(ExtensionNode{Name: marker, Text: typ})
+}
+
+// WithAtomCategory adds an entry category with the given term.
 func (b *ItemBuilder) WithAtomCategory(text string) *ItemBuilder {
-	text = strings.TrimSpace(text)
-	if text == "" {
+	return b.WithAtomCategoryTerm(text, "", "")
+}
+
+// WithAtomCategoryTerm adds an entry category with term and optional scheme IRI and label.
+func (b *ItemBuilder) WithAtomCategoryTerm(term, scheme, label string) *ItemBuilder {
+	term = strings.TrimSpace(term)
+	if term == "" {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_atom:category", Text: text})
+	n := ExtensionNode{Name: "_atom:category", Text: term}
+	attrs := map[string]string{}
+	if s := strings.TrimSpace(scheme); s != "" {
+		attrs["scheme"] = s
+	}
+	if s := strings.TrimSpace(label); s != "" {
+		attrs["label"] = s
+	}
+	if len(attrs) > 0 {
+		n.Attrs = attrs
+	}
+	return b.withOption(n)
 }
 
 // WithAtomRights sets entry rights.
@@ -716,7 +1098,7 @@ func (b *ItemBuilder) WithAtomRights(text string) *ItemBuilder {
 	if text == "" {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_atom:rights", Text: text})
+	return b.withOption(ExtensionNode{Name: "_atom:rights", Text: text})
 }
 
 // WithAtomContributor sets entry-level contributor.
@@ -734,35 +1116,30 @@ func (b *ItemBuilder) WithAtomContributor(name, email, uri string) *ItemBuilder
 	if len(attrs) == 0 {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_atom:contributor", Attrs: attrs})
+	return b.withOption(ExtensionNode{Name: "_atom:contributor", Attrs: attrs})
 }
 
 // WithAtomLink appends an additional link to the entry.
 func (b *ItemBuilder) WithAtomLink(href, rel, typ, length string) *ItemBuilder {
-	attrs := map[string]string{}
-	if s := strings.TrimSpace(href); s != "" {
-		attrs["href"] = s
-	}
-	if s := strings.TrimSpace(rel); s != "" {
-		attrs["rel"] = s
-	}
-	if s := strings.TrimSpace(typ); s != "" {
-		attrs["type"] = s
-	}
-	if s := strings.TrimSpace(length); s != "" {
-		attrs["length"] = s
-	}
+	return b.WithAtomLinkAttrs(AtomLink{Href: href, Rel: rel, Type: typ, Length: length})
+}
+
+// WithAtomLinkAttrs is WithAtomLink with every link attribute, including the optional title
+// and hreflang (RFC 4287 4.2.7), e.g. for multilingual alternate links.
+func (b *ItemBuilder) WithAtomLinkAttrs(l AtomLink) *ItemBuilder {
+	attrs := atomLinkAttrs(l)
 	if len(attrs) == 0 {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_atom:link", Attrs: attrs})
+	return b.withOption(ExtensionNode{Name: "_atom:link", Attrs: attrs})
 }
 
-// WithAtomSource sets the entry source.
+// WithAtomSource sets the title of the entry's <source>; use ItemBuilder.WithSourceFeed
+// for the full source metadata (id, links, updated).
 func (b *ItemBuilder) WithAtomSource(src string) *ItemBuilder {
 	src = strings.TrimSpace(src)
 	if src == "" {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_atom:source", Text: src})
+	return b.withOption(ExtensionNode{Name: "_atom:source", Text: src})
 }
@@ -483,7 +483,7 @@ func TestAtomBuilderHelpers_ItemLevelMapping(t *testing.T) {
 	}
 
 	// Assert mapped entry fields
-	if !strings.Contains(xmlStr, "<category>Cat</category>") {
+	if !strings.Contains(xmlStr, `<category term="Cat"></category>`) {
 		t.Errorf("expected Atom entry category from WithAtomCategory")
 	}
 	if !strings.Contains(xmlStr, "<rights>All rights</rights>") {
@@ -498,7 +498,7 @@ func TestAtomBuilderHelpers_ItemLevelMapping(t *testing.T) {
 	if !strings.Contains(xmlStr, `<link href="https://example.org/more" rel="related" type="text/html" length="5"`) {
 		t.Errorf("expected Atom entry link from WithAtomLink")
 	}
-	if !strings.Contains(xmlStr, "<source>SourceName</source>") {
+	if !strings.Contains(xmlStr, "<source>") || !strings.Contains(xmlStr, "<title>SourceName</title>") {
 		t.Errorf("expected Atom entry source from WithAtomSource")
 	}
 }
@@ -636,7 +636,7 @@ func TestAtomFeedCategoryFromGenericMapping(t *testing.T) {
 	if err != nil {
 		t.Fatalf("ToAtom failed: %v", err)
 	}
-	if !strings.Contains(xmlStr, "<category>Tech</category>") {
+	if !strings.Contains(xmlStr, `<category term="Tech"></category>`) {
 		t.Errorf("expected Atom feed category mapped from first generic category")
 	}
 }
@@ -716,3 +716,168 @@ func TestAtomTypedSummaryEscapedWhenCDATAFalse(t *testing.T) {
 		t.Errorf("expected escaped chardata when CDATA disabled, got: %s", s)
 	}
 }
+
+func TestAtomLinks_TitleAndHreflang(t *testing.T) {
+	now := time.Now().UTC()
+	f, err := gofeedx.NewFeed("T").
+		WithLink("https://example.org/").
+		WithAuthor("A", "").
+		WithAtomFeedLinkAttrs(gofeedx.AtomLink{Href: "https://example.org/de/", Rel: "alternate", Type: "text/html", Title: "Deutsch", Hreflang: "de"}).
+		AddItem(gofeedx.NewItem("E").
+			WithCreated(now).
+			WithAtomLinkAttrs(gofeedx.AtomLink{Href: "https://example.org/fr/e", Rel: "alternate", Type: "text/html", Title: "Version française", Hreflang: "fr"})).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() unexpected error: %v", err)
+	}
+	xmlStr, err := gofeedx.ToAtom(f)
+	if err != nil {
+		t.Fatalf("ToAtom failed: %v", err)
+	}
+	if !strings.Contains(xmlStr, `<link href="https://example.org/de/" rel="alternate" type="text/html" hreflang="de" title="Deutsch"></link>`) {
+		t.Errorf("expected feed link with hreflang/title, got:\n%s", xmlStr)
+	}
+	if !strings.Contains(xmlStr, `<link href="https://example.org/fr/e" rel="alternate" type="text/html" hreflang="fr" title="Version française"></link>`) {
+		t.Errorf("expected entry link with hreflang/title, got:\n%s", xmlStr)
+	}
+}
+
+func TestAtomFeedLinks_MultilingualAlternates(t *testing.T) {
+	f, err := gofeedx.NewFeed("T").
+		WithLink("https://example.org/").
+		WithAuthor("A", "").
+		WithAtomFeedLinkAttrs(gofeedx.AtomLink{Href: "https://example.org/de/", Rel: "alternate", Type: "text/html", Hreflang: "de"}).
+		WithAtomFeedLinkAttrs(gofeedx.AtomLink{Href: "https://example.org/fr/", Rel: "alternate", Type: "text/html", Hreflang: "fr"}).
+		AddItem(gofeedx.NewItem("E").WithCreated(time.Now().UTC())).
+		Build()
+	mustNoErr(t, err, "Build failed")
+	xmlStr, err := gofeedx.ToAtom(f)
+	mustNoErr(t, err, "ToAtom failed")
+	mustContain(t, xmlStr, `<link href="https://example.org/" rel="alternate"></link>`, "expected the primary link to be kept")
+	mustContain(t, xmlStr, `<link href="https://example.org/de/" rel="alternate" type="text/html" hreflang="de"></link>`, "expected German alternate link")
+	mustContain(t, xmlStr, `<link href="https://example.org/fr/" rel="alternate" type="text/html" hreflang="fr"></link>`, "expected French alternate link")
+}
+
+func TestAtomXMLLangAndBase(t *testing.T) {
+	f, err := gofeedx.NewFeed("T").
+		WithID("urn:x").
+		WithLanguage("de-DE").
+		WithAtomBase("https://example.org/blog/").
+		WithAuthor("A", "").
+		WithUpdated(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)).
+		AddItem(gofeedx.NewItem("I").
+			WithID("i1").
+			WithLink("posts/1").
+			WithAtomBase("posts/").
+			WithContentHTML(`<a href="img.png">x</a>`).
+			WithCreated(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))).
+		WithProfiles(gofeedx.ProfileAtom).
+		Build()
+	mustNoErr(t, err, "Build failed")
+	xml, err := gofeedx.ToAtom(f)
+	mustNoErr(t, err, "ToAtom failed")
+	mustContain(t, xml, `<feed xmlns="http://www.w3.org/2005/Atom" xml:lang="de-DE" xml:base="https://example.org/blog/">`, "expected xml:lang and xml:base on feed")
+	mustContain(t, xml, `xml:base="posts/">`, "expected xml:base on entry")
+	mustContain(t, xml, `href="posts/1"`, "relative link should be kept for xml:base resolution")
+
+	_, err = gofeedx.NewFeed("T").WithAtomBase("/blog/").Build()
+	mustErr(t, err, "expected error for relative feed xml:base")
+
+	f.Language = "not a tag"
+	mustErr(t, gofeedx.ValidateAtom(f), "expected invalid feed language to be rejected")
+}
+
+func TestAtomEntrySourceFeed(t *testing.T) {
+	f := newAtomBaseFeed()
+	it, err := gofeedx.NewItem("Republished").
+		WithID("urn:entry:1").
+		WithCreated(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)).
+		WithSourceFeed(gofeedx.SourceFeed{
+			ID:      "urn:origin",
+			Title:   "Origin Blog",
+			Link:    "https://origin.example.org/",
+			FeedURL: "https://origin.example.org/atom.xml",
+			Updated: time.Date(2024, 4, 30, 12, 0, 0, 0, time.UTC),
+		}).
+		Build()
+	mustNoErr(t, err, "item Build failed")
+	f.Items = append(f.Items, it)
+
+	xmlStr, err := gofeedx.ToAtom(f)
+	mustNoErr(t, err, "ToAtom failed")
+	var doc struct {
+		Entries []struct {
+			Source struct {
+				ID      string `xml:"id"`
+				Title   string `xml:"title"`
+				Updated string `xml:"updated"`
+				Links   []struct {
+					Href string `xml:"href,attr"`
+					Rel  string `xml:"rel,attr"`
+				} `xml:"link"`
+			} `xml:"source"`
+		} `xml:"entry"`
+	}
+	mustNoErr(t, xml.Unmarshal([]byte(xmlStr), &doc), "unmarshal")
+	src := doc.Entries[0].Source
+	if src.ID != "urn:origin" || src.Title != "Origin Blog" || src.Updated != "2024-04-30T12:00:00Z" {
+		t.Fatalf("unexpected source metadata: %+v", src)
+	}
+	if len(src.Links) != 2 || src.Links[0].Rel != "alternate" || src.Links[1].Rel != "self" || src.Links[1].Href != "https://origin.example.org/atom.xml" {
+		t.Fatalf("unexpected source links: %+v", src.Links)
+	}
+}
+
+func TestAtomCategoriesTermSchemeLabel(t *testing.T) {
+	f, err := gofeedx.NewFeed("T").
+		WithID("urn:x").
+		WithAuthor("A", "").
+		WithUpdated(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)).
+		WithCategory("go", "https://example.org/tags", "Go").
+		WithCategory("feeds", "", "").
+		AddItem(gofeedx.NewItem("I").
+			WithID("i1").
+			WithCreated(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)).
+			WithAtomCategoryTerm("howto", "https://example.org/kinds", "How-to").
+			WithAtomCategory("news")).
+		WithProfiles(gofeedx.ProfileAtom).
+		Build()
+	mustNoErr(t, err, "Build failed")
+	xmlStr, err := gofeedx.ToAtom(f)
+	mustNoErr(t, err, "ToAtom failed")
+	mustContain(t, xmlStr, `<category term="go" scheme="https://example.org/tags" label="Go"></category>`, "expected feed category with scheme and label")
+	mustContain(t, xmlStr, `<category term="feeds"></category>`, "expected every feed category")
+	mustContain(t, xmlStr, `<category term="howto" scheme="https://example.org/kinds" label="How-to"></category>`, "expected entry category with scheme and label")
+	mustContain(t, xmlStr, `<category term="news"></category>`, "expected multiple entry categories")
+}
+
+func TestAtomTextConstructTypes(t *testing.T) {
+	build := func(ib *gofeedx.ItemBuilder) (string, error) {
+		f, err := gofeedx.NewFeed("T").
+			WithID("urn:x").
+			WithAuthor("A", "").
+			WithUpdated(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)).
+			AddItem(ib.WithID("i1").WithCreated(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))).
+			WithProfiles(gofeedx.ProfileAtom).
+			Build()
+		if err != nil {
+			return "", err
+		}
+		return gofeedx.ToAtom(f)
+	}
+
+	xmlStr, err := build(gofeedx.NewItem("I").
+		WithDescription("1 < 2 & plain").
+		WithAtomSummaryType("text").
+		WithContentHTML("<p>Hello<br/></p>").
+		WithAtomContentType("xhtml"))
+	mustNoErr(t, err, "render text/xhtml entry")
+	mustContain(t, xmlStr, `<summary type="text">1 &lt; 2 &amp; plain</summary>`, "expected escaped text summary")
+	mustContain(t, xmlStr, `<content type="xhtml"><div xmlns="http://www.w3.org/1999/xhtml"><p>Hello<br/></p></div></content>`, "expected xhtml content wrapped in div")
+
+	_, err = build(gofeedx.NewItem("I").WithContentHTML("<p>unclosed").WithAtomContentType("xhtml"))
+	mustErr(t, err, "expected malformed xhtml content to be rejected")
+
+	_, err = gofeedx.NewItem("I").WithAtomSummaryType("markdown").Build()
+	mustErr(t, err, "expected error for unknown text type")
+}
@@ -0,0 +1,90 @@
+package gofeedx_test
+
+import (
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+// newBenchFeed builds a feed with n items carrying the fields most feeds set.
+func newBenchFeed(b *testing.B, n int) *gofeedx.Feed {
+	b.Helper()
+	fb := gofeedx.NewFeed("Bench").
+		WithLink("https://example.com/").
+		WithDescription("Benchmark feed").
+		WithAuthor("Host", "host@example.com").
+		WithFeedURL("https://example.com/feed.xml").
+		WithLanguage("en").
+		WithLenient()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		fb.AddItem(gofeedx.NewItem(fmt.Sprintf("Episode %d", i)).
+			WithID(fmt.Sprintf("ep-%d", i)).
+			WithLink(fmt.Sprintf("https://example.com/ep/%d", i)).
+			WithCreated(base.Add(time.Duration(i)*time.Hour)).
+			WithDescription("A <b>short</b> description & notes").
+			WithContentHTML("<p>Show notes</p>").
+			WithEnclosure(fmt.Sprintf("https://cdn.example.com/ep%d.mp3", i), 12345678, "audio/mpeg").
+			WithDurationSeconds(1800).
+			WithExtensions(gofeedx.ExtensionNode{Name: "podcast:episode", Text: fmt.Sprint(i + 1)}))
+	}
+	f, err := fb.Build()
+	if err != nil {
+		b.Fatalf("Build: %v", err)
+	}
+	return f
+}
+
+func benchmarkRender(b *testing.B, render func(*gofeedx.Feed) (string, error)) {
+	f := newBenchFeed(b, 10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := render(f); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkToRSS10k(b *testing.B)  { benchmarkRender(b, gofeedx.ToRSS) }
+func BenchmarkToAtom10k(b *testing.B) { benchmarkRender(b, gofeedx.ToAtom) }
+func BenchmarkToPSP10k(b *testing.B)  { benchmarkRender(b, gofeedx.ToPSP) }
+func BenchmarkToJSON10k(b *testing.B) { benchmarkRender(b, gofeedx.ToJSON) }
+
+func BenchmarkEncoderRSS10k(b *testing.B) {
+	f := newBenchFeed(b, 10000)
+	enc := gofeedx.NewEncoder()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := enc.EncodeRSS(f, io.Discard); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncoderRSS10kParallel(b *testing.B) {
+	f := newBenchFeed(b, 10000)
+	enc := gofeedx.NewEncoderWithOptions(gofeedx.EncodeOptions{Parallelism: -1})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := enc.EncodeRSS(f, io.Discard); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkWriteJSONStream10k(b *testing.B) {
+	f := newBenchFeed(b, 10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := gofeedx.WriteJSONStream(f, io.Discard); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package gofeedx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// maxBootstrapBody caps how much of a site's HTML is read by BootstrapFromSite.
+const maxBootstrapBody = 2 << 20
+
+var (
+	htmlTitlePattern = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	htmlTagPattern   = regexp.MustCompile(`(?is)<(html|meta|link)\b([^>]*)>`)
+	htmlAttrPattern  = regexp.MustCompile(`(?s)([a-zA-Z_:][-a-zA-Z0-9_:.]*)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+)
+
+// siteMetadata holds the values BootstrapFromSite extracts from a page.
+type siteMetadata struct {
+	title, ogTitle             string
+	description, ogDescription string
+	language, ogLocale         string
+	canonical, ogURL           string
+	icon, touchIcon, ogImage   string
+	feedURL                    string
+}
+
+// BootstrapFromSite fetches siteURL and returns a FeedBuilder pre-populated from the page's
+// metadata so a new feed can be scaffolded from an existing website:
+//   - title: og:title, else <title>
+//   - description: og:description, else <meta name="description">
+//   - language: <html lang>, else og:locale (normalized, see NormalizeLanguage)
+//   - link: og:url, else <link rel="canonical">, else the final (post-redirect) page URL
+//   - image: apple-touch-icon, else icon/shortcut icon, else og:image
+//   - feed URL: the first RSS/Atom/JSON Feed autodiscovery <link rel="alternate">
+//
+// Relative URLs are resolved against the page URL. A nil client uses http.DefaultClient.
+// The returned builder is strict; callers can continue configuring it before Build.
+func BootstrapFromSite(ctx context.Context, client *http.Client, siteURL string) (*FeedBuilder, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSpace(siteURL), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("bootstrap: GET %s: unexpected status %s", siteURL, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBootstrapBody))
+	if err != nil {
+		return nil, err
+	}
+	base := resp.Request.URL
+	meta := parseSiteMetadata(string(body), base)
+	title := firstNonEmpty(meta.ogTitle, meta.title)
+	if title == "" {
+		return nil, errors.New("bootstrap: site has no title")
+	}
+	link := firstNonEmpty(meta.ogURL, meta.canonical, base.String())
+	return NewFeed(title).
+		WithLink(link).
+		WithDescription(firstNonEmpty(meta.ogDescription, meta.description)).
+		WithLanguage(NormalizeLanguage(firstNonEmpty(meta.language, meta.ogLocale))).
+		WithFeedURL(meta.feedURL).
+		WithImage(firstNonEmpty(meta.touchIcon, meta.icon, meta.ogImage), title, link), nil
+}
+
+// parseSiteMetadata scans HTML for the <title>, <html lang>, <meta> and <link> data used by
+// BootstrapFromSite. It is a lenient tag scanner, not a full HTML parser.
+func parseSiteMetadata(doc string, base *url.URL) siteMetadata {
+	var m siteMetadata
+	if sm := htmlTitlePattern.FindStringSubmatch(doc); sm != nil {
+		m.title = cleanHTMLText(sm[1])
+	}
+	for _, tag := range htmlTagPattern.FindAllStringSubmatch(doc, -1) {
+		attrs := parseHTMLAttrs(tag[2])
+		switch strings.ToLower(tag[1]) {
+		case "html":
+			m.language = attrs["lang"]
+		case "meta":
+			applyMetaTag(&m, attrs)
+		case "link":
+			applyLinkTag(&m, attrs, base)
+		}
+	}
+	m.ogURL = resolveURL(base, m.ogURL)
+	m.ogImage = resolveURL(base, m.ogImage)
+	return m
+}
+
+func applyMetaTag(m *siteMetadata, attrs map[string]string) {
+	key := strings.ToLower(firstNonEmpty(attrs["property"], attrs["name"]))
+	val := attrs["content"]
+	if val == "" {
+		return
+	}
+	targets := map[string]*string{
+		"og:title":       &m.ogTitle,
+		"og:description": &m.ogDescription,
+		"description":    &m.description,
+		"og:locale":      &m.ogLocale,
+		"og:url":         &m.ogURL,
+		"og:image":       &m.ogImage,
+	}
+	if p, ok := targets[key]; ok && *p == "" {
+		*p = val
+	}
+}
+
+func applyLinkTag(m *siteMetadata, attrs map[string]string, base *url.URL) {
+	href := resolveURL(base, attrs["href"])
+	if href == "" {
+		return
+	}
+	for _, rel := range strings.Fields(strings.ToLower(attrs["rel"])) {
+		switch rel {
+		case "canonical":
+			if m.canonical == "" {
+				m.canonical = href
+			}
+		case "apple-touch-icon":
+			if m.touchIcon == "" {
+				m.touchIcon = href
+			}
+		case "icon":
+			if m.icon == "" {
+				m.icon = href
+			}
+		case "alternate":
+			switch strings.ToLower(attrs["type"]) {
+			case "application/rss+xml", "application/atom+xml", "application/feed+json", "application/json":
+				if m.feedURL == "" {
+					m.feedURL = href
+				}
+			}
+		}
+	}
+}
+
+// parseHTMLAttrs returns the attributes of a tag with lowercased names and unescaped values.
+func parseHTMLAttrs(s string) map[string]string {
+	out := map[string]string{}
+	for _, a := range htmlAttrPattern.FindAllStringSubmatch(s, -1) {
+		name := strings.ToLower(a[1])
+		if _, seen := out[name]; seen {
+			continue
+		}
+		out[name] = cleanHTMLText(a[2] + a[3] + a[4])
+	}
+	return out
+}
+
+// cleanHTMLText unescapes HTML entities and collapses whitespace.
+func cleanHTMLText(s string) string {
+	return strings.Join(strings.Fields(html.UnescapeString(s)), " ")
+}
+
+// resolveURL resolves ref against base; it returns "" for empty or unparsable refs.
+func resolveURL(base *url.URL, ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return ""
+	}
+	u, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	if base == nil {
+		return u.String()
+	}
+	return base.ResolveReference(u).String()
+}
@@ -0,0 +1,74 @@
+package gofeedx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const bootstrapTestPage = `<!DOCTYPE html>
+<html lang="en_US">
+<head>
+  <title>Example &amp; Co  Blog</title>
+  <meta name="description" content="Plain description">
+  <meta property="og:description" content="Open Graph description">
+  <link rel="canonical" href="/home">
+  <link rel="shortcut icon" href="/favicon.ico">
+  <link rel='alternate' type='application/rss+xml' title='RSS' href='/feed.xml'>
+</head>
+<body><p>Hello</p></body>
+</html>`
+
+func TestBootstrapFromSite(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(bootstrapTestPage))
+	}))
+	defer srv.Close()
+
+	b, err := BootstrapFromSite(context.Background(), srv.Client(), srv.URL+"/")
+	if err != nil {
+		t.Fatalf("BootstrapFromSite unexpected error: %v", err)
+	}
+	f, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build unexpected error: %v", err)
+	}
+	if f.Title != "Example & Co Blog" {
+		t.Errorf("title got %q", f.Title)
+	}
+	if f.Description != "Open Graph description" {
+		t.Errorf("description should prefer og:description, got %q", f.Description)
+	}
+	if f.Language != "en-us" {
+		t.Errorf("language got %q", f.Language)
+	}
+	if getLinkHref(f.Link) != srv.URL+"/home" {
+		t.Errorf("link should use canonical, got %q", getLinkHref(f.Link))
+	}
+	if f.FeedURL != srv.URL+"/feed.xml" {
+		t.Errorf("feed URL from autodiscovery got %q", f.FeedURL)
+	}
+	if f.Image == nil || f.Image.Url != srv.URL+"/favicon.ico" {
+		t.Errorf("image from icon got %#v", f.Image)
+	}
+}
+
+func TestBootstrapFromSite_Errors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/untitled" {
+			_, _ = w.Write([]byte("<html><body>no title</body></html>"))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	if _, err := BootstrapFromSite(context.Background(), srv.Client(), srv.URL+"/fail"); err == nil {
+		t.Errorf("expected error for non-2xx status")
+	}
+	if _, err := BootstrapFromSite(context.Background(), srv.Client(), srv.URL+"/untitled"); err == nil {
+		t.Errorf("expected error for page without title")
+	}
+}
@@ -1,8 +1,10 @@
 package gofeedx
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -25,6 +27,22 @@ type FeedBuilder struct {
 	items    []*Item
 	strict   bool
 	profiles []Profile
+	errs     []error // deferred input errors (e.g. from namespace sub-builders), reported by Build in strict mode
+
+	previousUpdated time.Time          // lower bound for Feed.Updated (see WithMonotonicUpdated)
+	readingTimeWPM  int                // words per minute for reading-time enrichment; 0 disables (see WithReadingTime)
+	maxItems        int                // cap on the number of items; 0 means unlimited (see WithMaxItems)
+	itemsSince      time.Time          // drop items dated before this instant (see WithItemsSince)
+	dedup           *DedupPolicy       // duplicate-ID handling; nil disables (see WithDeduplicate)
+	deterministic   bool               // canonical IDs and extension order (see WithDeterministicOutput)
+	descPolicy      *DescriptionPolicy // description length/HTML limits; nil disables (see WithPSPDescriptionPolicy)
+	idStrategy      IDStrategy         // ID generation for items without one; nil uses the defaults (see WithIDStrategy)
+	clock           Clock              // build time for a missing Updated; nil leaves it alone (see WithClock)
+	variants        map[string]Variant // named derived feeds (see WithVariant)
+	collisions      *CollisionPolicy   // extension collision handling; nil disables (see WithCollisionPolicy)
+	timeLocation    *time.Location     // zone for emitted dates; nil keeps each time's own (see WithTimeLocation)
+	durationProber  DurationProber     // fills in missing item durations; nil disables (see WithDurationProber)
+	filter          func(*Item) bool   // keeps only matching items on Build; nil keeps all (see WithFilter)
 }
 
 // NewFeed creates a new FeedBuilder with a required title.
@@ -95,6 +113,14 @@ func (b *FeedBuilder) WithUpdated(t time.Time) *FeedBuilder {
 	return b
 }
 
+// WithMonotonicUpdated guarantees that the built Feed.Updated is not earlier than previous,
+// typically the Updated value of the last published render. This protects against clock skew
+// between publishing hosts, which could otherwise make readers ignore an update.
+func (b *FeedBuilder) WithMonotonicUpdated(previous time.Time) *FeedBuilder {
+	b.previousUpdated = previous
+	return b
+}
+
 // WithCreated sets the feed created/published timestamp.
 func (b *FeedBuilder) WithCreated(t time.Time) *FeedBuilder {
 	b.feed.Created = t
@@ -126,6 +152,16 @@ func (b *FeedBuilder) WithImage(url, title, link string) *FeedBuilder {
 	return b
 }
 
+// WithCategory appends a category with an optional Atom scheme and label.
+func (b *FeedBuilder) WithCategory(term, scheme, label string) *FeedBuilder {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return b
+	}
+	b.feed.Categories = append(b.feed.Categories, &Category{Text: term, Scheme: strings.TrimSpace(scheme), Label: strings.TrimSpace(label)})
+	return b
+}
+
 // WithCategories replaces the feed categories with the provided list.
 func (b *FeedBuilder) WithCategories(categories ...string) *FeedBuilder {
 	var out []*Category
@@ -157,20 +193,78 @@ func (b *FeedBuilder) WithXMLCDATA(enabled bool) *FeedBuilder {
 	if enabled {
 		val = "true"
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_xml:cdata", Text: val})
+	return b.withOption(ExtensionNode{Name: "_xml:cdata", Text: val})
+}
+
+// WithXSLStylesheet makes ToRSS, ToAtom and ToPSP emit <?xml-stylesheet type="text/xsl" href="..."?>
+// after the XML declaration so browsers render the feed with that XSLT. An explicit
+// RenderOptions.StylesheetHref takes precedence.
+func (b *FeedBuilder) WithXSLStylesheet(href string) *FeedBuilder {
+	href = strings.TrimSpace(href)
+	if href == "" {
+		return b
+	}
+	return b.withOption(ExtensionNode{Name: xmlStylesheetMarker, Text: href, Attrs: map[string]string{"type": "text/xsl"}})
 }
 
 // AddItem appends a built item to the feed.
 // If ib.Build() returns an error, it is ignored here and handled by profile validation in Build.
+// Input errors ib recorded in strict mode (e.g. from its namespace sub-builders) are reported
+// by Build in strict mode.
 func (b *FeedBuilder) AddItem(ib *ItemBuilder) *FeedBuilder {
 	if ib == nil {
 		return b
 	}
+	b.addItemInputErrors(ib)
 	it, _ := ib.Build()
 	b.items = append(b.items, it) // it may be nil if ib.Build() failed in lenient mode; filter in Build()
 	return b
 }
 
+// UpsertItem replaces the item with the same ID as the one ib builds, keeping its position,
+// or appends it when no item has that ID (or it has none), so long-lived builders can keep
+// their item list current without rebuilding it. Items that fail to build are ignored; input
+// errors are reported like in AddItem.
+func (b *FeedBuilder) UpsertItem(ib *ItemBuilder) *FeedBuilder {
+	if ib == nil {
+		return b
+	}
+	b.addItemInputErrors(ib)
+	it, _ := ib.Build()
+	if it == nil {
+		return b
+	}
+	if id := strings.TrimSpace(it.ID); id != "" {
+		for i, old := range b.items {
+			if old != nil && strings.TrimSpace(old.ID) == id {
+				b.items[i] = it
+				return b
+			}
+		}
+	}
+	b.items = append(b.items, it)
+	return b
+}
+
+// addItemInputErrors defers the input errors of a strict ib to the feed's strict Build.
+func (b *FeedBuilder) addItemInputErrors(ib *ItemBuilder) {
+	if ib.strict && len(ib.errs) > 0 {
+		b.errs = append(b.errs, fmt.Errorf("builder: item %q: %w", ib.item.Title, errors.Join(ib.errs...)))
+	}
+}
+
+// RemoveItem removes every item whose ID is id; an empty id removes nothing.
+func (b *FeedBuilder) RemoveItem(id string) *FeedBuilder {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return b
+	}
+	b.items = slices.DeleteFunc(b.items, func(it *Item) bool {
+		return it != nil && strings.TrimSpace(it.ID) == id
+	})
+	return b
+}
+
 // AddItemFunc creates and configures an item with the supplied function.
 func (b *FeedBuilder) AddItemFunc(fn func(*ItemBuilder)) *FeedBuilder {
 	if fn == nil {
@@ -181,6 +275,136 @@ func (b *FeedBuilder) AddItemFunc(fn func(*ItemBuilder)) *FeedBuilder {
 	return b.AddItem(ib)
 }
 
+// IDStrategy returns the ID for an item that has none, e.g. from a database key or slug.
+// Returning "" leaves the item to the default fallback.
+type IDStrategy func(*Item) string
+
+// WithIDStrategy makes Build assign IDs from fn to items without one, for every profile,
+// before the tag:/UUID fallbacks run. Auto-assigned IDs get isPermaLink="false" unless set.
+func (b *FeedBuilder) WithIDStrategy(fn IDStrategy) *FeedBuilder {
+	b.idStrategy = fn
+	return b
+}
+
+// applyIDStrategy fills missing item IDs from fn.
+func applyIDStrategy(items []*Item, fn IDStrategy) {
+	for _, it := range items {
+		if strings.TrimSpace(it.ID) != "" {
+			continue
+		}
+		if id := strings.TrimSpace(fn(it)); id != "" {
+			it.ID = id
+			if it.IsPermaLink == "" {
+				it.IsPermaLink = "false"
+			}
+		}
+	}
+}
+
+// DedupPolicy selects how Build treats items sharing the same ID (GUID).
+type DedupPolicy int
+
+const (
+	// DedupKeepFirst keeps the first item with a given ID and drops later ones.
+	DedupKeepFirst DedupPolicy = iota
+	// DedupKeepLatest keeps the most recently updated item (Updated, else Created) with a given
+	// ID, at the position of the first occurrence; ties keep the earlier item.
+	DedupKeepLatest
+	// DedupError makes Build fail when two items share an ID.
+	DedupError
+)
+
+// WithDeduplicate makes Build detect items with duplicate IDs and resolve them per policy.
+// Items without an ID are never considered duplicates. Deduplication runs before
+// WithItemsSince/WithMaxItems so the window counts unique items.
+func (b *FeedBuilder) WithDeduplicate(policy DedupPolicy) *FeedBuilder {
+	b.dedup = &policy
+	return b
+}
+
+// dedupItems resolves duplicate item IDs per policy, preserving order.
+func dedupItems(items []*Item, policy DedupPolicy) ([]*Item, error) {
+	seen := make(map[string]int, len(items)) // id -> index in out
+	out := make([]*Item, 0, len(items))
+	for i, it := range items {
+		id := strings.TrimSpace(it.ID)
+		j, dup := seen[id]
+		if id == "" || !dup {
+			if id != "" {
+				seen[id] = len(out)
+			}
+			out = append(out, it)
+			continue
+		}
+		switch policy {
+		case DedupError:
+			return nil, fmt.Errorf("builder: item[%d] duplicates id %q", i, id)
+		case DedupKeepLatest:
+			if itemRevision(it).After(itemRevision(out[j])) {
+				out[j] = it
+			}
+		}
+	}
+	return out, nil
+}
+
+// itemRevision returns Updated, or Created when Updated is zero.
+func itemRevision(it *Item) time.Time {
+	if !it.Updated.IsZero() {
+		return it.Updated
+	}
+	return it.Created
+}
+
+// WithMaxItems keeps only the first n items (after sorting) on Build, e.g. the latest n episodes
+// when sorted by SortByCreated descending. n <= 0 removes the limit.
+func (b *FeedBuilder) WithMaxItems(n int) *FeedBuilder {
+	if n < 0 {
+		n = 0
+	}
+	b.maxItems = n
+	return b
+}
+
+// WithItemsSince drops items dated before t on Build. An item's date is Created, or Updated
+// when Created is zero; undated items are kept. A zero t removes the window.
+func (b *FeedBuilder) WithItemsSince(t time.Time) *FeedBuilder {
+	b.itemsSince = t
+	return b
+}
+
+// WithFilter makes Build keep only the items for which keep returns true, e.g. one category
+// or language, so several variants of a feed can be built from one item set. The filter
+// runs before deduplication and the WithItemsSince/WithMaxItems window, so the window counts
+// only matching items (unlike Variant.Filter, which filters the built feed). The builder's
+// items are not removed: call WithFilter again (nil keeps all items) and Build for the next
+// variant.
+func (b *FeedBuilder) WithFilter(keep func(*Item) bool) *FeedBuilder {
+	b.filter = keep
+	return b
+}
+
+// windowItems applies WithItemsSince and then WithMaxItems, preserving item order.
+func (b *FeedBuilder) windowItems(items []*Item) []*Item {
+	if !b.itemsSince.IsZero() {
+		kept := items[:0:0]
+		for _, it := range items {
+			d := it.Created
+			if d.IsZero() {
+				d = it.Updated
+			}
+			if d.IsZero() || !d.Before(b.itemsSince) {
+				kept = append(kept, it)
+			}
+		}
+		items = kept
+	}
+	if b.maxItems > 0 && len(items) > b.maxItems {
+		items = items[:b.maxItems]
+	}
+	return items
+}
+
 // WithSort sets a stable sort for items; call before Build.
 func (b *FeedBuilder) WithSort(less func(a, b *Item) bool) *FeedBuilder {
 	if less == nil {
@@ -326,20 +550,82 @@ func getEnclosureURL(e *Enclosure) string {
 // - For JSON/Atom/PSP profiles: if an item lacks ID, compute a stable fallback
 // Returns an error if any selected profile validation fails.
 func (b *FeedBuilder) Build() (*Feed, error) {
-	// Copy non-nil items
-	b.feed.Items = copyNonNilItems(b.items)
+	return b.BuildContext(context.Background())
+}
+
+// BuildContext is Build with a context for the work Build does on the network, such as
+// probing durations (see WithDurationProber).
+func (b *FeedBuilder) BuildContext(ctx context.Context) (*Feed, error) {
+	// Copy non-nil items, resolve duplicate IDs, then apply the item window (sorting already happened in WithSort)
+	items := copyNonNilItems(b.items)
+	if b.filter != nil {
+		items = slices.DeleteFunc(items, func(it *Item) bool { return !b.filter(it) })
+	}
+	if b.dedup != nil {
+		var err error
+		if items, err = dedupItems(items, *b.dedup); err != nil {
+			return nil, err
+		}
+	}
+	b.feed.Items = b.windowItems(items)
 
 	// Basic strict checks
 	if b.strict {
+		if len(b.errs) > 0 {
+			return nil, errors.Join(b.errs...)
+		}
 		if err := builderStrictChecks(&b.feed); err != nil {
 			return nil, err
 		}
 	}
 
-	// Defaults for Atom Updated
+	// the passes below rewrite item fields; work on copies of the items shared with their
+	// ItemBuilders
+	ownItems(b.feed.Items)
+
+	if b.durationProber != nil {
+		if _, err := ProbeDurations(ctx, &b.feed, b.durationProber); err != nil && b.strict {
+			return nil, err
+		}
+	}
+
+	// Build time from the injected clock, then defaults for Atom Updated
+	if b.clock != nil && b.feed.Updated.IsZero() {
+		b.feed.Updated = b.clock.Now()
+	}
 	if containsProfile(b.profiles, ProfileAtom) && b.feed.Updated.IsZero() {
 		b.feed.Updated = maxTime(collectItemTimes(b.feed.Items)...)
 	}
+	EnsureMonotonicUpdated(&b.feed, b.previousUpdated)
+	if b.timeLocation != nil {
+		inLocation(&b.feed, b.timeLocation)
+	}
+
+	if b.descPolicy != nil {
+		if errs := applyDescriptionPolicy(&b.feed, *b.descPolicy); len(errs) > 0 {
+			return nil, errors.Join(errs...)
+		}
+	}
+
+	if b.readingTimeWPM > 0 {
+		for _, it := range b.feed.Items {
+			AddReadingTime(it, b.readingTimeWPM)
+		}
+	}
+
+	if b.idStrategy != nil {
+		applyIDStrategy(b.feed.Items, b.idStrategy)
+	}
+
+	if b.deterministic {
+		canonicalizeFeed(&b.feed)
+	}
+
+	if b.collisions != nil {
+		if err := resolveCollisions(&b.feed, *b.collisions); err != nil {
+			return nil, err
+		}
+	}
 
 	// Auto IDs for items when Atom/JSON/PSP targets are selected
 	if containsAnyProfile(b.profiles, ProfileAtom, ProfileJSON, ProfilePSP) {
@@ -353,6 +639,26 @@ func (b *FeedBuilder) Build() (*Feed, error) {
 	return &b.feed, nil
 }
 
+// EnsureMonotonicUpdated raises f.Updated to previous when it is set but earlier than previous,
+// so Updated never moves backwards between renders. A zero Updated or previous is left alone.
+func EnsureMonotonicUpdated(f *Feed, previous time.Time) {
+	if f == nil || previous.IsZero() || f.Updated.IsZero() {
+		return
+	}
+	if f.Updated.Before(previous) {
+		f.Updated = previous
+	}
+}
+
+// ownItems replaces items with shallow copies so Build can rewrite their fields: the items
+// are shared with their ItemBuilders (ItemBuilder.Build returns the builder's own item).
+func ownItems(items []*Item) {
+	for i, it := range items {
+		c := *it
+		items[i] = &c
+	}
+}
+
 func copyNonNilItems(items []*Item) []*Item {
 	var out []*Item
 	for _, it := range items {
@@ -365,9 +671,17 @@ func copyNonNilItems(items []*Item) []*Item {
 
 func builderStrictChecks(f *Feed) error {
 	if strings.TrimSpace(f.Title) == "" {
-		return errors.New("builder: feed title required")
+		return fmt.Errorf("builder: feed %w", ErrMissingTitle)
 	}
 	// enclosure checks delegated to ItemBuilder strict mode; feed-level has none
+	if err := validateBooleanExtensions("builder: feed", f.Extensions); err != nil {
+		return err
+	}
+	for i, it := range f.Items {
+		if err := validateBooleanExtensions(fmt.Sprintf("builder: item[%d]", i), it.Extensions); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -403,6 +717,12 @@ func runProfileValidations(f *Feed, profiles []Profile) error {
 			if err := ValidateJSON(f); err != nil {
 				verr = errors.Join(verr, err)
 			}
+		default:
+			if c, ok := lookupCustomProfile(p); ok {
+				if err := c.writer.Validate(f); err != nil {
+					verr = errors.Join(verr, fmt.Errorf("%s: %w", c.name, err))
+				}
+			}
 		}
 	}
 	return verr
@@ -461,6 +781,7 @@ func maxTime(times ...time.Time) time.Time {
 type ItemBuilder struct {
 	item   Item
 	strict bool
+	errs   []error // deferred input errors (e.g. from namespace sub-builders), reported by Build in strict mode
 }
 
 // NewItem creates a new ItemBuilder with an optional title.
@@ -525,6 +846,39 @@ func (b *ItemBuilder) WithSource(href string) *ItemBuilder {
 	return b
 }
 
+// WithSourceFeed records the feed the item was originally published in; a SourceFeed with
+// no fields set clears it.
+func (b *ItemBuilder) WithSourceFeed(src SourceFeed) *ItemBuilder {
+	if src == (SourceFeed{}) {
+		b.item.SourceFeed = nil
+		return b
+	}
+	b.item.SourceFeed = &src
+	return b
+}
+
+// WithCommentsURL sets the page holding the item's comments.
+func (b *ItemBuilder) WithCommentsURL(url string) *ItemBuilder {
+	b.item.CommentsURL = strings.TrimSpace(url)
+	return b
+}
+
+// WithCommentsFeedURL sets the feed of the item's comments (wfw:commentRss in RSS).
+func (b *ItemBuilder) WithCommentsFeedURL(url string) *ItemBuilder {
+	b.item.CommentsFeedURL = strings.TrimSpace(url)
+	return b
+}
+
+// WithCommentCount sets the number of comments on the item (slash:comments in RSS).
+func (b *ItemBuilder) WithCommentCount(n int) *ItemBuilder {
+	if n < 0 {
+		b.errs = append(b.errs, fmt.Errorf("comment count must not be negative, got %d", n))
+		return b
+	}
+	b.item.CommentCount = &n
+	return b
+}
+
 // WithAuthor sets the item author.
 func (b *ItemBuilder) WithAuthor(name, email string) *ItemBuilder {
 	name = strings.TrimSpace(name)
@@ -549,6 +903,12 @@ func (b *ItemBuilder) WithContentHTML(html string) *ItemBuilder {
 	return b
 }
 
+// WithLanguage sets the item language (e.g., de-AT) for multilingual feeds.
+func (b *ItemBuilder) WithLanguage(lang string) *ItemBuilder {
+	b.item.Language = strings.TrimSpace(lang)
+	return b
+}
+
 // WithCreated sets the item published date.
 func (b *ItemBuilder) WithCreated(t time.Time) *ItemBuilder {
 	b.item.Created = t
@@ -573,6 +933,19 @@ func (b *ItemBuilder) WithEnclosure(url string, length int64, mime string) *Item
 	return b
 }
 
+// WithImageURL sets the item artwork independently of the enclosure (itunes:image in PSP,
+// media:thumbnail in RSS/Atom, image in JSON). A url that is not absolute is reported by
+// Build in strict mode.
+func (b *ItemBuilder) WithImageURL(url string) *ItemBuilder {
+	url = strings.TrimSpace(url)
+	if url != "" && !isAbsoluteURL(url) {
+		b.errs = append(b.errs, fmt.Errorf("image url %q must be absolute", url))
+		return b
+	}
+	b.item.ImageURL = url
+	return b
+}
+
 // WithDurationSeconds sets the item duration (seconds) for PSP and JSON attachments.
 func (b *ItemBuilder) WithDurationSeconds(sec int) *ItemBuilder {
 	if sec < 0 {
@@ -601,13 +974,17 @@ func (b *ItemBuilder) WithXMLCDATA(enabled bool) *ItemBuilder {
 	if enabled {
 		val = "true"
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_xml:cdata", Text: val})
+	return b.withOption(ExtensionNode{Name: "_xml:cdata", Text: val})
 }
 
 // Build finalizes the item with minimal strict checks:
 // - title or description must be present in strict mode
+// - input errors recorded by namespace sub-builders are returned in strict mode
 func (b *ItemBuilder) Build() (*Item, error) {
 	if b.strict {
+		if len(b.errs) > 0 {
+			return nil, errors.Join(b.errs...)
+		}
 		if strings.TrimSpace(b.item.Title) == "" && strings.TrimSpace(b.item.Description) == "" {
 			return nil, errors.New("builder: item requires a title or description")
 		}
@@ -283,8 +283,11 @@ func TestWithXMLCDATA_FeedAndItemExtensions(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Build error: %v", err)
 	}
+	if len(f.Extensions) != 0 {
+		t.Errorf("expected builder option to stay out of Extensions, got %v", f.Extensions)
+	}
 	found := false
-	for _, n := range f.Extensions {
+	for _, n := range f.allExtensions() {
 		if strings.EqualFold(strings.TrimSpace(n.Name), "_xml:cdata") && strings.TrimSpace(n.Text) == "false" {
 			found = true
 		}
@@ -299,7 +302,7 @@ func TestWithXMLCDATA_FeedAndItemExtensions(t *testing.T) {
 		t.Fatalf("item Build error: %v", err)
 	}
 	foundItem := false
-	for _, n := range item.Extensions {
+	for _, n := range item.allExtensions() {
 		if strings.EqualFold(strings.TrimSpace(n.Name), "_xml:cdata") && strings.TrimSpace(n.Text) == "false" {
 			foundItem = true
 		}
@@ -766,3 +769,357 @@ func TestCDATA_ItemOverride_EnableOnItemWhenFeedDisabled(t *testing.T) {
 		t.Errorf("item description should use CDATA when item override true; got:\n%s", rssXML)
 	}
 }
+
+func TestFeedBuilder_WithMonotonicUpdated(t *testing.T) {
+	prev := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	skewed := prev.Add(-3 * time.Minute)
+	f, err := NewFeed("t").WithUpdated(skewed).WithMonotonicUpdated(prev).Build()
+	if err != nil {
+		t.Fatalf("Build unexpected error: %v", err)
+	}
+	if !f.Updated.Equal(prev) {
+		t.Errorf("Updated should not move backwards: got %v, want %v", f.Updated, prev)
+	}
+
+	later := prev.Add(time.Hour)
+	f, err = NewFeed("t").WithUpdated(later).WithMonotonicUpdated(prev).Build()
+	if err != nil {
+		t.Fatalf("Build unexpected error: %v", err)
+	}
+	if !f.Updated.Equal(later) {
+		t.Errorf("newer Updated should be kept: got %v, want %v", f.Updated, later)
+	}
+
+	// Atom default from item times is also clamped
+	f, err = NewFeed("t").WithLink("https://example.org/").WithAuthor("a", "").
+		WithProfiles(ProfileAtom).WithMonotonicUpdated(prev).
+		AddItem(NewItem("i").WithCreated(skewed)).Build()
+	if err != nil {
+		t.Fatalf("Build unexpected error: %v", err)
+	}
+	if !f.Updated.Equal(prev) {
+		t.Errorf("defaulted Updated should be clamped: got %v", f.Updated)
+	}
+}
+
+func TestEnsureMonotonicUpdated_ZeroValues(t *testing.T) {
+	f := &Feed{}
+	EnsureMonotonicUpdated(f, time.Now())
+	if !f.Updated.IsZero() {
+		t.Errorf("zero Updated should stay zero")
+	}
+	EnsureMonotonicUpdated(nil, time.Now()) // must not panic
+}
+
+func TestFeedBuilder_WithMaxItemsAndItemsSince(t *testing.T) {
+	base := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	newBuilder := func() *FeedBuilder {
+		b := NewFeed("Window")
+		for i := 0; i < 5; i++ {
+			b.AddItem(NewItem("Ep " + string(rune('A'+i))).WithID(string(rune('a' + i))).WithCreated(base.AddDate(0, 0, i)))
+		}
+		b.AddItem(NewItem("Undated").WithID("u"))
+		return b
+	}
+
+	// Latest three episodes: sort first, then cap
+	f, err := newBuilder().WithSortBy(SortByCreated, SortDesc).WithMaxItems(3).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	var ids []string
+	for _, it := range f.Items {
+		ids = append(ids, it.ID)
+	}
+	if got := strings.Join(ids, ","); got != "e,d,c" {
+		t.Errorf("WithMaxItems after sort = %s, want e,d,c", got)
+	}
+
+	// Time window keeps undated items and items on/after the bound
+	f, err = newBuilder().WithItemsSince(base.AddDate(0, 0, 3)).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	ids = ids[:0]
+	for _, it := range f.Items {
+		ids = append(ids, it.ID)
+	}
+	if got := strings.Join(ids, ","); got != "d,e,u" {
+		t.Errorf("WithItemsSince = %s, want d,e,u", got)
+	}
+
+	// Zero values disable the options
+	f, err = newBuilder().WithMaxItems(2).WithMaxItems(0).WithItemsSince(time.Time{}).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if len(f.Items) != 6 {
+		t.Errorf("expected all 6 items without window, got %d", len(f.Items))
+	}
+}
+
+func TestFeedBuilder_WithDeduplicate(t *testing.T) {
+	t0 := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	newBuilder := func() *FeedBuilder {
+		return NewFeed("Dedup").
+			AddItem(NewItem("First").WithID("a").WithCreated(t0)).
+			AddItem(NewItem("Other").WithID("b").WithCreated(t0)).
+			AddItem(NewItem("Revised").WithID("a").WithCreated(t0).WithUpdated(t0.Add(time.Hour))).
+			AddItem(NewItem("No ID 1")).
+			AddItem(NewItem("No ID 2"))
+	}
+	titles := func(f *Feed) string {
+		var s []string
+		for _, it := range f.Items {
+			s = append(s, it.Title)
+		}
+		return strings.Join(s, ",")
+	}
+
+	f, err := newBuilder().WithDeduplicate(DedupKeepFirst).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if got := titles(f); got != "First,Other,No ID 1,No ID 2" {
+		t.Errorf("DedupKeepFirst = %s", got)
+	}
+
+	f, err = newBuilder().WithDeduplicate(DedupKeepLatest).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if got := titles(f); got != "Revised,Other,No ID 1,No ID 2" {
+		t.Errorf("DedupKeepLatest = %s", got)
+	}
+
+	_, err = newBuilder().WithDeduplicate(DedupError).Build()
+	if err == nil || !strings.Contains(err.Error(), `item[2] duplicates id "a"`) {
+		t.Errorf("DedupError expected duplicate error, got %v", err)
+	}
+
+	// Without the option duplicates pass through unchanged
+	f, err = newBuilder().Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if len(f.Items) != 5 {
+		t.Errorf("expected 5 items without dedup, got %d", len(f.Items))
+	}
+}
+
+func TestFeedBuilder_WithDeterministicOutput(t *testing.T) {
+	build := func(reverse bool) *Feed {
+		b := NewFeed("Det").WithID("urn:example:det").WithDescription("D").WithAuthor("A", "a@example.org").WithDeterministicOutput()
+		b.WithExtensions(ExtensionNode{Name: "x:b", Text: "2"}, ExtensionNode{Name: "x:a", Text: "1"})
+		if reverse {
+			b.WithRSSDocs("https://example.org/docs").WithRSSTTL(30)
+		} else {
+			b.WithRSSTTL(30).WithRSSDocs("https://example.org/docs")
+		}
+		b.AddItem(NewItem("No link, no ID").WithDescription("same input").WithCreated(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)))
+		f, err := b.WithProfiles(ProfileAtom, ProfileJSON).Build()
+		if err != nil {
+			t.Fatalf("Build failed: %v", err)
+		}
+		return f
+	}
+	render := func(f *Feed) string {
+		var out []string
+		for _, fn := range []func(*Feed) (string, error){ToRSS, ToAtom, ToJSON} {
+			s, err := fn(f)
+			if err != nil {
+				t.Fatalf("render failed: %v", err)
+			}
+			out = append(out, s)
+		}
+		return strings.Join(out, "\n")
+	}
+
+	f1, f2 := build(false), build(true)
+	if !strings.HasPrefix(f1.Items[0].ID, "urn:uuid:") || f1.Items[0].ID != f2.Items[0].ID {
+		t.Fatalf("expected identical name-based IDs, got %q and %q", f1.Items[0].ID, f2.Items[0].ID)
+	}
+	if a, b := render(f1), render(f2); a != b {
+		t.Errorf("expected byte-identical output, got:\n%s\n---\n%s", a, b)
+	}
+	if f1.Extensions[0].Name != "x:b" {
+		t.Errorf("expected caller extensions in insertion order, got %v", f1.Extensions)
+	}
+	if f1.options[0].Name != "_rss:docs" || f2.options[0].Name != "_rss:docs" {
+		t.Errorf("expected builder options sorted by name, got %v and %v", f1.options, f2.options)
+	}
+}
+
+func TestFeedBuilder_WithIDStrategy(t *testing.T) {
+	b := NewFeed("IDs").WithDescription("D").WithIDStrategy(func(it *Item) string {
+		if it.Title == "skip" {
+			return ""
+		}
+		return "urn:example:post:" + strings.ToLower(it.Title)
+	})
+	b.AddItem(NewItem("Hello").WithDescription("x"))
+	b.AddItem(NewItem("Kept").WithID("custom-1").WithDescription("x"))
+	b.AddItem(NewItem("skip").WithDescription("x"))
+	f, err := b.WithProfiles(ProfileJSON).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if f.Items[0].ID != "urn:example:post:hello" || f.Items[0].IsPermaLink != "false" {
+		t.Errorf("expected strategy ID, got %q (isPermaLink %q)", f.Items[0].ID, f.Items[0].IsPermaLink)
+	}
+	if f.Items[1].ID != "custom-1" {
+		t.Errorf("explicit ID must be kept, got %q", f.Items[1].ID)
+	}
+	if !strings.HasPrefix(f.Items[2].ID, "urn:uuid:") {
+		t.Errorf("empty strategy result should use the default fallback, got %q", f.Items[2].ID)
+	}
+
+	// The strategy also applies without Atom/JSON/PSP profiles
+	f, err = NewFeed("IDs").WithDescription("D").WithIDStrategy(func(*Item) string { return "db-42" }).
+		AddItem(NewItem("Hello").WithDescription("x")).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if f.Items[0].ID != "db-42" {
+		t.Errorf("expected strategy ID without profiles, got %q", f.Items[0].ID)
+	}
+}
+
+func TestContentHashID(t *testing.T) {
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.FixedZone("CEST", 2*3600))
+	build := func() *Feed {
+		b := NewFeed("Hash").WithDescription("D").WithIDStrategy(ContentHashID)
+		b.AddItem(NewItem("Ep 1").WithCreated(created).WithEnclosure("https://cdn.example.org/ep1.mp3", 10, "audio/mpeg"))
+		b.AddItem(NewItem("Ep 2").WithCreated(created).WithEnclosure("https://cdn.example.org/ep2.mp3", 10, "audio/mpeg"))
+		f, err := b.WithProfiles(ProfileJSON).Build()
+		if err != nil {
+			t.Fatalf("Build failed: %v", err)
+		}
+		return f
+	}
+	f1, f2 := build(), build()
+	id := f1.Items[0].ID
+	if !strings.HasPrefix(id, "urn:sha256:") || len(id) != len("urn:sha256:")+64 {
+		t.Fatalf("unexpected ID %q", id)
+	}
+	if id != f2.Items[0].ID {
+		t.Errorf("expected identical IDs across builds, got %q and %q", id, f2.Items[0].ID)
+	}
+	if id == f1.Items[1].ID {
+		t.Error("expected different IDs for different enclosures")
+	}
+	same := &Item{Title: "Ep 1", Created: created.UTC(), Enclosure: &Enclosure{Url: "https://cdn.example.org/ep1.mp3"}}
+	if ContentHashID(same) != id {
+		t.Error("expected the ID to ignore the time zone of the publication date")
+	}
+	if ContentHashID(&Item{}) != "" {
+		t.Error("expected no ID for an empty item")
+	}
+}
+
+func TestFeedBuilder_WithClock(t *testing.T) {
+	pinned := time.Date(2024, 6, 1, 8, 30, 0, 0, time.UTC)
+	build := func() string {
+		b := NewFeed("Clock").WithLink("https://example.org/").WithDescription("D").
+			WithClock(FixedClock(pinned)).WithDeterministicOutput()
+		b.AddItem(NewItem("No date").WithDescription("x"))
+		f, err := b.WithProfiles(ProfileRSS, ProfileJSON).Build()
+		if err != nil {
+			t.Fatalf("Build failed: %v", err)
+		}
+		if !f.Updated.Equal(pinned) {
+			t.Fatalf("expected Updated from clock, got %v", f.Updated)
+		}
+		rss, err := ToRSS(f)
+		if err != nil {
+			t.Fatalf("ToRSS failed: %v", err)
+		}
+		return rss
+	}
+	out := build()
+	if !strings.Contains(out, "<lastBuildDate>Sat, 01 Jun 2024 08:30:00 +0000</lastBuildDate>") {
+		t.Errorf("expected lastBuildDate from the pinned clock:\n%s", out)
+	}
+	if again := build(); again != out {
+		t.Errorf("expected byte-identical output with a pinned clock")
+	}
+
+	explicit := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	f, err := NewFeed("Clock").WithDescription("D").WithUpdated(explicit).WithClock(FixedClock(pinned)).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if !f.Updated.Equal(explicit) {
+		t.Errorf("clock must not override an explicit Updated, got %v", f.Updated)
+	}
+}
+
+func TestFeedBuilder_UpsertAndRemoveItem(t *testing.T) {
+	b := NewFeed("t")
+	b.AddItem(NewItem("one").WithID("1"))
+	b.AddItem(NewItem("two").WithID("2"))
+	b.UpsertItem(NewItem("one, edited").WithID(" 1 "))
+	b.UpsertItem(NewItem("three").WithID("3"))
+	b.UpsertItem(NewItem("")) // fails to build: ignored
+	b.UpsertItem(nil)
+	f, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build() unexpected error: %v", err)
+	}
+	var titles []string
+	for _, it := range f.Items {
+		titles = append(titles, it.Title)
+	}
+	if strings.Join(titles, "|") != "one, edited|two|three" {
+		t.Fatalf("unexpected items after upsert: %v", titles)
+	}
+
+	b.RemoveItem("2").RemoveItem("missing").RemoveItem("")
+	f, err = b.Build()
+	if err != nil {
+		t.Fatalf("Build() unexpected error: %v", err)
+	}
+	if len(f.Items) != 2 || f.Items[0].ID != "1" || f.Items[1].ID != "3" {
+		t.Errorf("unexpected items after remove: %+v", f.Items)
+	}
+}
+
+func TestFeedBuilder_WithFilter(t *testing.T) {
+	b := NewFeed("Languages")
+	for _, l := range []string{"de", "en", "de", "en", "en"} {
+		id := l + string(rune('0'+len(b.items)))
+		b.AddItem(NewItem(id).WithID(id).WithLanguage(l))
+	}
+	ids := func(f *Feed) string {
+		var out []string
+		for _, it := range f.Items {
+			out = append(out, it.ID)
+		}
+		return strings.Join(out, ",")
+	}
+
+	// The window counts only matching items
+	f, err := b.WithFilter(func(it *Item) bool { return it.Language == "en" }).WithMaxItems(2).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if got := ids(f); got != "en1,en3" {
+		t.Errorf("filtered items = %s, want en1,en3", got)
+	}
+
+	// The same builder yields the next variant; nil removes the filter
+	f, err = b.WithFilter(func(it *Item) bool { return it.Language == "de" }).WithMaxItems(0).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if got := ids(f); got != "de0,de2" {
+		t.Errorf("filtered items = %s, want de0,de2", got)
+	}
+	f, err = b.WithFilter(nil).Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if len(f.Items) != 5 {
+		t.Errorf("expected all 5 items without a filter, got %d", len(f.Items))
+	}
+}
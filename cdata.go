@@ -62,17 +62,14 @@ func encodeElementCDATA(e *xml.Encoder, name string, value string, useCDATA bool
 }
 
 // UseCDATAFromExtensions returns the CDATA preference from a list of extensions.
-// Default: true (enabled). Overridden when an "_xml:cdata" node with "true"/"false" is present.
+// Default: true (enabled). Overridden when an "_xml:cdata" node with a boolean value
+// accepted by ParseFeedBool is present.
 func UseCDATAFromExtensions(exts []ExtensionNode) bool {
 	use := true
 	for _, n := range exts {
 		if strings.EqualFold(strings.TrimSpace(n.Name), "_xml:cdata") {
-			t := strings.ToLower(strings.TrimSpace(n.Text))
-			if t == "false" {
-				return false
-			}
-			if t == "true" {
-				return true
+			if v, ok := ParseFeedBool(n.Text); ok {
+				return v
 			}
 		}
 	}
@@ -107,5 +104,5 @@ func UseCDATAForFeed(f *Feed) bool {
 	if f == nil {
 		return true
 	}
-	return UseCDATAFromExtensions(f.Extensions)
+	return UseCDATAFromExtensions(f.allExtensions())
 }
@@ -0,0 +1,113 @@
+package gofeedx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ChaptersMIMEType is the type attribute of podcast:chapters for JSON chapter files.
+const ChaptersMIMEType = "application/json+chapters"
+
+// chaptersVersion is the PodcastIndex JSON Chapters version written when Chapters.Version is empty.
+const chaptersVersion = "1.2.0"
+
+// Chapter is one entry of a PodcastIndex JSON Chapters file. Times are in seconds.
+type Chapter struct {
+	StartTime float64 `json:"startTime"`
+	EndTime   float64 `json:"endTime,omitempty"`
+	Title     string  `json:"title,omitempty"`
+	Img       string  `json:"img,omitempty"` // absolute image URL
+	URL       string  `json:"url,omitempty"` // absolute web page URL
+	TOC       *bool   `json:"toc,omitempty"` // false hides the chapter from tables of contents
+}
+
+// Chapters is a PodcastIndex JSON Chapters file
+// (https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/examples/chapters/jsonChapters.md).
+type Chapters struct {
+	Version     string    `json:"version"` // defaults to "1.2.0"
+	Author      string    `json:"author,omitempty"`
+	Title       string    `json:"title,omitempty"`
+	PodcastName string    `json:"podcastName,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Chapters    []Chapter `json:"chapters"`
+}
+
+// ToChaptersJSON validates c and returns it in the JSON Chapters format. Chapters must be
+// ordered by StartTime, times must not be negative and img/url must be absolute.
+func (c *Chapters) ToChaptersJSON() ([]byte, error) {
+	if c == nil {
+		return nil, errors.New("chapters: nil chapters")
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	out := *c
+	if strings.TrimSpace(out.Version) == "" {
+		out.Version = chaptersVersion
+	}
+	if out.Chapters == nil {
+		out.Chapters = []Chapter{}
+	}
+	return json.MarshalIndent(out, "", "  ")
+}
+
+func (c *Chapters) validate() error {
+	var errs []error
+	prev := 0.0
+	for i, ch := range c.Chapters {
+		if ch.StartTime < 0 || ch.EndTime < 0 {
+			errs = append(errs, fmt.Errorf("chapters: chapter[%d] times must not be negative", i))
+		}
+		if ch.StartTime < prev {
+			errs = append(errs, fmt.Errorf("chapters: chapter[%d] starts before the previous chapter", i))
+		}
+		if ch.EndTime != 0 && ch.EndTime < ch.StartTime {
+			errs = append(errs, fmt.Errorf("chapters: chapter[%d] ends before it starts", i))
+		}
+		for _, u := range []struct{ name, val string }{{"img", ch.Img}, {"url", ch.URL}} {
+			if v := strings.TrimSpace(u.val); v != "" && !isAbsoluteURL(v) {
+				errs = append(errs, fmt.Errorf("chapters: chapter[%d] %s %q must be absolute", i, u.name, v))
+			}
+		}
+		prev = ch.StartTime
+	}
+	return errors.Join(errs...)
+}
+
+// chaptersJSONOption keeps the chapters document WithChapters serialized on the item.
+const chaptersJSONOption = "_xml:chapters-json"
+
+// WithChapters serializes ch and adds a podcast:chapters element pointing to publicURL, where
+// the caller hosts the document; Item.ChaptersJSON returns it. Invalid chapters or a relative
+// publicURL are reported by Build.
+func (b *ItemBuilder) WithChapters(ch *Chapters, publicURL string) *ItemBuilder {
+	publicURL = strings.TrimSpace(publicURL)
+	if !isAbsoluteURL(publicURL) {
+		b.errs = append(b.errs, fmt.Errorf("chapters: url %q must be absolute", publicURL))
+		return b
+	}
+	data, err := ch.ToChaptersJSON()
+	if err != nil {
+		b.errs = append(b.errs, err)
+		return b
+	}
+	b.withOption(ExtensionNode{Name: chaptersJSONOption, Text: string(data)})
+	return b.WithExtensions(ExtensionNode{Name: "podcast:chapters", Attrs: map[string]string{"url": publicURL, "type": ChaptersMIMEType}})
+}
+
+// ChaptersJSON returns the chapters document WithChapters serialized for the item, to be
+// published at its podcast:chapters URL, or nil when the item has none.
+func (it *Item) ChaptersJSON() []byte {
+	if it == nil {
+		return nil
+	}
+	var data []byte
+	for _, n := range it.options {
+		if n.Name == chaptersJSONOption {
+			data = []byte(n.Text)
+		}
+	}
+	return data
+}
@@ -0,0 +1,69 @@
+package gofeedx_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestChapters_ToChaptersJSON(t *testing.T) {
+	ch := &gofeedx.Chapters{Title: "Episode 1", Chapters: []gofeedx.Chapter{
+		{StartTime: 0, Title: "Intro"},
+		{StartTime: 61.5, Title: "Interview", Img: "https://example.com/guest.jpg", URL: "https://example.com/guest"},
+	}}
+	data, err := ch.ToChaptersJSON()
+	mustNoErr(t, err, "ToChaptersJSON failed")
+	var decoded map[string]any
+	mustNoErr(t, json.Unmarshal(data, &decoded), "invalid JSON")
+	if decoded["version"] != "1.2.0" {
+		t.Errorf("expected default version 1.2.0, got %v", decoded["version"])
+	}
+	mustContain(t, string(data), `"startTime": 61.5`, "expected startTime in seconds")
+	mustContain(t, string(data), `"img": "https://example.com/guest.jpg"`, "expected img")
+	mustNotContain(t, string(data), `"endTime"`, "unset endTime should be omitted")
+
+	for _, bad := range []*gofeedx.Chapters{
+		nil,
+		{Chapters: []gofeedx.Chapter{{StartTime: 10}, {StartTime: 5}}},
+		{Chapters: []gofeedx.Chapter{{StartTime: -1}}},
+		{Chapters: []gofeedx.Chapter{{StartTime: 0, Img: "guest.jpg"}}},
+	} {
+		if _, err := bad.ToChaptersJSON(); err == nil {
+			t.Errorf("expected error for %+v", bad)
+		}
+	}
+}
+
+func TestItemBuilder_WithChapters(t *testing.T) {
+	ch := &gofeedx.Chapters{Chapters: []gofeedx.Chapter{{StartTime: 0, Title: "Intro"}}}
+	b := gofeedx.NewFeed("Show").
+		WithLink("https://example.com/").
+		WithDescription("d").
+		WithLanguage("en").
+		WithFeedURL("https://example.com/podcast.rss").
+		WithImage("https://example.com/artwork.jpg", "", "").
+		WithCategories("Technology").
+		WithAuthor("Host", "host@example.com").
+		WithProfiles(gofeedx.ProfilePSP)
+	b.AddItem(gofeedx.NewItem("E1").WithID("1").WithCreated(time.Now()).
+		WithEnclosure("https://example.com/e1.mp3", 1, "audio/mpeg").WithDescription("e").
+		WithChapters(ch, "https://example.com/e1.chapters.json"))
+	f, err := b.Build()
+	mustNoErr(t, err, "Build failed")
+	out, err := gofeedx.ToPSP(f)
+	mustNoErr(t, err, "ToPSP failed")
+	mustContain(t, out, `<podcast:chapters type="application/json+chapters" url="https://example.com/e1.chapters.json">`, "expected podcast:chapters")
+	mustNotContain(t, out, "chapters-json", "the chapters document must not be rendered into the feed")
+	want, err := ch.ToChaptersJSON()
+	mustNoErr(t, err, "ToChaptersJSON failed")
+	if got := f.Items[0].ChaptersJSON(); string(got) != string(want) {
+		t.Errorf("ChaptersJSON = %s, want %s", got, want)
+	}
+
+	_, err = gofeedx.NewItem("E1").WithChapters(ch, "/e1.chapters.json").Build()
+	mustErr(t, err, "expected error for relative chapters url")
+	_, err = gofeedx.NewItem("E1").WithChapters(&gofeedx.Chapters{Chapters: []gofeedx.Chapter{{StartTime: -1}}}, "https://example.com/c.json").Build()
+	mustErr(t, err, "expected error for invalid chapters")
+}
@@ -0,0 +1,162 @@
+package gofeedx
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// xmlCharset is an output encoding RenderOptions.Encoding can select.
+type xmlCharset int
+
+const (
+	charsetUTF8 xmlCharset = iota
+	charsetASCII
+	charsetLatin1
+)
+
+// xmlCharsets maps the supported encoding labels, compared case-insensitively.
+var xmlCharsets = map[string]xmlCharset{
+	"utf-8":      charsetUTF8,
+	"us-ascii":   charsetASCII,
+	"iso-8859-1": charsetLatin1,
+}
+
+// charset returns the output encoding selected by opts.Encoding.
+func (opts RenderOptions) charset() (xmlCharset, error) {
+	enc := strings.TrimSpace(opts.Encoding)
+	if enc == "" {
+		return charsetUTF8, nil
+	}
+	cs, ok := xmlCharsets[strings.ToLower(enc)]
+	if !ok {
+		return 0, fmt.Errorf("render: unsupported encoding %q (use UTF-8, US-ASCII or ISO-8859-1)", enc)
+	}
+	return cs, nil
+}
+
+// xmlScanState is the kind of document region an xmlScanner is in.
+type xmlScanState int
+
+const (
+	scanText xmlScanState = iota
+	scanTag
+	scanAttr
+	scanCDATA
+	scanComment
+	scanPI
+)
+
+// xmlScanner tracks which region of an encoder-written XML document a position is in.
+type xmlScanner struct {
+	state xmlScanState
+	quote byte
+}
+
+// step advances past r, the rune at the start of rest, and returns the length of the
+// multi-byte delimiter starting there (which callers copy whole), or 0.
+func (s *xmlScanner) step(rest []byte, r rune) int {
+	switch s.state {
+	case scanText:
+		switch {
+		case bytes.HasPrefix(rest, []byte("<![CDATA[")):
+			s.state = scanCDATA
+			return len("<![CDATA[")
+		case bytes.HasPrefix(rest, []byte("<!--")):
+			s.state = scanComment
+			return len("<!--")
+		case bytes.HasPrefix(rest, []byte("<?")):
+			s.state = scanPI
+			return len("<?")
+		case r == '<':
+			s.state = scanTag
+		}
+	case scanTag:
+		switch r {
+		case '"', '\'':
+			s.state, s.quote = scanAttr, byte(r)
+		case '>':
+			s.state = scanText
+		}
+	case scanAttr:
+		if r == rune(s.quote) {
+			s.state = scanTag
+		}
+	case scanCDATA:
+		if bytes.HasPrefix(rest, []byte("]]>")) {
+			s.state = scanText
+			return len("]]>")
+		}
+	case scanComment:
+		if bytes.HasPrefix(rest, []byte("-->")) {
+			s.state = scanText
+			return len("-->")
+		}
+	case scanPI:
+		if bytes.HasPrefix(rest, []byte("?>")) {
+			s.state = scanText
+			return len("?>")
+		}
+	}
+	return 0
+}
+
+// transcodeXML converts a UTF-8 XML document to cs. Characters cs cannot represent become
+// character references in text and attribute values; CDATA sections are split around them.
+// Such characters in names, comments or processing instructions are an error.
+func transcodeXML(data []byte, cs xmlCharset) ([]byte, error) {
+	if cs == charsetUTF8 {
+		return data, nil
+	}
+	limit := rune(0x7f)
+	if cs == charsetLatin1 {
+		limit = 0xff
+	}
+	out := make([]byte, 0, len(data)+len(data)/8)
+	var sc xmlScanner
+	reopened := false // out ends with a CDATA section reopened after a character reference
+	for i := 0; i < len(data); {
+		r, size := utf8.DecodeRune(data[i:])
+		if r > limit {
+			ref := fmt.Sprintf("&#x%X;", r)
+			switch sc.state {
+			case scanText, scanAttr:
+				out = append(out, ref...)
+			case scanCDATA:
+				if reopened {
+					out = append(out[:len(out)-len("<![CDATA[")], ref+"<![CDATA["...)
+				} else {
+					out = append(out, "]]>"+ref+"<![CDATA["...)
+				}
+				reopened = true
+			default:
+				return nil, fmt.Errorf("render: %U in markup cannot be written in the selected encoding", r)
+			}
+			i += size
+			continue
+		}
+		rest := data[i:]
+		inCDATA := sc.state == scanCDATA
+		delim := sc.step(rest, r)
+		if inCDATA && sc.state == scanText && reopened {
+			// drop the empty section left behind by a trailing reference
+			out, reopened = out[:len(out)-len("<![CDATA[")], false
+			i += delim
+			continue
+		}
+		reopened = false
+		if delim > 0 {
+			out = append(out, rest[:delim]...)
+			i += delim
+			continue
+		}
+		if r >= utf8.RuneSelf {
+			out = append(out, byte(r)) // Latin-1
+		} else {
+			out = append(out, rest[:size]...)
+		}
+		i += size
+	}
+	return out, nil
+}
@@ -0,0 +1,32 @@
+package gofeedx
+
+import "time"
+
+// Clock supplies the current time to Build. Inject one with WithClock to pin "now" in tests
+// and reproducible builds.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a function to the Clock interface.
+type ClockFunc func() time.Time
+
+// Now calls f.
+func (f ClockFunc) Now() time.Time { return f() }
+
+// SystemClock reads the system time.
+var SystemClock Clock = ClockFunc(time.Now)
+
+// FixedClock returns a Clock that always reports t.
+func FixedClock(t time.Time) Clock {
+	return ClockFunc(func() time.Time { return t })
+}
+
+// WithClock sets the clock Build uses as the build time: a feed without Updated gets the
+// clock's time (RSS lastBuildDate, Atom and JSON feed updated) instead of being derived from
+// its items or left empty. Combine with WithDeterministicOutput for byte-identical output,
+// since it also replaces random fallback item IDs with name-based ones.
+func (b *FeedBuilder) WithClock(c Clock) *FeedBuilder {
+	b.clock = c
+	return b
+}
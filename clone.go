@@ -0,0 +1,114 @@
+package gofeedx
+
+// Clone returns a deep copy of f: items, links, authors, images, enclosures, categories and
+// extension nodes (attributes and children) are copied, so the clone can be modified, e.g. to
+// produce a members-only variant with different enclosures, without affecting f.
+func (f *Feed) Clone() *Feed {
+	if f == nil {
+		return nil
+	}
+	c := *f
+	c.Link = cloneLink(f.Link)
+	c.Author = cloneAuthor(f.Author)
+	if f.License != nil {
+		l := *f.License
+		c.License = &l
+	}
+	if f.Generator != nil {
+		g := *f.Generator
+		c.Generator = &g
+	}
+	if f.Image != nil {
+		img := *f.Image
+		c.Image = &img
+	}
+	if f.Images != nil {
+		imgs := *f.Images
+		c.Images = &imgs
+	}
+	c.Categories = cloneCategories(f.Categories)
+	c.Extensions = CloneExtensions(f.Extensions)
+	c.options = CloneExtensions(f.options)
+	if f.Items != nil {
+		c.Items = make([]*Item, len(f.Items))
+		for i, it := range f.Items {
+			c.Items[i] = it.Clone()
+		}
+	}
+	return &c
+}
+
+// Clone returns a deep copy of it (see Feed.Clone).
+func (it *Item) Clone() *Item {
+	if it == nil {
+		return nil
+	}
+	c := *it
+	c.Link = cloneLink(it.Link)
+	c.Source = cloneLink(it.Source)
+	c.Author = cloneAuthor(it.Author)
+	if it.Enclosure != nil {
+		enc := *it.Enclosure
+		c.Enclosure = &enc
+	}
+	if it.SourceFeed != nil {
+		sf := *it.SourceFeed
+		c.SourceFeed = &sf
+	}
+	if it.CommentCount != nil {
+		n := *it.CommentCount
+		c.CommentCount = &n
+	}
+	c.Extensions = CloneExtensions(it.Extensions)
+	c.options = CloneExtensions(it.options)
+	return &c
+}
+
+// CloneExtensions deep-copies extension nodes including their attributes and children.
+func CloneExtensions(exts []ExtensionNode) []ExtensionNode {
+	if exts == nil {
+		return nil
+	}
+	out := make([]ExtensionNode, len(exts))
+	for i, n := range exts {
+		out[i] = n
+		if n.Attrs != nil {
+			out[i].Attrs = make(map[string]string, len(n.Attrs))
+			for k, v := range n.Attrs {
+				out[i].Attrs[k] = v
+			}
+		}
+		out[i].Children = CloneExtensions(n.Children)
+	}
+	return out
+}
+
+func cloneLink(l *Link) *Link {
+	if l == nil {
+		return nil
+	}
+	c := *l
+	return &c
+}
+
+func cloneAuthor(a *Author) *Author {
+	if a == nil {
+		return nil
+	}
+	c := *a
+	return &c
+}
+
+func cloneCategories(cats []*Category) []*Category {
+	if cats == nil {
+		return nil
+	}
+	out := make([]*Category, len(cats))
+	for i, cat := range cats {
+		if cat != nil {
+			c := *cat
+			out[i] = &c
+		}
+	}
+	return out
+}
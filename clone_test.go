@@ -0,0 +1,60 @@
+package gofeedx_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestFeedClone(t *testing.T) {
+	count := 3
+	orig := &gofeedx.Feed{
+		Title:      "Orig",
+		Link:       &gofeedx.Link{Href: "https://example.org/"},
+		Author:     &gofeedx.Author{Name: "A"},
+		Image:      &gofeedx.Image{Url: "https://example.org/a.png"},
+		Categories: []*gofeedx.Category{{Text: "Tech"}},
+		Extensions: []gofeedx.ExtensionNode{{Name: "x:a", Attrs: map[string]string{"k": "v"}, Children: []gofeedx.ExtensionNode{{Name: "x:b", Attrs: map[string]string{"c": "d"}}}}},
+		Items: []*gofeedx.Item{{
+			Title:        "Ep",
+			Created:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Enclosure:    &gofeedx.Enclosure{Url: "https://cdn.example.org/public.mp3", Type: "audio/mpeg", Length: 1},
+			SourceFeed:   &gofeedx.SourceFeed{Title: "S"},
+			CommentCount: &count,
+			Extensions:   []gofeedx.ExtensionNode{{Name: "y:z", Attrs: map[string]string{"a": "b"}}},
+		}},
+	}
+	c := orig.Clone()
+	if !reflect.DeepEqual(orig, c) {
+		t.Fatal("clone must equal the original")
+	}
+
+	c.Link.Href = "https://members.example.org/"
+	c.Author.Name = "B"
+	c.Image.Url = "x"
+	c.Categories[0].Text = "News"
+	c.Extensions[0].Attrs["k"] = "changed"
+	c.Extensions[0].Children[0].Attrs["c"] = "changed"
+	c.Items[0].Enclosure.Url = "https://cdn.example.org/premium.mp3"
+	c.Items[0].SourceFeed.Title = "T"
+	*c.Items[0].CommentCount = 9
+	c.Items[0].Extensions[0].Attrs["a"] = "changed"
+	c.Items = append(c.Items, &gofeedx.Item{Title: "Bonus"})
+
+	if orig.Link.Href != "https://example.org/" || orig.Author.Name != "A" || orig.Image.Url != "https://example.org/a.png" ||
+		orig.Categories[0].Text != "Tech" || orig.Extensions[0].Attrs["k"] != "v" || orig.Extensions[0].Children[0].Attrs["c"] != "d" {
+		t.Error("feed-level data aliased between clone and original")
+	}
+	it := orig.Items[0]
+	if it.Enclosure.Url != "https://cdn.example.org/public.mp3" || it.SourceFeed.Title != "S" || *it.CommentCount != 3 ||
+		it.Extensions[0].Attrs["a"] != "b" || len(orig.Items) != 1 {
+		t.Error("item data aliased between clone and original")
+	}
+
+	var nilFeed *gofeedx.Feed
+	if nilFeed.Clone() != nil {
+		t.Error("expected nil clone for nil feed")
+	}
+}
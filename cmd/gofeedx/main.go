@@ -0,0 +1,171 @@
+// Command gofeedx validates a feed and renders it as RSS, Atom, JSON Feed or PSP-1 podcast RSS,
+// so pipelines without Go code can use the library's validation and writers.
+//
+// Usage:
+//
+//	gofeedx [-profiles rss,atom,json,psp] [-out dir] [-check] [-format snapshot|yaml] [file]
+//
+// The input is a canonical feed snapshot (see gofeedx.Feed.MarshalCanonical) or a YAML feed
+// description (see feedyaml.Load), read from file or, when file is omitted or "-",
+// from standard input. Files ending in .yaml or .yml are read as YAML unless -format is given. The feed is validated against every profile;
+// with -check nothing else happens. Otherwise a single profile is written to standard output,
+// or each profile is written to a file in the -out directory (rss.xml, atom.xml, feed.json,
+// podcast.xml). The exit status is 1 when validation or rendering fails and 2 on usage errors.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jo-hoe/gofeedx"
+	"github.com/jo-hoe/gofeedx/feedyaml"
+)
+
+// outputFiles names the file written per profile with -out.
+var outputFiles = map[gofeedx.Profile]string{
+	gofeedx.ProfileRSS:  "rss.xml",
+	gofeedx.ProfileAtom: "atom.xml",
+	gofeedx.ProfileJSON: "feed.json",
+	gofeedx.ProfilePSP:  "podcast.xml",
+}
+
+// validators are the library validators per profile.
+var validators = map[gofeedx.Profile]func(*gofeedx.Feed) error{
+	gofeedx.ProfileRSS:  gofeedx.ValidateRSS,
+	gofeedx.ProfileAtom: gofeedx.ValidateAtom,
+	gofeedx.ProfileJSON: gofeedx.ValidateJSON,
+	gofeedx.ProfilePSP:  gofeedx.ValidatePSP,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("gofeedx", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	profileList := fs.String("profiles", "rss", "comma-separated profiles to validate and write: rss, atom, json, psp")
+	outDir := fs.String("out", "", "directory to write one file per profile to (default: standard output)")
+	checkOnly := fs.Bool("check", false, "validate only; write no output")
+	format := fs.String("format", "", "input format: snapshot or yaml (default: by file extension, snapshot for stdin)")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: gofeedx [-profiles rss,atom,json,psp] [-out dir] [-check] [-format snapshot|yaml] [file]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return 2
+	}
+	profiles, err := parseProfiles(*profileList)
+	if err != nil {
+		fmt.Fprintln(stderr, "gofeedx:", err)
+		return 2
+	}
+	switch *format {
+	case "", "snapshot", "yaml":
+	default:
+		fmt.Fprintf(stderr, "gofeedx: unknown format %q\n", *format)
+		return 2
+	}
+	if !*checkOnly && *outDir == "" && len(profiles) > 1 {
+		fmt.Fprintln(stderr, "gofeedx: -out is required to write more than one profile")
+		return 2
+	}
+
+	feed, err := readFeed(fs.Arg(0), *format, stdin)
+	if err != nil {
+		fmt.Fprintln(stderr, "gofeedx:", err)
+		return 1
+	}
+	var verr error
+	for _, p := range profiles {
+		if err := validators[p](feed); err != nil {
+			verr = errors.Join(verr, err)
+		}
+	}
+	if verr != nil {
+		fmt.Fprintln(stderr, "gofeedx: validation failed:")
+		fmt.Fprintln(stderr, verr)
+		return 1
+	}
+	if *checkOnly {
+		return 0
+	}
+
+	for _, p := range profiles {
+		out, err := gofeedx.Render(feed, p)
+		if err != nil {
+			fmt.Fprintf(stderr, "gofeedx: %s: %v\n", p, err)
+			return 1
+		}
+		if *outDir == "" {
+			if _, err := io.WriteString(stdout, out); err != nil {
+				fmt.Fprintln(stderr, "gofeedx:", err)
+				return 1
+			}
+			continue
+		}
+		if err := os.WriteFile(filepath.Join(*outDir, outputFiles[p]), []byte(out), 0o644); err != nil {
+			fmt.Fprintln(stderr, "gofeedx:", err)
+			return 1
+		}
+	}
+	return 0
+}
+
+// parseProfiles parses the -profiles list, dropping duplicates.
+func parseProfiles(list string) ([]gofeedx.Profile, error) {
+	var profiles []gofeedx.Profile
+	for _, name := range strings.Split(list, ",") {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		p, ok := gofeedx.ProfileByName(name)
+		if _, builtin := validators[p]; !ok || !builtin {
+			return nil, fmt.Errorf("unknown profile %q", strings.TrimSpace(name))
+		}
+		if !slices.Contains(profiles, p) {
+			profiles = append(profiles, p)
+		}
+	}
+	if len(profiles) == 0 {
+		return nil, errors.New("no profiles given")
+	}
+	return profiles, nil
+}
+
+// readFeed reads the feed at path, or from stdin when path is "" or "-", in format; an empty
+// format is picked from the file extension.
+func readFeed(path, format string, stdin io.Reader) (*gofeedx.Feed, error) {
+	var (
+		data []byte
+		err  error
+	)
+	if path == "" || path == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if format == "" {
+		if ext := strings.ToLower(filepath.Ext(path)); ext == ".yaml" || ext == ".yml" {
+			format = "yaml"
+		}
+	}
+	if format == "yaml" {
+		return feedyaml.Load(bytes.NewReader(data))
+	}
+	return gofeedx.UnmarshalCanonical(data)
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func snapshot(t *testing.T, b *gofeedx.FeedBuilder) []byte {
+	t.Helper()
+	f, err := b.Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	data, err := f.MarshalCanonical()
+	if err != nil {
+		t.Fatalf("MarshalCanonical failed: %v", err)
+	}
+	return data
+}
+
+func sampleFeed() *gofeedx.FeedBuilder {
+	b := gofeedx.NewFeed("CLI").
+		WithLink("https://example.com/").
+		WithDescription("d").
+		WithLanguage("en").
+		WithFeedURL("https://example.com/podcast.xml").
+		WithImage("https://example.com/artwork.jpg", "", "").
+		WithCategories("Technology").
+		WithAuthor("Host", "host@example.com").
+		WithUpdated(time.Date(2024, 5, 2, 8, 0, 0, 0, time.UTC))
+	b.AddItem(gofeedx.NewItem("E1").WithID("1").WithCreated(time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)).
+		WithEnclosure("https://example.com/e1.mp3", 1, "audio/mpeg").WithDescription("e"))
+	return b
+}
+
+func TestRun_StdinToStdout(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-profiles", "psp"}, bytes.NewReader(snapshot(t, sampleFeed())), &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "<itunes:image") {
+		t.Errorf("expected PSP output, got %s", stdout.String())
+	}
+}
+
+func TestRun_WritesFilesPerProfile(t *testing.T) {
+	dir := t.TempDir()
+	in := filepath.Join(dir, "feed.snapshot.json")
+	if err := os.WriteFile(in, snapshot(t, sampleFeed()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-profiles", "rss,atom,json,psp", "-out", dir, in}, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr.String())
+	}
+	for _, name := range []string{"rss.xml", "atom.xml", "feed.json", "podcast.xml"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected %s to be written: %v", name, err)
+		}
+	}
+}
+
+func TestRun_YAMLInput(t *testing.T) {
+	in := filepath.Join(t.TempDir(), "podcast.yaml")
+	yaml := `title: YAML
+link: https://example.com/
+description: d
+author: Host
+updated: 2024-05-02T08:00:00Z
+items:
+  - title: E1
+    id: "1"
+    created: 2024-05-01T08:00:00Z
+`
+	if err := os.WriteFile(in, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-profiles", "atom", in}, nil, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "<title>E1</title>") {
+		t.Errorf("expected Atom output of the YAML feed, got %s", stdout.String())
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	invalid := snapshot(t, gofeedx.NewFeed("No link"))
+	tests := []struct {
+		name string
+		args []string
+		in   []byte
+		code int
+		msg  string
+	}{
+		{"validation", []string{"-profiles", "psp", "-check"}, invalid, 1, "validation failed"},
+		{"unknown profile", []string{"-profiles", "mp3"}, nil, 2, `unknown profile "mp3"`},
+		{"several profiles to stdout", []string{"-profiles", "rss,atom"}, nil, 2, "-out is required"},
+		{"malformed input", nil, []byte("{"), 1, "snapshot:"},
+		{"malformed yaml", []string{"-format", "yaml"}, []byte("title: [a\n"), 1, "yaml:"},
+		{"unknown format", []string{"-format", "toml"}, nil, 2, `unknown format "toml"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, bytes.NewReader(tt.in), &stdout, &stderr)
+			if code != tt.code || !strings.Contains(stderr.String(), tt.msg) {
+				t.Fatalf("got exit %d, stderr %q; want exit %d containing %q", code, stderr.String(), tt.code, tt.msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package gofeedx
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// CollisionPolicy selects how Build treats extension nodes that collide: a single-valued
+// element (e.g. itunes:explicit) added more than once at the same scope, an extension that
+// duplicates a typed field (e.g. itunes:author next to Feed.Author), or a node in Extensions
+// that uses a reserved internal prefix ("_rss:", "_atom:", "_json:", "_psp:", "_xml:",
+// "_websub:"). CheckExtensionCollisions reports the same collisions as lint warnings.
+type CollisionPolicy int
+
+const (
+	// CollisionKeepAll leaves nodes as added; writers apply their usual precedence.
+	CollisionKeepAll CollisionPolicy = iota
+	// CollisionKeepFirst drops later copies of a single-valued element.
+	CollisionKeepFirst
+	// CollisionKeepLast drops earlier copies of a single-valued element.
+	CollisionKeepLast
+	// CollisionError makes Build fail on any collision.
+	CollisionError
+)
+
+// WithCollisionPolicy makes Build detect extension collisions and resolve them per policy.
+// Typed-field duplicates and reserved prefixes are only rejected by CollisionError; the
+// keep policies resolve repeated single-valued elements.
+func (b *FeedBuilder) WithCollisionPolicy(policy CollisionPolicy) *FeedBuilder {
+	b.collisions = &policy
+	return b
+}
+
+// singleValuedChannelNodes lists channel elements that may appear at most once.
+var singleValuedChannelNodes = map[string]bool{
+	"itunes:author":       true,
+	"itunes:block":        true,
+	"itunes:complete":     true,
+	"itunes:explicit":     true,
+	"itunes:image":        true,
+	"itunes:new-feed-url": true,
+	"itunes:type":         true,
+	"podcast:guid":        true,
+	"podcast:license":     true,
+	"podcast:locked":      true,
+	"podcast:medium":      true,
+}
+
+// singleValuedItemNodes lists item elements that may appear at most once.
+var singleValuedItemNodes = map[string]bool{
+	"itunes:author":      true,
+	"itunes:block":       true,
+	"itunes:duration":    true,
+	"itunes:episode":     true,
+	"itunes:episodetype": true,
+	"itunes:explicit":    true,
+	"itunes:image":       true,
+	"itunes:season":      true,
+	"podcast:chapters":   true,
+	"podcast:episode":    true,
+	"podcast:license":    true,
+	"podcast:season":     true,
+}
+
+// CheckExtensionCollisions reports extension collisions (see CollisionPolicy) as warnings.
+func CheckExtensionCollisions() Check {
+	return func(feed *Feed) []Issue {
+		var out []Issue
+		for _, c := range findCollisions(feed) {
+			out = append(out, Issue{Path: c.path, Severity: SeverityWarning, Message: c.message})
+		}
+		return out
+	}
+}
+
+type extensionCollision struct {
+	path, message string
+}
+
+func findCollisions(f *Feed) []extensionCollision {
+	var typed []string
+	if f.Author != nil && strings.TrimSpace(f.Author.Name) != "" {
+		typed = append(typed, "itunes:author")
+	}
+	out := scopeCollisions("Feed", f.Extensions, f.allExtensions(), singleValuedChannelNodes, typed)
+	for i, it := range f.Items {
+		if it == nil {
+			continue
+		}
+		typed = typed[:0]
+		if it.DurationSeconds > 0 {
+			typed = append(typed, "itunes:duration")
+		}
+		if strings.TrimSpace(it.ImageURL) != "" {
+			typed = append(typed, "itunes:image")
+		}
+		out = append(out, scopeCollisions(fmt.Sprintf("Items[%d]", i), it.Extensions, it.allExtensions(), singleValuedItemNodes, typed)...)
+	}
+	return out
+}
+
+// scopeCollisions checks one scope: user holds the caller's Extensions, all adds the builder
+// options, single lists single-valued names and typed the names a typed field already emits.
+func scopeCollisions(scope string, user, all []ExtensionNode, single map[string]bool, typed []string) []extensionCollision {
+	var out []extensionCollision
+	for _, n := range user {
+		if IsInternalExtensionName(n.Name) {
+			out = append(out, extensionCollision{
+				path:    fmt.Sprintf("%s.Extensions[%s]", scope, strings.TrimSpace(n.Name)),
+				message: "uses a reserved internal prefix; prefer the matching builder helper",
+			})
+		}
+	}
+	counts := map[string]int{}
+	for _, n := range all {
+		if name := textLowerTrim(n.Name); single[name] {
+			counts[name]++
+		}
+	}
+	for _, n := range all {
+		name := textLowerTrim(n.Name)
+		path := fmt.Sprintf("%s.Extensions[%s]", scope, strings.TrimSpace(n.Name))
+		if counts[name] > 1 {
+			out = append(out, extensionCollision{path: path, message: fmt.Sprintf("single-valued element added %d times", counts[name])})
+			counts[name] = 0 // report once
+		}
+		for _, t := range typed {
+			if name == t {
+				out = append(out, extensionCollision{path: path, message: "duplicates a typed field"})
+			}
+		}
+	}
+	return out
+}
+
+// resolveCollisions applies policy to f and its items.
+func resolveCollisions(f *Feed, policy CollisionPolicy) error {
+	switch policy {
+	case CollisionError:
+		var errs []error
+		for _, c := range findCollisions(f) {
+			errs = append(errs, fmt.Errorf("builder: %s %s", c.path, c.message))
+		}
+		return errors.Join(errs...)
+	case CollisionKeepFirst, CollisionKeepLast:
+		last := policy == CollisionKeepLast
+		f.Extensions = dropRepeated(f.Extensions, singleValuedChannelNodes, last)
+		for _, it := range f.Items {
+			it.Extensions = dropRepeated(it.Extensions, singleValuedItemNodes, last)
+		}
+	}
+	return nil
+}
+
+// dropRepeated keeps one node per single-valued name, the first or the last, preserving order.
+func dropRepeated(exts []ExtensionNode, single map[string]bool, keepLast bool) []ExtensionNode {
+	keep := map[string]int{}
+	for i, n := range exts {
+		name := textLowerTrim(n.Name)
+		if !single[name] {
+			continue
+		}
+		if _, seen := keep[name]; !seen || keepLast {
+			keep[name] = i
+		}
+	}
+	if len(keep) == 0 {
+		return exts
+	}
+	out := exts[:0:0]
+	for i, n := range exts {
+		if j, ok := keep[textLowerTrim(n.Name)]; ok && j != i {
+			continue
+		}
+		out = append(out, n)
+	}
+	return out
+}
@@ -0,0 +1,62 @@
+package gofeedx_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func newCollidingFeed() *gofeedx.FeedBuilder {
+	return gofeedx.NewFeed("Show").
+		WithAuthor("Host", "").
+		WithPSPExplicit(false).
+		WithExtensions(
+			gofeedx.ExtensionNode{Name: "itunes:explicit", Text: "true"},
+			gofeedx.ExtensionNode{Name: "itunes:author", Text: "Other Host"},
+			gofeedx.ExtensionNode{Name: "_rss:ttl", Text: "5"},
+		)
+}
+
+func TestCollisions_Lint(t *testing.T) {
+	f, err := newCollidingFeed().Build()
+	mustNoErr(t, err, "Build failed")
+
+	issues := gofeedx.Lint(f, gofeedx.CheckExtensionCollisions())
+	var got []string
+	for _, i := range issues {
+		if i.Severity != gofeedx.SeverityWarning {
+			t.Errorf("expected warning, got %v", i)
+		}
+		got = append(got, i.String())
+	}
+	all := strings.Join(got, "\n")
+	mustContain(t, all, "Feed.Extensions[_rss:ttl] warning: uses a reserved internal prefix", "expected reserved prefix warning")
+	mustContain(t, all, "Feed.Extensions[itunes:explicit] warning: single-valued element added 2 times", "expected duplicate warning")
+	mustContain(t, all, "Feed.Extensions[itunes:author] warning: duplicates a typed field", "expected typed field warning")
+}
+
+func TestCollisions_Policy(t *testing.T) {
+	_, err := newCollidingFeed().WithCollisionPolicy(gofeedx.CollisionError).Build()
+	mustErr(t, err, "expected CollisionError to fail Build")
+
+	count := func(f *gofeedx.Feed) (n int, text string) {
+		for _, e := range f.Extensions {
+			if e.Name == "itunes:explicit" {
+				n++
+				text = e.Text
+			}
+		}
+		return n, text
+	}
+	f, err := newCollidingFeed().WithCollisionPolicy(gofeedx.CollisionKeepFirst).Build()
+	mustNoErr(t, err, "Build failed")
+	if n, text := count(f); n != 1 || text != "false" {
+		t.Errorf("KeepFirst: expected one itunes:explicit=false, got %d %q", n, text)
+	}
+	f, err = newCollidingFeed().WithCollisionPolicy(gofeedx.CollisionKeepLast).Build()
+	mustNoErr(t, err, "Build failed")
+	if n, text := count(f); n != 1 || text != "true" {
+		t.Errorf("KeepLast: expected one itunes:explicit=true, got %d %q", n, text)
+	}
+}
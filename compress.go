@@ -0,0 +1,76 @@
+package gofeedx
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+)
+
+// Compression selects how an Encoder compresses its output.
+type Compression int
+
+const (
+	// CompressionNone writes the feed as is.
+	CompressionNone Compression = iota
+	// CompressionGzip writes a gzip stream (Content-Encoding: gzip).
+	CompressionGzip
+	// CompressionDeflate writes a zlib stream, which is what HTTP calls
+	// Content-Encoding: deflate (RFC 9110), not raw DEFLATE data.
+	CompressionDeflate
+)
+
+// ContentEncoding returns the HTTP Content-Encoding value for c, or "" for CompressionNone.
+func (c Compression) ContentEncoding() string {
+	switch c {
+	case CompressionGzip:
+		return "gzip"
+	case CompressionDeflate:
+		return "deflate"
+	}
+	return ""
+}
+
+// write writes data to w compressed with c.
+func (c Compression) write(w io.Writer, data []byte) error {
+	var zw io.WriteCloser
+	switch c {
+	case CompressionNone:
+		_, err := w.Write(data)
+		return err
+	case CompressionGzip:
+		zw = gzip.NewWriter(w)
+	case CompressionDeflate:
+		zw = zlib.NewWriter(w)
+	default:
+		return fmt.Errorf("render: unknown compression %d", c)
+	}
+	if _, err := zw.Write(data); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// gzipEncoder backs the Write*Gzip helpers; Encoders are safe for concurrent use.
+var gzipEncoder = NewEncoderWithOptions(EncodeOptions{Compression: CompressionGzip})
+
+// WriteRSSGzip writes feed as gzip-compressed RSS 2.0 to w.
+func WriteRSSGzip(feed *Feed, w io.Writer) error {
+	return gzipEncoder.EncodeRSS(feed, w)
+}
+
+// WriteAtomGzip writes feed as gzip-compressed Atom 1.0 to w.
+func WriteAtomGzip(feed *Feed, w io.Writer) error {
+	return gzipEncoder.EncodeAtom(feed, w)
+}
+
+// WritePSPGzip writes feed as gzip-compressed PSP-1 RSS to w.
+func WritePSPGzip(feed *Feed, w io.Writer) error {
+	return gzipEncoder.EncodePSP(feed, w)
+}
+
+// WriteJSONGzip writes feed as a gzip-compressed JSON Feed to w. For very large feeds,
+// WriteJSONStreamGzip keeps memory bounded instead.
+func WriteJSONGzip(feed *Feed, w io.Writer) error {
+	return gzipEncoder.EncodeJSON(feed, w)
+}
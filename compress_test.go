@@ -0,0 +1,60 @@
+package gofeedx_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"testing"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestWriteGzipHelpers(t *testing.T) {
+	f := newRSSBaseFeed()
+	it := newRSSBaseItem()
+	it.ID = "item-1"
+	f.Items = []*gofeedx.Item{it}
+	cases := []struct {
+		name   string
+		write  func(*gofeedx.Feed, io.Writer) error
+		render func(*gofeedx.Feed) (string, error)
+	}{
+		{"rss", gofeedx.WriteRSSGzip, gofeedx.ToRSS},
+		{"atom", gofeedx.WriteAtomGzip, gofeedx.ToAtom},
+		{"psp", gofeedx.WritePSPGzip, gofeedx.ToPSP},
+		{"json", gofeedx.WriteJSONGzip, gofeedx.ToJSON},
+	}
+	for _, tc := range cases {
+		want, err := tc.render(f)
+		mustNoErr(t, err, tc.name+" render")
+		var buf bytes.Buffer
+		mustNoErr(t, tc.write(f, &buf), tc.name+" gzip write")
+		zr, err := gzip.NewReader(&buf)
+		mustNoErr(t, err, tc.name+" gzip reader")
+		got, err := io.ReadAll(zr)
+		mustNoErr(t, err, tc.name+" gzip read")
+		if string(got) != want {
+			t.Fatalf("%s: decompressed output differs", tc.name)
+		}
+	}
+}
+
+func TestEncoder_CompressionDeflate(t *testing.T) {
+	f := newRSSBaseFeed()
+	enc := gofeedx.NewEncoderWithOptions(gofeedx.EncodeOptions{Compression: gofeedx.CompressionDeflate})
+	var buf bytes.Buffer
+	mustNoErr(t, enc.EncodeRSS(f, &buf), "EncodeRSS")
+	zr, err := zlib.NewReader(&buf)
+	mustNoErr(t, err, "zlib reader")
+	got, err := io.ReadAll(zr)
+	mustNoErr(t, err, "zlib read")
+	want, err := gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS")
+	if string(got) != want {
+		t.Fatal("decompressed output differs")
+	}
+	if gofeedx.CompressionDeflate.ContentEncoding() != "deflate" || gofeedx.CompressionGzip.ContentEncoding() != "gzip" || gofeedx.CompressionNone.ContentEncoding() != "" {
+		t.Fatal("unexpected Content-Encoding values")
+	}
+}
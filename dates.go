@@ -0,0 +1,61 @@
+package gofeedx
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// FormatRFC1123Z formats t as RSS and PSP write pubDate and lastBuildDate
+// ("Mon, 02 Jan 2006 15:04:05 -0700"); the zero time yields "".
+func FormatRFC1123Z(t time.Time) string {
+	return anyTimeFormat(time.RFC1123Z, t)
+}
+
+// FormatRFC3339 formats t as Atom writes updated and published; the zero time yields "".
+func FormatRFC3339(t time.Time) string {
+	return anyTimeFormat(time.RFC3339, t)
+}
+
+// feedTimeLayouts are tried in order by ParseFeedTime.
+var feedTimeLayouts = []string{
+	time.RFC1123Z,
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"2 Jan 2006 15:04:05 -0700",
+	"Mon, 2 Jan 2006 15:04 -0700",
+	"2 Jan 2006 15:04 -0700",
+	"Mon, 2 Jan 06 15:04:05 -0700",
+	time.RFC822Z,
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// feedTimeZones maps the zone names RFC 822 allows to their offsets. ParseFeedTime rewrites
+// them as numeric offsets and rejects other abbreviations, which Go would read as UTC.
+var feedTimeZones = map[string]string{
+	"UT": "+0000", "GMT": "+0000", "UTC": "+0000", "Z": "+0000",
+	"EST": "-0500", "EDT": "-0400", "CST": "-0600", "CDT": "-0500",
+	"MST": "-0700", "MDT": "-0600", "PST": "-0800", "PDT": "-0700",
+}
+
+// ParseFeedTime leniently parses a feed date: RFC 1123 / RFC 822 with or without weekday,
+// seconds and four-digit year, with a numeric offset or an RFC 822 zone name (GMT, EST, ...),
+// and RFC 3339 with or without offset (interpreted as UTC) or as a plain date. It accepts
+// everything FormatRFC1123Z and FormatRFC3339 produce.
+func ParseFeedTime(s string) (time.Time, error) {
+	v := strings.Join(strings.Fields(s), " ")
+	if i := strings.LastIndexByte(v, ' '); i >= 0 {
+		if off, ok := feedTimeZones[strings.ToUpper(v[i+1:])]; ok {
+			v = v[:i+1] + off
+		}
+	}
+	for _, layout := range feedTimeLayouts {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid feed time %q", s)
+}
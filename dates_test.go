@@ -0,0 +1,58 @@
+package gofeedx_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestParseFeedTime(t *testing.T) {
+	want := time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC)
+	for _, s := range []string{
+		"Tue, 05 Mar 2024 14:30:00 +0000",
+		"Tue, 5 Mar 2024 14:30:00 GMT",
+		"  Tue,  05 Mar 2024   14:30:00 UT ",
+		"05 Mar 2024 14:30:00 +0000",
+		"Tue, 05 Mar 2024 09:30 EST",
+		"Tue, 05 Mar 24 14:30:00 Z",
+		"05 Mar 24 14:30 +0000",
+		"2024-03-05T14:30:00Z",
+		"2024-03-05T15:30:00+01:00",
+		"2024-03-05T14:30:00",
+		"2024-03-05 14:30:00",
+	} {
+		got, err := gofeedx.ParseFeedTime(s)
+		if err != nil {
+			t.Errorf("ParseFeedTime(%q): %v", s, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("ParseFeedTime(%q) = %v, want %v", s, got, want)
+		}
+	}
+	if d, err := gofeedx.ParseFeedTime("2024-03-05"); err != nil || !d.Equal(time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("plain date: %v, %v", d, err)
+	}
+	for _, bad := range []string{"", "yesterday", "Tue, 05 Mar 2024 14:30:00 CET", "2024-13-05"} {
+		if _, err := gofeedx.ParseFeedTime(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestFormatFeedTime_RoundTrip(t *testing.T) {
+	ts := time.Date(2024, 3, 5, 14, 30, 0, 0, time.FixedZone("", 2*3600))
+	if got := gofeedx.FormatRFC1123Z(ts); got != "Tue, 05 Mar 2024 14:30:00 +0200" {
+		t.Errorf("FormatRFC1123Z = %q", got)
+	}
+	if gofeedx.FormatRFC1123Z(time.Time{}) != "" || gofeedx.FormatRFC3339(time.Time{}) != "" {
+		t.Errorf("zero time should format as empty")
+	}
+	for _, s := range []string{gofeedx.FormatRFC1123Z(ts), gofeedx.FormatRFC3339(ts)} {
+		got, err := gofeedx.ParseFeedTime(s)
+		if err != nil || !got.Equal(ts) {
+			t.Errorf("round trip %q = %v, %v", s, got, err)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package gofeedx
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// PSPDescriptionMaxBytes is the PSP-1 limit for channel and item descriptions.
+const PSPDescriptionMaxBytes = 4000
+
+// DescriptionPolicy limits the length and HTML of Feed and Item descriptions.
+// Build applies it before validation (see WithPSPDescriptionPolicy).
+type DescriptionPolicy struct {
+	MaxBytes    int             // 0 means no limit
+	AllowedTags map[string]bool // lowercase tag names; nil allows any tag
+	FlagOnly    bool            // report violations as Build errors instead of fixing them
+}
+
+// DescriptionOption configures a DescriptionPolicy.
+type DescriptionOption func(*DescriptionPolicy)
+
+// TruncateAt limits descriptions to n bytes. Truncation never splits a UTF-8 sequence,
+// a tag or a character reference, and closes the elements left open (within the n bytes).
+func TruncateAt(n int) DescriptionOption {
+	return func(p *DescriptionPolicy) { p.MaxBytes = n }
+}
+
+// AllowTags keeps only the named HTML tags; other tags are removed with their text kept,
+// except script and style, whose contents are removed too.
+func AllowTags(tags ...string) DescriptionOption {
+	return func(p *DescriptionPolicy) {
+		if p.AllowedTags == nil {
+			p.AllowedTags = map[string]bool{}
+		}
+		for _, t := range tags {
+			if t = textLowerTrim(t); t != "" {
+				p.AllowedTags[t] = true
+			}
+		}
+	}
+}
+
+// FlagOnly makes the policy report violations instead of rewriting descriptions.
+func FlagOnly() DescriptionOption {
+	return func(p *DescriptionPolicy) { p.FlagOnly = true }
+}
+
+// NewDescriptionPolicy builds a policy from options.
+func NewDescriptionPolicy(opts ...DescriptionOption) DescriptionPolicy {
+	var p DescriptionPolicy
+	for _, o := range opts {
+		if o != nil {
+			o(&p)
+		}
+	}
+	return p
+}
+
+// WithPSPDescriptionPolicy makes Build fix (or, with FlagOnly, report) channel and item
+// descriptions that break the policy, e.g.
+// WithPSPDescriptionPolicy(TruncateAt(PSPDescriptionMaxBytes), AllowTags("p", "a", "b", "i", "ul", "ol", "li")).
+// The generic Description is rewritten, so all formats see the result.
+func (b *FeedBuilder) WithPSPDescriptionPolicy(opts ...DescriptionOption) *FeedBuilder {
+	p := NewDescriptionPolicy(opts...)
+	b.descPolicy = &p
+	return b
+}
+
+var (
+	htmlElementTagPattern = regexp.MustCompile(`</?([a-zA-Z][a-zA-Z0-9]*)\b[^>]*>`)
+	htmlScriptPattern     = regexp.MustCompile(`(?is)<(script|style)\b[^>]*>.*?</(script|style)\s*>`)
+	htmlEntityPattern     = regexp.MustCompile(`^&(#[0-9]+|#[xX][0-9a-fA-F]+|[a-zA-Z][a-zA-Z0-9]*);`)
+)
+
+// Apply returns s rewritten to satisfy the policy.
+func (p DescriptionPolicy) Apply(s string) string {
+	if p.AllowedTags != nil {
+		if !p.AllowedTags["script"] || !p.AllowedTags["style"] {
+			s = htmlScriptPattern.ReplaceAllStringFunc(s, func(m string) string {
+				if p.AllowedTags[strings.ToLower(htmlScriptPattern.FindStringSubmatch(m)[1])] {
+					return m
+				}
+				return ""
+			})
+		}
+		s = htmlElementTagPattern.ReplaceAllStringFunc(s, func(m string) string {
+			if p.AllowedTags[strings.ToLower(htmlElementTagPattern.FindStringSubmatch(m)[1])] {
+				return m
+			}
+			return ""
+		})
+	}
+	if p.MaxBytes > 0 && len(s) > p.MaxBytes {
+		s = truncateHTMLBytes(s, p.MaxBytes)
+	}
+	return s
+}
+
+// Violations describes how s breaks the policy; nil means it complies.
+func (p DescriptionPolicy) Violations(s string) []string {
+	var out []string
+	if p.MaxBytes > 0 && len(s) > p.MaxBytes {
+		out = append(out, fmt.Sprintf("%d bytes exceeds %d", len(s), p.MaxBytes))
+	}
+	if p.AllowedTags != nil {
+		seen := map[string]bool{}
+		for _, m := range htmlElementTagPattern.FindAllStringSubmatch(s, -1) {
+			if t := strings.ToLower(m[1]); !p.AllowedTags[t] {
+				seen[t] = true
+			}
+		}
+		tags := make([]string, 0, len(seen))
+		for t := range seen {
+			tags = append(tags, t)
+		}
+		sort.Strings(tags)
+		for _, t := range tags {
+			out = append(out, fmt.Sprintf("tag <%s> not allowed", t))
+		}
+	}
+	return out
+}
+
+// htmlVoidElements have no closing tag.
+var htmlVoidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// truncateHTMLBytes cuts s to at most n bytes without splitting a rune, a tag or a character
+// reference, and appends closing tags for the elements still open; the closing tags count
+// towards n.
+func truncateHTMLBytes(s string, n int) string {
+	tags := htmlElementTagPattern.FindAllStringSubmatchIndex(s, -1)
+	var open, bestOpen []string
+	closeLen, best := 0, 0
+	for i, t := 0, 0; ; {
+		if i+closeLen <= n {
+			best, bestOpen = i, append(bestOpen[:0], open...)
+		} else if i > n {
+			break
+		}
+		if i == len(s) {
+			break
+		}
+		switch {
+		case t < len(tags) && tags[t][0] == i:
+			m := tags[t]
+			name := strings.ToLower(s[m[2]:m[3]])
+			switch tag := s[m[0]:m[1]]; {
+			case strings.HasPrefix(tag, "</"):
+				for j := len(open) - 1; j >= 0; j-- {
+					if open[j] == name {
+						for _, o := range open[j:] {
+							closeLen -= len("</>") + len(o)
+						}
+						open = open[:j]
+						break
+					}
+				}
+			case !htmlVoidElements[name] && !strings.HasSuffix(tag, "/>"):
+				open = append(open, name)
+				closeLen += len("</>") + len(name)
+			}
+			i = m[1]
+			t++
+		case s[i] == '<':
+			// comments and other markup are kept whole
+			if j := strings.IndexByte(s[i:], '>'); j >= 0 {
+				i += j + 1
+			} else {
+				i = len(s)
+			}
+		case s[i] == '&':
+			if loc := htmlEntityPattern.FindStringIndex(s[i:]); loc != nil {
+				i += loc[1]
+			} else {
+				i++
+			}
+		default:
+			_, size := utf8.DecodeRuneInString(s[i:])
+			i += size
+		}
+	}
+	var b strings.Builder
+	b.WriteString(s[:best])
+	for j := len(bestOpen) - 1; j >= 0; j-- {
+		b.WriteString("</" + bestOpen[j] + ">")
+	}
+	return b.String()
+}
+
+// applyDescriptionPolicy rewrites descriptions in place or, with FlagOnly, returns one error
+// per violation.
+func applyDescriptionPolicy(f *Feed, p DescriptionPolicy) []error {
+	var errs []error
+	fix := func(path string, s *string) {
+		if !p.FlagOnly {
+			*s = p.Apply(*s)
+			return
+		}
+		for _, v := range p.Violations(*s) {
+			errs = append(errs, fmt.Errorf("description policy: %s: %s", path, v))
+		}
+	}
+	fix("Feed.Description", &f.Description)
+	for i, it := range f.Items {
+		if it != nil {
+			fix(fmt.Sprintf("Items[%d].Description", i), &it.Description)
+		}
+	}
+	return errs
+}
+
+// CheckDescriptionPolicy reports descriptions that break the policy as warnings, for use with Lint.
+func CheckDescriptionPolicy(opts ...DescriptionOption) Check {
+	p := NewDescriptionPolicy(opts...)
+	return func(feed *Feed) []Issue {
+		var out []Issue
+		report := func(path, s string) {
+			for _, v := range p.Violations(s) {
+				out = append(out, Issue{Path: path, Severity: SeverityWarning, Message: v})
+			}
+		}
+		report("Feed.Description", feed.Description)
+		for i, it := range feed.Items {
+			if it != nil {
+				report(fmt.Sprintf("Items[%d].Description", i), it.Description)
+			}
+		}
+		return out
+	}
+}
@@ -0,0 +1,73 @@
+package gofeedx_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestDescriptionPolicyApply(t *testing.T) {
+	p := gofeedx.NewDescriptionPolicy(gofeedx.AllowTags("p", "a", "b"))
+	got := p.Apply(`<p>Hi <span class="x">there</span> <a href="https://e.x/">link</a><script>alert(1)</script></p>`)
+	want := `<p>Hi there <a href="https://e.x/">link</a></p>`
+	if got != want {
+		t.Fatalf("Apply = %q, want %q", got, want)
+	}
+
+	p = gofeedx.NewDescriptionPolicy(gofeedx.TruncateAt(12))
+	if got := p.Apply("<p>ab <b>cd</b></p>"); got != "<p>ab </p>" {
+		t.Errorf("truncate must not split a tag, got %q", got)
+	}
+	p = gofeedx.NewDescriptionPolicy(gofeedx.TruncateAt(40))
+	if got := p.Apply(`<p><a href="https://e.x/">long text</a><br> more</p>`); got != `<p><a href="https://e.x/">long t</a></p>` {
+		t.Errorf("truncate must close open elements, got %q", got)
+	}
+	p = gofeedx.NewDescriptionPolicy(gofeedx.TruncateAt(12))
+	if got := p.Apply("abcdefgh &amp; more"); got != "abcdefgh " {
+		t.Errorf("truncate must not split an entity, got %q", got)
+	}
+	if got := p.Apply("ääääääää"); got != "ääääää" {
+		t.Errorf("truncate must not split a rune, got %q", got)
+	}
+}
+
+func TestWithPSPDescriptionPolicy(t *testing.T) {
+	long := "<p>" + strings.Repeat("x", 5000) + "</p><div>end</div>"
+	newBuilder := func(opts ...gofeedx.DescriptionOption) *gofeedx.FeedBuilder {
+		b := gofeedx.NewFeed("Show").
+			WithLink("https://example.com/").
+			WithDescription(long).
+			WithPSPDescriptionPolicy(opts...)
+		b.AddItem(gofeedx.NewItem("Ep").WithDescription(`<p>ok</p><img src="x.png">`))
+		return b
+	}
+
+	f, err := newBuilder(gofeedx.TruncateAt(gofeedx.PSPDescriptionMaxBytes), gofeedx.AllowTags("p")).Build()
+	mustNoErr(t, err, "Build with description policy")
+	if n := len(f.Description); n > gofeedx.PSPDescriptionMaxBytes {
+		t.Errorf("feed description is %d bytes, want <= %d", n, gofeedx.PSPDescriptionMaxBytes)
+	}
+	if f.Items[0].Description != "<p>ok</p>" {
+		t.Errorf("item description = %q", f.Items[0].Description)
+	}
+
+	_, err = newBuilder(gofeedx.TruncateAt(gofeedx.PSPDescriptionMaxBytes), gofeedx.AllowTags("p"), gofeedx.FlagOnly()).Build()
+	mustErr(t, err, "expected FlagOnly to report violations")
+	mustContain(t, err.Error(), "Feed.Description: 5021 bytes exceeds 4000", "unexpected error: "+err.Error())
+	mustContain(t, err.Error(), "Items[0].Description: tag <img> not allowed", "unexpected error: "+err.Error())
+
+	// the policy rewrites copies, not the items of the caller's ItemBuilders
+	ib := gofeedx.NewItem("Ep").WithDescription(long)
+	_, err = gofeedx.NewFeed("Show").WithLink("https://example.com/").WithDescription("d").
+		WithPSPDescriptionPolicy(gofeedx.TruncateAt(100)).AddItem(ib).Build()
+	mustNoErr(t, err, "Build with truncating policy")
+	if it, _ := ib.Build(); it.Description != long {
+		t.Errorf("truncation leaked into the ItemBuilder: %d bytes", len(it.Description))
+	}
+
+	issues := gofeedx.Lint(&gofeedx.Feed{Description: "<p>x</p><div>y</div>"}, gofeedx.CheckDescriptionPolicy(gofeedx.AllowTags("p")))
+	if len(issues) != 1 || issues[0].Path != "Feed.Description" {
+		t.Errorf("unexpected lint issues: %v", issues)
+	}
+}
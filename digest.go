@@ -0,0 +1,154 @@
+package gofeedx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DigestOptions configures ToDigest.
+type DigestOptions struct {
+	MaxItems int    // newest items to include; zero uses 10
+	Boundary string // multipart boundary; empty picks a random one
+}
+
+// Digest is a multipart/alternative email body.
+type Digest struct {
+	ContentType string // value for the message's Content-Type header, including the boundary
+	Body        []byte // MIME body with a text/plain and a text/html part
+}
+
+type digestItem struct {
+	Title, Link, Text string
+	HTML              template.HTML
+	Date              time.Time
+}
+
+var digestHTMLTemplate = template.Must(template.New("digest").Parse(`<!DOCTYPE html>
+<html><body>
+<h1>{{if .Link}}<a href="{{.Link}}">{{.Title}}</a>{{else}}{{.Title}}{{end}}</h1>
+{{range .Items}}<h2>{{if .Link}}<a href="{{.Link}}">{{.Title}}</a>{{else}}{{.Title}}{{end}}</h2>
+{{if not .Date.IsZero}}<p>{{.Date.Format "2 January 2006"}}</p>
+{{end}}{{.HTML}}
+{{end}}</body></html>
+`))
+
+// ToDigest renders the newest opts.MaxItems items of feed (by Updated or Created) as a
+// newsletter-style email body: a text/plain part with the descriptions as plain text and a
+// text/html part with the item HTML after the feed's HTMLSanitizer (see WithHTMLSanitizer),
+// both quoted-printable encoded. Without a sanitizer the HTML part carries the escaped plain
+// text, as item HTML is not trusted.
+func ToDigest(feed *Feed, opts DigestOptions) (*Digest, error) {
+	if feed == nil {
+		return nil, errors.New("nil feed")
+	}
+	sanitized := feed.sanitizer != nil
+	feed = feed.renderView(profilePage)
+	items := digestItems(feed, opts.MaxItems, sanitized)
+	link := ""
+	if feed.Link != nil {
+		link = strings.TrimSpace(feed.Link.Href)
+	}
+	title := strings.TrimSpace(feed.Title)
+
+	var text strings.Builder
+	text.WriteString(title + "\n")
+	if link != "" {
+		text.WriteString(link + "\n")
+	}
+	for _, it := range items {
+		text.WriteString("\n" + it.Title + "\n")
+		if !it.Date.IsZero() {
+			text.WriteString(it.Date.Format("2 January 2006") + "\n")
+		}
+		if it.Link != "" {
+			text.WriteString(it.Link + "\n")
+		}
+		if it.Text != "" {
+			text.WriteString("\n" + it.Text + "\n")
+		}
+	}
+	var html bytes.Buffer
+	data := struct {
+		Title, Link string
+		Items       []digestItem
+	}{title, link, items}
+	if err := digestHTMLTemplate.Execute(&html, data); err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	if opts.Boundary != "" {
+		if err := mw.SetBoundary(opts.Boundary); err != nil {
+			return nil, fmt.Errorf("digest: %w", err)
+		}
+	}
+	for _, part := range []struct {
+		contentType string
+		data        []byte
+	}{
+		{"text/plain; charset=utf-8", []byte(text.String())},
+		{"text/html; charset=utf-8", html.Bytes()},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write(part.data); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	return &Digest{ContentType: "multipart/alternative; boundary=" + mw.Boundary(), Body: body.Bytes()}, nil
+}
+
+// digestItems returns the newest max items of f, newest first.
+func digestItems(f *Feed, max int, sanitized bool) []digestItem {
+	if max <= 0 {
+		max = 10
+	}
+	var items []*Item
+	for _, it := range f.Items {
+		if it != nil {
+			items = append(items, it)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return maxTime(items[i].Created, items[i].Updated).After(maxTime(items[j].Created, items[j].Updated))
+	})
+	if len(items) > max {
+		items = items[:max]
+	}
+	out := make([]digestItem, 0, len(items))
+	for _, it := range items {
+		desc := firstNonEmpty(it.Description, it.Content)
+		d := digestItem{
+			Title: strings.TrimSpace(it.Title),
+			Text:  HTMLToText(desc),
+			HTML:  safeHTML(desc, sanitized),
+			Date:  maxTime(it.Created, it.Updated),
+		}
+		if it.Link != nil {
+			d.Link = strings.TrimSpace(it.Link.Href)
+		}
+		out = append(out, d)
+	}
+	return out
+}
@@ -0,0 +1,66 @@
+package gofeedx_test
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestToDigest(t *testing.T) {
+	base := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	f := &gofeedx.Feed{Title: "Newsletter", Link: &gofeedx.Link{Href: "https://example.org/"}}
+	for i, title := range []string{"old", "newest", "middle"} {
+		f.Items = append(f.Items, &gofeedx.Item{
+			Title:       title,
+			Link:        &gofeedx.Link{Href: "https://example.org/" + title},
+			Description: "<p>About <b>" + title + "</b><script>x()</script></p>",
+			Created:     base.Add(time.Duration([]int{0, 48, 24}[i]) * time.Hour),
+		})
+	}
+	f = f.WithHTMLSanitizer(func(s string) string { return strings.ReplaceAll(s, "<script>x()</script>", "") })
+
+	d, err := gofeedx.ToDigest(f, gofeedx.DigestOptions{MaxItems: 2, Boundary: "digest-boundary"})
+	mustNoErr(t, err, "ToDigest failed")
+	mediaType, params, err := mime.ParseMediaType(d.ContentType)
+	if err != nil || mediaType != "multipart/alternative" || params["boundary"] != "digest-boundary" {
+		t.Fatalf("unexpected content type %q", d.ContentType)
+	}
+	mr := multipart.NewReader(strings.NewReader(string(d.Body)), params["boundary"])
+	var parts []string
+	for {
+		p, err := mr.NextPart() // decodes quoted-printable
+		if err == io.EOF {
+			break
+		}
+		mustNoErr(t, err, "NextPart")
+		data, err := io.ReadAll(p)
+		mustNoErr(t, err, "read part")
+		parts = append(parts, p.Header.Get("Content-Type")+"\n"+strings.ReplaceAll(string(data), "\r\n", "\n"))
+	}
+	if len(parts) != 2 {
+		t.Fatalf("expected text and html parts, got %d", len(parts))
+	}
+	text, html := parts[0], parts[1]
+	mustContain(t, text, "text/plain; charset=utf-8", "expected text part first")
+	mustContain(t, text, "newest\n3 May 2024\nhttps://example.org/newest\n\nAbout newest", "expected plain-text item")
+	if strings.Index(text, "newest") > strings.Index(text, "middle") || strings.Contains(text, "old") {
+		t.Errorf("expected the two newest items, newest first:\n%s", text)
+	}
+	mustContain(t, html, "text/html; charset=utf-8", "expected html part")
+	mustContain(t, html, "<p>About <b>middle</b></p>", "expected sanitized item HTML")
+	mustNotContain(t, html, "<script>", "sanitizer must run over item HTML")
+}
+
+func TestToDigest_EscapesWithoutSanitizer(t *testing.T) {
+	f := &gofeedx.Feed{Title: "N", Items: []*gofeedx.Item{{Title: "a", Description: "<p>x<script>steal()</script><iframe src=evil></iframe></p>"}}}
+	d, err := gofeedx.ToDigest(f, gofeedx.DigestOptions{Boundary: "b"})
+	mustNoErr(t, err, "ToDigest failed")
+	body := string(d.Body)
+	mustNotContain(t, body, "<iframe", "expected raw item HTML to be dropped without a sanitizer")
+	mustNotContain(t, body, "steal()", "expected scripts to be dropped without a sanitizer")
+}
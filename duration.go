@@ -0,0 +1,63 @@
+package gofeedx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DurationProber infers the playing time of an item's enclosure, e.g. by reading the media
+// headers of the remote file (see mediascan.HTTPProber) or by looking it up in a catalogue.
+type DurationProber interface {
+	ProbeDuration(ctx context.Context, it *Item) (seconds int, err error)
+}
+
+// DurationProberFunc adapts a function to the DurationProber interface.
+type DurationProberFunc func(ctx context.Context, it *Item) (int, error)
+
+// ProbeDuration calls f.
+func (f DurationProberFunc) ProbeDuration(ctx context.Context, it *Item) (int, error) {
+	return f(ctx, it)
+}
+
+// ProbeDurations fills in DurationSeconds for items that have an enclosure URL but no
+// duration, using p. The feed is updated in place and returned for convenience. Failures for
+// individual items do not stop processing; they are collected and returned joined.
+func ProbeDurations(ctx context.Context, feed *Feed, p DurationProber) (*Feed, error) {
+	if feed == nil {
+		return nil, errors.New("nil feed")
+	}
+	if p == nil {
+		return feed, nil
+	}
+	var errs error
+	for i, it := range feed.Items {
+		if it == nil || it.DurationSeconds > 0 || it.Enclosure == nil || strings.TrimSpace(it.Enclosure.Url) == "" {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return feed, errors.Join(errs, err)
+		}
+		sec, err := p.ProbeDuration(ctx, it)
+		if err == nil && sec <= 0 {
+			err = errors.New("no duration found")
+		}
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("duration: item[%d] %w", i, err))
+			continue
+		}
+		it.DurationSeconds = sec
+	}
+	return feed, errs
+}
+
+// WithDurationProber makes Build probe the duration of every item it keeps that has an
+// enclosure but no DurationSeconds (see ProbeDurations). Probing runs with the context given
+// to BuildContext (context.Background() for Build) on copies of the items, so the items held
+// by their ItemBuilders keep no duration. In strict mode probe failures fail Build; in
+// lenient mode the affected items keep no duration.
+func (b *FeedBuilder) WithDurationProber(p DurationProber) *FeedBuilder {
+	b.durationProber = p
+	return b
+}
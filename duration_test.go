@@ -0,0 +1,96 @@
+package gofeedx_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestWithDurationProber(t *testing.T) {
+	calls := 0
+	prober := gofeedx.DurationProberFunc(func(ctx context.Context, it *gofeedx.Item) (int, error) {
+		calls++
+		if it.ID == "broken" {
+			return 0, errors.New("unreachable")
+		}
+		return 1801, nil
+	})
+	newFeed := func() *gofeedx.FeedBuilder {
+		b := gofeedx.NewFeed("Probed").WithDurationProber(prober)
+		created := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+		b.AddItem(gofeedx.NewItem("Known").WithID("known").WithCreated(created).
+			WithEnclosure("https://example.com/known.mp3", 1, "audio/mpeg").WithDurationSeconds(60))
+		b.AddItem(gofeedx.NewItem("Missing").WithID("missing").WithCreated(created).
+			WithEnclosure("https://example.com/missing.mp3", 1, "audio/mpeg"))
+		b.AddItem(gofeedx.NewItem("Text").WithID("text").WithCreated(created))
+		return b
+	}
+
+	f, err := newFeed().Build()
+	mustNoErr(t, err, "Build failed")
+	if calls != 1 {
+		t.Errorf("prober called %d times, want 1 (only items with an enclosure and no duration)", calls)
+	}
+	if f.Items[0].DurationSeconds != 60 || f.Items[1].DurationSeconds != 1801 || f.Items[2].DurationSeconds != 0 {
+		t.Errorf("unexpected durations: %d %d %d", f.Items[0].DurationSeconds, f.Items[1].DurationSeconds, f.Items[2].DurationSeconds)
+	}
+
+	b := newFeed()
+	b.AddItem(gofeedx.NewItem("Broken").WithID("broken").WithCreated(time.Date(2024, 5, 2, 8, 0, 0, 0, time.UTC)).
+		WithEnclosure("https://example.com/broken.mp3", 1, "audio/mpeg"))
+	_, err = b.Build()
+	mustErr(t, err, "strict Build should report probe failures")
+
+	f, err = b.WithLenient().Build()
+	mustNoErr(t, err, "lenient Build should ignore probe failures")
+	if f.Items[3].DurationSeconds != 0 {
+		t.Errorf("failed probe must leave the duration unset")
+	}
+}
+
+func TestProbeDurations_Context(t *testing.T) {
+	f := &gofeedx.Feed{Items: []*gofeedx.Item{{Enclosure: &gofeedx.Enclosure{Url: "https://example.com/a.mp3"}}}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := gofeedx.ProbeDurations(ctx, f, gofeedx.DurationProberFunc(func(context.Context, *gofeedx.Item) (int, error) {
+		t.Fatal("prober must not run after cancellation")
+		return 0, nil
+	}))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	_, err = gofeedx.ProbeDurations(context.Background(), nil, nil)
+	mustErr(t, err, "expected error for nil feed")
+}
+
+func TestBuildContext_DurationProber(t *testing.T) {
+	prober := gofeedx.DurationProberFunc(func(ctx context.Context, it *gofeedx.Item) (int, error) {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		return 90, nil
+	})
+	ib := gofeedx.NewItem("Missing").WithID("missing").WithCreated(time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)).
+		WithEnclosure("https://example.com/missing.mp3", 1, "audio/mpeg")
+	b := gofeedx.NewFeed("Probed").WithDurationProber(prober).AddItem(ib)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := b.BuildContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled from BuildContext, got %v", err)
+	}
+
+	f, err := b.BuildContext(context.Background())
+	mustNoErr(t, err, "BuildContext failed")
+	if f.Items[0].DurationSeconds != 90 {
+		t.Errorf("expected probed duration, got %d", f.Items[0].DurationSeconds)
+	}
+	it, _ := ib.Build()
+	if it.DurationSeconds != 0 {
+		t.Errorf("probing must not modify the ItemBuilder's item, got %d", it.DurationSeconds)
+	}
+}
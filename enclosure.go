@@ -0,0 +1,61 @@
+package gofeedx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ResolveEnclosureLengths fills in missing enclosure lengths by issuing HTTP HEAD requests
+// to enclosure URLs whose Length is not positive, using the Content-Length response header.
+// PSP-1 and RSS require a positive length, which publishers often do not know up front.
+//
+// The feed is updated in place and returned for convenience. Items that already have a
+// length are left untouched. Failures for individual enclosures do not stop processing;
+// they are collected and returned joined. A nil client uses http.DefaultClient.
+func ResolveEnclosureLengths(ctx context.Context, feed *Feed, client *http.Client) (*Feed, error) {
+	if feed == nil {
+		return nil, errors.New("nil feed")
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	var errs error
+	for i, it := range feed.Items {
+		if it == nil || it.Enclosure == nil || it.Enclosure.Length > 0 || strings.TrimSpace(it.Enclosure.Url) == "" {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return feed, errors.Join(errs, err)
+		}
+		n, err := headContentLength(ctx, client, it.Enclosure.Url)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("enclosure: item[%d] %w", i, err))
+			continue
+		}
+		it.Enclosure.Length = n
+	}
+	return feed, errs
+}
+
+// headContentLength returns the positive Content-Length reported by a HEAD request to u.
+func headContentLength(ctx context.Context, client *http.Client, u string) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, strings.TrimSpace(u), nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return 0, fmt.Errorf("HEAD %s: unexpected status %s", u, resp.Status)
+	}
+	if resp.ContentLength <= 0 {
+		return 0, fmt.Errorf("HEAD %s: no Content-Length in response", u)
+	}
+	return resp.ContentLength, nil
+}
@@ -0,0 +1,63 @@
+package gofeedx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestResolveEnclosureLengths(t *testing.T) {
+	var heads int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("expected HEAD request, got %s", r.Method)
+		}
+		heads++
+		switch r.URL.Path {
+		case "/ep1.mp3":
+			w.Header().Set("Content-Length", "12345")
+		case "/missing.mp3":
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	feed := &Feed{Items: []*Item{
+		{Title: "a", Enclosure: &Enclosure{Url: srv.URL + "/ep1.mp3", Type: "audio/mpeg"}},
+		{Title: "b", Enclosure: &Enclosure{Url: srv.URL + "/known.mp3", Type: "audio/mpeg", Length: 42}},
+		{Title: "c", Enclosure: &Enclosure{Url: srv.URL + "/missing.mp3", Type: "audio/mpeg"}},
+		{Title: "d"},
+	}}
+	got, err := ResolveEnclosureLengths(context.Background(), feed, srv.Client())
+	if got != feed {
+		t.Fatalf("expected the same feed to be returned")
+	}
+	if err == nil || !strings.Contains(err.Error(), "item[2]") {
+		t.Fatalf("expected error for item[2], got %v", err)
+	}
+	if feed.Items[0].Enclosure.Length != 12345 {
+		t.Errorf("item[0] length expected 12345, got %d", feed.Items[0].Enclosure.Length)
+	}
+	if feed.Items[1].Enclosure.Length != 42 {
+		t.Errorf("item[1] length should be untouched, got %d", feed.Items[1].Enclosure.Length)
+	}
+	if heads != 2 {
+		t.Errorf("expected 2 HEAD requests, got %d", heads)
+	}
+}
+
+func TestResolveEnclosureLengths_NilFeedAndCanceledContext(t *testing.T) {
+	if _, err := ResolveEnclosureLengths(context.Background(), nil, nil); err == nil {
+		t.Errorf("expected error for nil feed")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	feed := &Feed{Items: []*Item{{Enclosure: &Enclosure{Url: "https://example.org/a.mp3"}}}}
+	if _, err := ResolveEnclosureLengths(ctx, feed, nil); err == nil {
+		t.Errorf("expected context error")
+	}
+}
@@ -0,0 +1,169 @@
+package gofeedx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// maxPooledBufferSize caps the buffers an Encoder keeps for reuse, so one huge feed does not
+// pin its memory for the lifetime of the Encoder.
+const maxPooledBufferSize = 16 << 20
+
+// Encoder renders feeds into io.Writers while reusing its output buffers between renders,
+// which avoids regrowing a buffer for every request in servers that render many feeds.
+//
+// An Encoder is safe for concurrent use by multiple goroutines: each call takes its own
+// buffer from a sync.Pool and returns it when done. encoding/xml and encoding/json encoders
+// cannot be reset onto a new writer, so those are created per call; the pooled buffers
+// hold the bulk of the per-render memory. The output equals ToRSSWithOptions, ToAtomWithOptions,
+// ToPSPWithOptions and ToJSON, with EncodeOptions.PostProcessors run on the rendered bytes,
+// written to w in one Write.
+type Encoder struct {
+	opts EncodeOptions
+	pool sync.Pool
+}
+
+// EncodeOptions configures an Encoder.
+type EncodeOptions struct {
+	// RenderOptions apply to every XML render.
+	RenderOptions
+
+	// Parallelism, when greater than 1, pre-serializes the items (or Atom entries) of XML
+	// feeds in up to that many goroutines and stitches them into the document in order;
+	// a negative value uses runtime.GOMAXPROCS(0). The output is identical to sequential
+	// rendering. It only pays off for large feeds, so measure before enabling it.
+	Parallelism int
+
+	// PostProcessors transform the rendered document of every profile, in order and before
+	// compression.
+	PostProcessors []PostProcessor
+
+	// Compression compresses the written output (after post-processors), e.g. to store
+	// pre-compressed feeds or serve them with the matching Content-Encoding header.
+	Compression Compression
+
+	// TimeLocation, when set, writes all feed, item and source-feed dates in this zone (e.g.
+	// time.UTC) for output that does not depend on the server's local time. The feed is
+	// copied per render; the caller's feed is not modified.
+	TimeLocation *time.Location
+
+	// HTMLSanitizer, when set, replaces the feed's sanitizer (see FeedBuilder.WithHTMLSanitizer)
+	// for item HTML in every render.
+	HTMLSanitizer HTMLSanitizer
+}
+
+// NewEncoder returns an Encoder that renders with default options.
+func NewEncoder() *Encoder {
+	return NewEncoderWithOptions(EncodeOptions{})
+}
+
+// NewEncoderWithOptions returns an Encoder that applies opts to every render.
+func NewEncoderWithOptions(opts EncodeOptions) *Encoder {
+	enc := &Encoder{opts: opts}
+	enc.pool.New = func() any { return new(bytes.Buffer) }
+	return enc
+}
+
+// EncodeRSS writes feed as RSS 2.0 to w.
+func (enc *Encoder) EncodeRSS(feed *Feed, w io.Writer) error {
+	return enc.encodeXML(feed, ProfileRSS, w)
+}
+
+// EncodeAtom writes feed as Atom 1.0 to w.
+func (enc *Encoder) EncodeAtom(feed *Feed, w io.Writer) error {
+	return enc.encodeXML(feed, ProfileAtom, w)
+}
+
+// EncodePSP writes feed as PSP-1 RSS to w.
+func (enc *Encoder) EncodePSP(feed *Feed, w io.Writer) error {
+	return enc.encodeXML(feed, ProfilePSP, w)
+}
+
+// EncodeJSON writes feed as JSON Feed 1.1 to w.
+func (enc *Encoder) EncodeJSON(feed *Feed, w io.Writer) error {
+	if feed == nil {
+		return errors.New("nil feed")
+	}
+	feed = enc.localize(feed)
+	buf := enc.getBuffer()
+	defer enc.putBuffer(buf)
+	je := json.NewEncoder(buf)
+	je.SetIndent("", "  ")
+	if err := je.Encode((&JSON{Feed: feed}).JSONFeed()); err != nil {
+		return err
+	}
+	// json.Encoder terminates each value with a newline; ToJSON output has none
+	buf.Truncate(buf.Len() - 1)
+	return enc.write(ProfileJSON, buf.Bytes(), w)
+}
+
+// Encode writes feed in the format of profile to w.
+func (enc *Encoder) Encode(feed *Feed, profile Profile, w io.Writer) error {
+	switch profile {
+	case ProfileJSON:
+		return enc.EncodeJSON(feed, w)
+	case ProfileRSS, ProfileAtom, ProfilePSP:
+		return enc.encodeXML(feed, profile, w)
+	}
+	data, err := renderCustom(enc.localize(feed), profile)
+	if err != nil {
+		return err
+	}
+	return enc.write(profile, data, w)
+}
+
+func (enc *Encoder) encodeXML(feed *Feed, profile Profile, w io.Writer) error {
+	if feed == nil {
+		return errors.New("nil feed")
+	}
+	feed = enc.localize(feed)
+	var x XmlFeed
+	switch profile {
+	case ProfileRSS:
+		x = &Rss{feed}
+	case ProfileAtom:
+		x = &Atom{feed}
+	case ProfilePSP:
+		x = &PSP{feed}
+	default:
+		return fmt.Errorf("render: unknown profile %d", profile)
+	}
+	buf := enc.getBuffer()
+	defer enc.putBuffer(buf)
+	var err error
+	if enc.opts.Parallelism > 1 || enc.opts.Parallelism < 0 {
+		err = writeXMLParallel(feed, x.FeedXml, buf, enc.opts.RenderOptions, enc.opts.Parallelism)
+	} else {
+		err = WriteXMLWithOptions(x, buf, enc.opts.RenderOptions)
+	}
+	if err != nil {
+		return err
+	}
+	return enc.write(profile, buf.Bytes(), w)
+}
+
+func (enc *Encoder) write(profile Profile, data []byte, w io.Writer) error {
+	data, err := applyPostProcessors(enc.opts.PostProcessors, profile, data)
+	if err != nil {
+		return err
+	}
+	return enc.opts.Compression.write(w, data)
+}
+
+func (enc *Encoder) getBuffer() *bytes.Buffer {
+	buf := enc.pool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+func (enc *Encoder) putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	enc.pool.Put(buf)
+}
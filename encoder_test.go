@@ -0,0 +1,115 @@
+package gofeedx_test
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestEncoder_MatchesToFunctions(t *testing.T) {
+	f := newRSSBaseFeed()
+	f.Items = []*gofeedx.Item{newRSSBaseItem()}
+	f.Items[0].ID = "item-1"
+	enc := gofeedx.NewEncoder()
+	cases := []struct {
+		name   string
+		encode func(*gofeedx.Feed, *bytes.Buffer) error
+		render func(*gofeedx.Feed) (string, error)
+	}{
+		{"rss", func(f *gofeedx.Feed, b *bytes.Buffer) error { return enc.EncodeRSS(f, b) }, gofeedx.ToRSS},
+		{"atom", func(f *gofeedx.Feed, b *bytes.Buffer) error { return enc.EncodeAtom(f, b) }, gofeedx.ToAtom},
+		{"psp", func(f *gofeedx.Feed, b *bytes.Buffer) error { return enc.EncodePSP(f, b) }, gofeedx.ToPSP},
+		{"json", func(f *gofeedx.Feed, b *bytes.Buffer) error { return enc.EncodeJSON(f, b) }, gofeedx.ToJSON},
+	}
+	for _, tc := range cases {
+		want, err := tc.render(f)
+		mustNoErr(t, err, tc.name+" render")
+		// twice, so the second run reuses a pooled buffer
+		for i := 0; i < 2; i++ {
+			var buf bytes.Buffer
+			mustNoErr(t, tc.encode(f, &buf), tc.name+" encode")
+			if buf.String() != want {
+				t.Fatalf("%s: Encoder output differs\n got %q\nwant %q", tc.name, buf.String(), want)
+			}
+		}
+	}
+	mustErr(t, enc.EncodeRSS(nil, &bytes.Buffer{}), "expected error for nil feed")
+}
+
+func TestEncoder_OptionsAndPostProcessors(t *testing.T) {
+	f := newRSSBaseFeed()
+	done := func(p gofeedx.Profile, data []byte) ([]byte, error) {
+		return append(data, "<!-- done -->"...), nil
+	}
+	var buf bytes.Buffer
+	opts := gofeedx.EncodeOptions{RenderOptions: gofeedx.RenderOptions{Compact: true}, PostProcessors: []gofeedx.PostProcessor{done}}
+	mustNoErr(t, gofeedx.NewEncoderWithOptions(opts).EncodeRSS(f, &buf), "EncodeRSS")
+	want, err := gofeedx.ToRSSWithOptions(f, gofeedx.RenderOptions{Compact: true})
+	mustNoErr(t, err, "ToRSSWithOptions")
+	want += "<!-- done -->"
+	if buf.String() != want {
+		t.Fatalf("unexpected output %q, want %q", buf.String(), want)
+	}
+}
+
+func TestEncoder_ConcurrentUse(t *testing.T) {
+	f := newRSSBaseFeed()
+	want, err := gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS")
+	enc := gofeedx.NewEncoder()
+	var wg sync.WaitGroup
+	errs := make(chan string, 16)
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				var buf bytes.Buffer
+				if err := enc.EncodeRSS(f, &buf); err != nil || buf.String() != want {
+					errs <- "concurrent EncodeRSS produced unexpected output"
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for msg := range errs {
+		t.Fatal(msg)
+	}
+}
+
+func TestEncoder_ParallelMatchesSequential(t *testing.T) {
+	f := newRSSBaseFeed()
+	f.Items = nil
+	for i := 0; i < 25; i++ {
+		it := newRSSBaseItem()
+		it.ID = "item-" + strings.Repeat("x", i)
+		it.Description = "<p>item " + it.ID + "</p>"
+		if i%3 == 0 {
+			it.Extensions = append(it.Extensions, gofeedx.ExtensionNode{Name: "_xml:cdata", Text: "false"})
+		}
+		f.Items = append(f.Items, it)
+	}
+	variants := []gofeedx.RenderOptions{
+		{},
+		{Compact: true},
+		{Indent: "\t", Newline: "\r\n"},
+		{StylesheetHref: "/feed.xsl"},
+	}
+	for _, opts := range variants {
+		seq := gofeedx.NewEncoderWithOptions(gofeedx.EncodeOptions{RenderOptions: opts})
+		par := gofeedx.NewEncoderWithOptions(gofeedx.EncodeOptions{RenderOptions: opts, Parallelism: 4})
+		for _, p := range []gofeedx.Profile{gofeedx.ProfileRSS, gofeedx.ProfileAtom, gofeedx.ProfilePSP} {
+			var want, got bytes.Buffer
+			mustNoErr(t, seq.Encode(f, p, &want), "sequential Encode")
+			mustNoErr(t, par.Encode(f, p, &got), "parallel Encode")
+			if got.String() != want.String() {
+				t.Fatalf("profile %d opts %+v: parallel output differs\n got %q\nwant %q", p, opts, got.String(), want.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package gofeedx
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sentinel errors classify profile validation failures. Validators return a *ProfileError
+// wrapping one of them (or *ErrInvalidEnclosure), and Build joins those with errors.Join, so
+// callers can react with errors.Is / errors.As instead of matching message text.
+var (
+	ErrMissingTitle       = errors.New("title required")
+	ErrMissingLink        = errors.New("link required")
+	ErrMissingDescription = errors.New("description required")
+	ErrMissingID          = errors.New("id required")
+	ErrMissingUpdated     = errors.New("updated timestamp required")
+	ErrMissingAuthor      = errors.New("author required")
+	ErrMissingLanguage    = errors.New("language required")
+	ErrMissingCategory    = errors.New("category required")
+	ErrMissingFeedURL     = errors.New("feed url required")
+	ErrMissingItems       = errors.New("at least one item required")
+	ErrTooLong            = errors.New("value too long")
+	ErrInvalidLanguage    = errors.New("invalid language tag")
+	ErrInvalidAuthor      = errors.New("invalid author")
+	ErrInvalidContent     = errors.New("invalid content")
+	ErrInvalidExtension   = errors.New("invalid extension")
+)
+
+// ErrInvalidEnclosure reports an item whose enclosure lacks a url, a type or a positive length.
+type ErrInvalidEnclosure struct {
+	Index int // item index
+}
+
+func (e *ErrInvalidEnclosure) Error() string {
+	return fmt.Sprintf("item[%d] enclosure url/type/length required", e.Index)
+}
+
+// ProfileError is one validation failure for a target profile. Err is the sentinel (e.g.
+// ErrMissingTitle) or *ErrInvalidEnclosure describing the failure; Error returns the
+// validator's full message, e.g. "psp: item[2] enclosure url/type/length required".
+type ProfileError struct {
+	Profile Profile
+	Field   string // generic Feed/Item field, e.g. "Title", "Enclosure", "Extensions"
+	Index   int    // item index, or -1 for feed-level fields
+	Path    string // input path as in Issue.Path, e.g. "Items[3].Enclosure.Length" or "Feed.Link.Href"
+	Err     error
+
+	msg string
+}
+
+func (e *ProfileError) Error() string {
+	return e.msg
+}
+
+func (e *ProfileError) Unwrap() error {
+	return e.Err
+}
+
+// ProfileErrors returns every ProfileError in err, looking through errors.Join and wrapping,
+// e.g. to highlight each offending form field by its Path.
+func ProfileErrors(err error) []*ProfileError {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case *ProfileError:
+		return []*ProfileError{e}
+	case interface{ Unwrap() []error }:
+		var out []*ProfileError
+		for _, inner := range e.Unwrap() {
+			out = append(out, ProfileErrors(inner)...)
+		}
+		return out
+	}
+	return ProfileErrors(errors.Unwrap(err))
+}
+
+// feedError returns a feed-level ProfileError with a formatted message; Path defaults to
+// "Feed.<field>" (see at).
+func feedError(p Profile, field string, err error, format string, args ...any) *ProfileError {
+	e := &ProfileError{Profile: p, Field: field, Index: -1, Err: err, msg: fmt.Sprintf(format, args...)}
+	return e.at(field)
+}
+
+// itemError returns an item-level ProfileError with a formatted message; Path defaults to
+// "Items[index].<field>" (see at).
+func itemError(p Profile, index int, field string, err error, format string, args ...any) *ProfileError {
+	e := &ProfileError{Profile: p, Field: field, Index: index, Err: err, msg: fmt.Sprintf(format, args...)}
+	return e.at(field)
+}
+
+// at sets Path to sub within the error's scope, e.g. "Enclosure.Length" for an item.
+func (e *ProfileError) at(sub string) *ProfileError {
+	if e.Index < 0 {
+		e.Path = "Feed." + sub
+	} else {
+		e.Path = fmt.Sprintf("Items[%d].%s", e.Index, sub)
+	}
+	return e
+}
+
+// enclosurePath names the first missing enclosure attribute, or "Enclosure" when e is nil.
+func enclosurePath(e *Enclosure) string {
+	switch {
+	case e == nil:
+		return "Enclosure"
+	case strings.TrimSpace(e.Url) == "":
+		return "Enclosure.Url"
+	case strings.TrimSpace(e.Type) == "":
+		return "Enclosure.Type"
+	}
+	return "Enclosure.Length"
+}
+
+// extensionPath is the path segment of the extension node named name.
+func extensionPath(name string) string {
+	return "Extensions[" + strings.TrimSpace(name) + "]"
+}
+
+// String returns the profile's lowercase name as used in validation messages, or the name a
+// custom profile was registered with.
+func (p Profile) String() string {
+	switch p {
+	case ProfileRSS:
+		return "rss"
+	case ProfileAtom:
+		return "atom"
+	case ProfilePSP:
+		return "psp"
+	case ProfileJSON:
+		return "json"
+	}
+	if c, ok := lookupCustomProfile(p); ok {
+		return c.name
+	}
+	return fmt.Sprintf("Profile(%d)", int(p))
+}
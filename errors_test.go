@@ -0,0 +1,73 @@
+package gofeedx_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestProfileErrors_IsAs(t *testing.T) {
+	b := gofeedx.NewFeed("Show").
+		WithLink("https://example.com/").
+		WithDescription("d").
+		WithLanguage("en").
+		WithFeedURL("https://example.com/podcast.rss").
+		WithCategories("Technology").
+		WithProfiles(gofeedx.ProfilePSP, gofeedx.ProfileJSON)
+	b.AddItem(gofeedx.NewItem("E1").WithID("1").WithLenient().
+		WithEnclosure("https://example.com/e1.mp3", 0, "audio/mpeg"))
+	_, err := b.Build()
+	mustErr(t, err, "expected validation error")
+
+	var enc *gofeedx.ErrInvalidEnclosure
+	if !errors.As(err, &enc) || enc.Index != 0 {
+		t.Fatalf("expected ErrInvalidEnclosure for item 0, got %v", err)
+	}
+	var pe *gofeedx.ProfileError
+	if !errors.As(err, &pe) {
+		t.Fatalf("expected ProfileError, got %T", err)
+	}
+	if pe.Profile != gofeedx.ProfilePSP || pe.Field != "Enclosure" || pe.Index != 0 {
+		t.Errorf("unexpected ProfileError: %+v", pe)
+	}
+	mustContain(t, err.Error(), "psp: item[0] enclosure url/type/length required", "expected message to be kept")
+
+	_, err = gofeedx.NewFeed("").WithLenient().WithProfiles(gofeedx.ProfileRSS).Build()
+	if !errors.Is(err, gofeedx.ErrMissingTitle) {
+		t.Errorf("expected ErrMissingTitle, got %v", err)
+	}
+	_, err = gofeedx.NewFeed("").Build()
+	if !errors.Is(err, gofeedx.ErrMissingTitle) {
+		t.Errorf("expected builder ErrMissingTitle, got %v", err)
+	}
+	if err := gofeedx.ValidateJSON(&gofeedx.Feed{Title: "x", Language: "not a tag"}); !errors.Is(err, gofeedx.ErrInvalidLanguage) {
+		t.Errorf("expected ErrInvalidLanguage, got %v", err)
+	}
+}
+
+func TestProfileErrors_Paths(t *testing.T) {
+	f := &gofeedx.Feed{
+		Title: "Show",
+		Items: []*gofeedx.Item{
+			{Title: "E1", ID: "1", Enclosure: &gofeedx.Enclosure{Url: "https://example.com/e1.mp3", Type: "audio/mpeg"}},
+		},
+	}
+	err := errors.Join(gofeedx.ValidateRSS(f), gofeedx.ValidatePSP(f))
+	var paths []string
+	for _, pe := range gofeedx.ProfileErrors(err) {
+		paths = append(paths, pe.Profile.String()+":"+pe.Path)
+	}
+	want := []string{"rss:Feed.Link.Href", "psp:Feed.Description"}
+	if len(paths) != len(want) || paths[0] != want[0] || paths[1] != want[1] {
+		t.Fatalf("unexpected paths %v, want %v", paths, want)
+	}
+
+	f.Link = &gofeedx.Link{Href: "https://example.com/"}
+	f.Description = "d"
+	err = gofeedx.ValidateRSS(f)
+	pes := gofeedx.ProfileErrors(err)
+	if len(pes) != 1 || pes[0].Path != "Items[0].Enclosure.Length" {
+		t.Fatalf("expected Items[0].Enclosure.Length, got %v", err)
+	}
+}
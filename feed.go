@@ -17,10 +17,12 @@ type Author struct {
 }
 
 // Category represents a generic top-level category.
-// Atom/RSS writers use only the first top-level category.
+// RSS writers use only the first top-level category; Atom emits all of them.
 // PSP maps categories to itunes:category (single level).
 type Category struct {
-	Text string
+	Text   string
+	Scheme string // Atom category scheme (IRI of the categorization scheme), optional
+	Label  string // Atom human-readable label, optional
 }
 
 // Image represents a channel-level image.
@@ -39,6 +41,16 @@ type Enclosure struct {
 	Type   string
 }
 
+// SourceFeed describes the feed an item was originally published in, so republished
+// items keep their provenance (Atom <source>, RFC 4287 4.2.11).
+type SourceFeed struct {
+	ID      string
+	Title   string
+	Link    string // the source feed's website (alternate link)
+	FeedURL string // the source feed document (self link)
+	Updated time.Time
+}
+
 // Item represents a single entry/post/episode.
 type Item struct {
 	Title       string
@@ -52,9 +64,19 @@ type Item struct {
 	Created     time.Time
 	Enclosure   *Enclosure
 	Content     string // HTML content (RSS content:encoded, Atom content, JSON content_html)
+	Language    string // per-item language: dc:language in RSS, xml:lang in Atom, language in JSON
+	SourceFeed  *SourceFeed
+	ImageURL    string // item artwork: itunes:image in PSP, media:thumbnail in RSS/Atom, image in JSON
+
+	// Comments on the item: comments / wfw:commentRss / slash:comments in RSS, rel="replies"
+	// links (with thr:count) in Atom
+	CommentsURL     string // page with the item's comments
+	CommentsFeedURL string // feed of the item's comments
+	CommentCount    *int   // number of comments; nil omits it
 
 	// Extensions holds arbitrary extension nodes to append in item/entry scope (RSS/PSP/Atom) and to be flattened for JSON.
 	Extensions []ExtensionNode
+	options    []ExtensionNode // builder-recorded format options (see allExtensions)
 
 	// Generic item fields used by multiple targets
 	DurationSeconds int // used by JSON (attachments) and PSP (itunes:duration)
@@ -71,11 +93,17 @@ type Feed struct {
 	ID          string
 	Items       []*Item
 	Copyright   string
+	License     *License // structured license; Copyright, when set, wins for copyright/rights text
 	Image       *Image
+	Images      *Images // per-format icon/logo/artwork; empty slots fall back to Image
 	Language    string
+	Generator   *Generator // producing software; see WithDefaultGenerator and WithGenerator
 
 	// Extensions holds arbitrary extension nodes to append in channel/feed scope (RSS/PSP/Atom) and to be flattened for JSON.
 	Extensions []ExtensionNode
+	options    []ExtensionNode     // builder-recorded format options (see allExtensions)
+	rewriters  []enclosureRewriter // render-time enclosure URL rewriters (see WithEnclosureURLRewriter)
+	sanitizer  HTMLSanitizer       // render-time item HTML sanitizer (see WithHTMLSanitizer)
 
 	// Generic channel fields used by multiple targets
 	FeedURL    string      // used by JSON (feed_url) and PSP (atom:link rel=self)
@@ -0,0 +1,17 @@
+// Package feedyaml loads feed descriptions written in YAML, e.g. kept next to the episode
+// files of a static site, into the gofeedx Feed model without third-party dependencies.
+//
+// The loader lives in the root package as gofeedx.LoadFeedFromYAML, which documents the
+// schema; this package offers it under the import path github.com/jo-hoe/gofeedx/feedyaml.
+package feedyaml
+
+import (
+	"io"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+// Load reads a YAML feed description; it is gofeedx.LoadFeedFromYAML.
+func Load(r io.Reader) (*gofeedx.Feed, error) {
+	return gofeedx.LoadFeedFromYAML(r)
+}
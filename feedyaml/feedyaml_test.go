@@ -0,0 +1,21 @@
+package feedyaml_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jo-hoe/gofeedx/feedyaml"
+)
+
+func TestLoad(t *testing.T) {
+	f, err := feedyaml.Load(strings.NewReader("title: Folder Cast\nitems:\n  - title: Episode 1\n"))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if f.Title != "Folder Cast" || len(f.Items) != 1 || f.Items[0].Title != "Episode 1" {
+		t.Errorf("unexpected feed: %+v", f)
+	}
+	if _, err := feedyaml.Load(strings.NewReader("title: [a\n")); err == nil {
+		t.Errorf("expected error for malformed YAML")
+	}
+}
@@ -0,0 +1,134 @@
+package gofeedx
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"runtime/debug"
+	"strings"
+)
+
+const (
+	generatorName   = "gofeedx"
+	generatorURL    = "https://github.com/jo-hoe/gofeedx"
+	generatorModule = "github.com/jo-hoe/gofeedx"
+)
+
+// Generator identifies the software that produced a feed: RSS/PSP <generator>, the Atom
+// <generator> element and a "_generator" user-agent style string in JSON Feed.
+type Generator struct {
+	Name    string
+	Version string
+	URL     string
+}
+
+// DefaultGenerator returns the Generator WithDefaultGenerator sets: gofeedx with the module
+// version from the build info, or no version when it is not available (e.g. in gofeedx's own
+// tests).
+func DefaultGenerator() *Generator {
+	return &Generator{Name: generatorName, Version: moduleVersion(), URL: generatorURL}
+}
+
+// moduleVersion returns the gofeedx module version from the build info, or "".
+func moduleVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	if info.Main.Path == generatorModule && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == generatorModule {
+			if dep.Replace != nil && dep.Replace.Version != "" {
+				return dep.Replace.Version
+			}
+			return dep.Version
+		}
+	}
+	return ""
+}
+
+// String returns the text form used by RSS and PSP, e.g. "gofeedx v1.2.0 (https://...)".
+func (g *Generator) String() string {
+	if g == nil || strings.TrimSpace(g.Name) == "" {
+		return ""
+	}
+	s := strings.TrimSpace(g.Name)
+	if v := strings.TrimSpace(g.Version); v != "" {
+		s += " " + v
+	}
+	if u := strings.TrimSpace(g.URL); u != "" {
+		s += " (" + u + ")"
+	}
+	return s
+}
+
+// userAgent returns the JSON Feed form, e.g. "gofeedx/v1.2.0 (+https://...)".
+func (g *Generator) userAgent() string {
+	if g == nil || strings.TrimSpace(g.Name) == "" {
+		return ""
+	}
+	s := strings.TrimSpace(g.Name)
+	if v := strings.TrimSpace(g.Version); v != "" {
+		s += "/" + v
+	}
+	if u := strings.TrimSpace(g.URL); u != "" {
+		s += " (+" + u + ")"
+	}
+	return s
+}
+
+// AtomGenerator is the Atom <generator> element.
+type AtomGenerator struct {
+	XMLName xml.Name `xml:"generator"`
+	URI     string   `xml:"uri,attr,omitempty"`
+	Version string   `xml:"version,attr,omitempty"`
+	Text    string   `xml:",chardata"`
+}
+
+func atomGeneratorFrom(g *Generator) *AtomGenerator {
+	if g == nil || strings.TrimSpace(g.Name) == "" {
+		return nil
+	}
+	return &AtomGenerator{
+		URI:     strings.TrimSpace(g.URL),
+		Version: strings.TrimSpace(g.Version),
+		Text:    strings.TrimSpace(g.Name),
+	}
+}
+
+// applyJSONGenerator sets the "_generator" key; a "_generator" set through WithJSONExtension
+// is applied later and wins.
+func applyJSONGenerator(feed *JSONFeed, g *Generator) {
+	ua := g.userAgent()
+	if ua == "" {
+		return
+	}
+	data, err := json.Marshal(ua)
+	if err != nil {
+		return
+	}
+	if feed.Custom == nil {
+		feed.Custom = map[string]json.RawMessage{}
+	}
+	feed.Custom["_generator"] = data
+}
+
+// WithDefaultGenerator identifies gofeedx as the feed generator (see DefaultGenerator).
+// Feeds have no generator unless it or WithGenerator is used.
+func (b *FeedBuilder) WithDefaultGenerator() *FeedBuilder {
+	b.feed.Generator = DefaultGenerator()
+	return b
+}
+
+// WithGenerator sets the generator metadata. An empty name suppresses the generator in every
+// format. WithRSSGenerator still takes precedence for the RSS text.
+func (b *FeedBuilder) WithGenerator(name, version, url string) *FeedBuilder {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		b.feed.Generator = nil
+		return b
+	}
+	b.feed.Generator = &Generator{Name: name, Version: strings.TrimSpace(version), URL: strings.TrimSpace(url)}
+	return b
+}
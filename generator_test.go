@@ -0,0 +1,62 @@
+package gofeedx_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func newGeneratorFeed(t *testing.T, b *gofeedx.FeedBuilder) *gofeedx.Feed {
+	t.Helper()
+	b.WithLink("https://example.com/").WithDescription("d").WithAuthor("A", "")
+	b.AddItem(gofeedx.NewItem("e1").WithID("1").WithCreated(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	f, err := b.Build()
+	mustNoErr(t, err, "Build")
+	return f
+}
+
+func TestFeedBuilder_DefaultGenerator(t *testing.T) {
+	f := newGeneratorFeed(t, gofeedx.NewFeed("T"))
+	if f.Generator != nil {
+		t.Fatalf("expected no generator by default, got %+v", f.Generator)
+	}
+	rss, err := gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS")
+	mustNotContain(t, rss, "<generator>", "did not expect a generator by default")
+
+	f = newGeneratorFeed(t, gofeedx.NewFeed("T").WithDefaultGenerator())
+	if f.Generator == nil || f.Generator.Name != "gofeedx" {
+		t.Fatalf("expected default generator, got %+v", f.Generator)
+	}
+	rss, err = gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS")
+	mustContain(t, rss, "<generator>gofeedx ", "expected RSS generator")
+	mustNotContain(t, rss, "devel", "an unknown version must be omitted")
+	atom, err := gofeedx.ToAtom(f)
+	mustNoErr(t, err, "ToAtom")
+	mustContain(t, atom, `<generator uri="https://github.com/jo-hoe/gofeedx"`, "expected Atom generator")
+	js, err := gofeedx.ToJSON(f)
+	mustNoErr(t, err, "ToJSON")
+	mustContain(t, js, `"_generator": "gofeedx`, "expected JSON _generator")
+}
+
+func TestFeedBuilder_WithGenerator(t *testing.T) {
+	f := newGeneratorFeed(t, gofeedx.NewFeed("T").WithGenerator("MyHost", "2.1", "https://host.example/"))
+	rss, err := gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS")
+	mustContain(t, rss, "MyHost 2.1 (https://host.example/)", "expected overridden RSS generator")
+	atom, err := gofeedx.ToAtom(f)
+	mustNoErr(t, err, "ToAtom")
+	mustContain(t, atom, `<generator uri="https://host.example/" version="2.1">MyHost</generator>`, "expected overridden Atom generator")
+	js, err := gofeedx.ToJSON(f)
+	mustNoErr(t, err, "ToJSON")
+	mustContain(t, js, `"_generator": "MyHost/2.1 (+https://host.example/)"`, "expected overridden JSON _generator")
+
+	f = newGeneratorFeed(t, gofeedx.NewFeed("T").WithGenerator("", "", ""))
+	for _, render := range []func(*gofeedx.Feed) (string, error){gofeedx.ToRSS, gofeedx.ToAtom, gofeedx.ToJSON} {
+		out, err := render(f)
+		mustNoErr(t, err, "render")
+		mustNotContain(t, out, "generator", "expected generator to be suppressed")
+	}
+}
@@ -0,0 +1,110 @@
+package gofeedx
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// xmlnsGeoRSS is the GeoRSS-Simple namespace, declared on RSS/Atom roots when items carry georss:* nodes.
+const xmlnsGeoRSS = "http://www.georss.org/georss"
+
+// GeoPoint is a WGS84 coordinate in decimal degrees.
+type GeoPoint struct {
+	Lat float64
+	Lon float64
+}
+
+// valid reports whether the point lies within latitude [-90, 90] and longitude [-180, 180].
+func (p GeoPoint) valid() bool {
+	return p.Lat >= -90 && p.Lat <= 90 && p.Lon >= -180 && p.Lon <= 180
+}
+
+// formatGeoPoints renders points as the GeoRSS-Simple "lat lon lat lon ..." list.
+func formatGeoPoints(points ...GeoPoint) string {
+	parts := make([]string, 0, len(points)*2)
+	for _, p := range points {
+		parts = append(parts, strconv.FormatFloat(p.Lat, 'f', -1, 64), strconv.FormatFloat(p.Lon, 'f', -1, 64))
+	}
+	return strings.Join(parts, " ")
+}
+
+// parseGeoPoint parses a GeoRSS-Simple point ("lat lon").
+func parseGeoPoint(s string) (GeoPoint, error) {
+	f := strings.Fields(s)
+	if len(f) != 2 {
+		return GeoPoint{}, errors.New("georss: point requires \"lat lon\"")
+	}
+	lat, err := strconv.ParseFloat(f[0], 64)
+	if err != nil {
+		return GeoPoint{}, err
+	}
+	lon, err := strconv.ParseFloat(f[1], 64)
+	if err != nil {
+		return GeoPoint{}, err
+	}
+	p := GeoPoint{Lat: lat, Lon: lon}
+	if !p.valid() {
+		return GeoPoint{}, errors.New("georss: point out of range")
+	}
+	return p, nil
+}
+
+// isGeoRSSName reports whether an extension node belongs to the georss prefix.
+func isGeoRSSName(name string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(name)), "georss:")
+}
+
+// hasGeoRSSNodes reports whether any node in exts is a georss:* element.
+func hasGeoRSSNodes(exts []ExtensionNode) bool {
+	for _, n := range exts {
+		if isGeoRSSName(n.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Item-level helpers:
+
+// WithGeoPoint sets the item location as georss:point (RSS/Atom) and _latitude/_longitude (JSON Feed).
+// Out-of-range coordinates are ignored.
+func (b *ItemBuilder) WithGeoPoint(lat, lon float64) *ItemBuilder {
+	p := GeoPoint{Lat: lat, Lon: lon}
+	if !p.valid() {
+		return b
+	}
+	return b.WithExtensions(ExtensionNode{Name: "georss:point", Text: formatGeoPoints(p)})
+}
+
+// WithGeoLine sets a georss:line path; at least two valid points are required, otherwise the call is ignored.
+func (b *ItemBuilder) WithGeoLine(points ...GeoPoint) *ItemBuilder {
+	if len(points) < 2 || !allGeoPointsValid(points) {
+		return b
+	}
+	return b.WithExtensions(ExtensionNode{Name: "georss:line", Text: formatGeoPoints(points...)})
+}
+
+// WithGeoPolygon sets a georss:polygon; the ring is closed automatically when the last point differs
+// from the first. At least three distinct valid points are required, otherwise the call is ignored.
+func (b *ItemBuilder) WithGeoPolygon(points ...GeoPoint) *ItemBuilder {
+	if len(points) < 3 || !allGeoPointsValid(points) {
+		return b
+	}
+	if points[0] != points[len(points)-1] {
+		points = append(append([]GeoPoint{}, points...), points[0])
+	}
+	if len(points) < 4 {
+		return b
+	}
+	return b.WithExtensions(ExtensionNode{Name: "georss:polygon", Text: formatGeoPoints(points...)})
+}
+
+func allGeoPointsValid(points []GeoPoint) bool {
+	for _, p := range points {
+		if !p.valid() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,81 @@
+package gofeedx_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func newGeoFeed(t *testing.T) *gofeedx.Feed {
+	t.Helper()
+	ib := gofeedx.NewItem("Somewhere").
+		WithID("geo-1").
+		WithLink("https://example.org/geo").
+		WithDescription("Located item").
+		WithCreated(time.Now().UTC()).
+		WithGeoPoint(45.256, -71.92).
+		WithGeoLine(gofeedx.GeoPoint{Lat: 45.256, Lon: -110.45}, gofeedx.GeoPoint{Lat: 46.46, Lon: -109.48}).
+		WithGeoPolygon(
+			gofeedx.GeoPoint{Lat: 45.256, Lon: -110.45},
+			gofeedx.GeoPoint{Lat: 46.46, Lon: -109.48},
+			gofeedx.GeoPoint{Lat: 43.84, Lon: -109.86},
+		)
+	f, err := gofeedx.NewFeed("Geo Feed").
+		WithLink("https://example.org/").
+		WithDescription("Geotagged items").
+		WithAuthor("Jane", "jane@example.org").
+		AddItem(ib).
+		Build()
+	mustNoErr(t, err, "Build failed")
+	return f
+}
+
+func TestGeoRSS_RSSAndAtomDeclareNamespace(t *testing.T) {
+	f := newGeoFeed(t)
+
+	rss, err := gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS failed")
+	mustContain(t, rss, `xmlns:georss="http://www.georss.org/georss"`, "expected georss namespace on rss root")
+	mustContain(t, rss, "<georss:point>45.256 -71.92</georss:point>", "expected georss:point in RSS item")
+	mustContain(t, rss, "<georss:line>45.256 -110.45 46.46 -109.48</georss:line>", "expected georss:line in RSS item")
+	// polygon ring is closed automatically
+	mustContain(t, rss, "<georss:polygon>45.256 -110.45 46.46 -109.48 43.84 -109.86 45.256 -110.45</georss:polygon>", "expected closed georss:polygon")
+
+	atom, err := gofeedx.ToAtom(f)
+	mustNoErr(t, err, "ToAtom failed")
+	mustContain(t, atom, `xmlns:georss="http://www.georss.org/georss"`, "expected georss namespace on atom feed")
+	mustContain(t, atom, "<georss:point>45.256 -71.92</georss:point>", "expected georss:point in Atom entry")
+}
+
+func TestGeoRSS_JSONFlattensLatLon(t *testing.T) {
+	f := newGeoFeed(t)
+	js, err := gofeedx.ToJSON(f)
+	mustNoErr(t, err, "ToJSON failed")
+	mustContain(t, js, `"_latitude": 45.256`, "expected _latitude in JSON item")
+	mustContain(t, js, `"_longitude": -71.92`, "expected _longitude in JSON item")
+	mustNotContain(t, js, `"georss:point"`, "did not expect raw georss:point key in JSON item")
+}
+
+func TestGeoRSS_NoNamespaceWithoutGeoData(t *testing.T) {
+	f := newRSSBaseFeed()
+	f.Items = append(f.Items, newRSSBaseItem())
+	rss, err := gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS failed")
+	if strings.Contains(rss, "xmlns:georss") {
+		t.Errorf("did not expect georss namespace without georss nodes")
+	}
+}
+
+func TestGeoRSS_InvalidCoordinatesIgnored(t *testing.T) {
+	it, err := gofeedx.NewItem("x").
+		WithGeoPoint(91, 0).
+		WithGeoLine(gofeedx.GeoPoint{Lat: 0, Lon: 0}).
+		WithGeoPolygon(gofeedx.GeoPoint{Lat: 0, Lon: 0}, gofeedx.GeoPoint{Lat: 1, Lon: 181}, gofeedx.GeoPoint{Lat: 2, Lon: 2}).
+		Build()
+	mustNoErr(t, err, "Build failed")
+	if len(it.Extensions) != 0 {
+		t.Fatalf("expected no georss nodes for invalid input, got %+v", it.Extensions)
+	}
+}
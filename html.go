@@ -0,0 +1,124 @@
+package gofeedx
+
+import (
+	"bytes"
+	"errors"
+	"html/template"
+	"strings"
+	"time"
+)
+
+// HTMLPage is the data ToHTML passes to its template.
+type HTMLPage struct {
+	Feed        *Feed
+	Title       string
+	Description string // plain text
+	Link        string
+	Artwork     string // Images.Artwork, falling back to Image.Url
+	Language    string
+	Episodes    []HTMLEpisode
+}
+
+// HTMLEpisode is one item of an HTMLPage.
+type HTMLEpisode struct {
+	Item        *Item
+	Title       string
+	Link        string
+	Description string // plain text
+	// DescriptionHTML is the item HTML after the feed's HTMLSanitizer (see WithHTMLSanitizer).
+	// Without a sanitizer it is the escaped plain text of the description.
+	DescriptionHTML template.HTML
+	Published       time.Time
+	Duration        string // HH:MM:SS, empty when unknown
+	ImageURL        string
+	MediaURL        string
+	MediaType       string
+	Video           bool // MediaType is video/*; the default template uses <video> instead of <audio>
+}
+
+// DefaultHTMLTemplate is the page ToHTML renders when no template is given: the feed title,
+// artwork and description followed by each episode with a media player.
+var DefaultHTMLTemplate = template.Must(template.New("feed").Parse(`<!DOCTYPE html>
+<html{{with .Language}} lang="{{.}}"{{end}}>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+{{with .Description}}<meta name="description" content="{{.}}">
+{{end}}<meta property="og:title" content="{{.Title}}">
+{{with .Artwork}}<meta property="og:image" content="{{.}}">
+{{end}}</head>
+<body>
+<header>
+{{with .Artwork}}<img src="{{.}}" alt="" width="300" height="300">
+{{end}}<h1>{{if .Link}}<a href="{{.Link}}">{{.Title}}</a>{{else}}{{.Title}}{{end}}</h1>
+{{with .Description}}<p>{{.}}</p>
+{{end}}</header>
+<main>
+{{range .Episodes}}<article>
+<h2>{{if .Link}}<a href="{{.Link}}">{{.Title}}</a>{{else}}{{.Title}}{{end}}</h2>
+{{if not .Published.IsZero}}<p><time datetime="{{.Published.Format "2006-01-02T15:04:05Z07:00"}}">{{.Published.Format "2 January 2006"}}</time>{{with .Duration}} · {{.}}{{end}}</p>
+{{end}}{{if .MediaURL}}{{if .Video}}<video controls preload="none" src="{{.MediaURL}}"></video>{{else}}<audio controls preload="none" src="{{.MediaURL}}"></audio>{{end}}
+{{end}}{{with .Description}}<p>{{.}}</p>
+{{end}}</article>
+{{end}}</main>
+</body>
+</html>
+`))
+
+// ToHTML renders feed as a human-readable HTML page with tmpl, or DefaultHTMLTemplate when
+// tmpl is nil, so a landing page can be served from the same data as the feeds. The template
+// receives an HTMLPage; html/template escapes all text and URLs.
+func ToHTML(feed *Feed, tmpl *template.Template) (string, error) {
+	if feed == nil {
+		return "", errors.New("nil feed")
+	}
+	if tmpl == nil {
+		tmpl = DefaultHTMLTemplate
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, newHTMLPage(feed.renderView(profilePage), feed.sanitizer != nil)); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func newHTMLPage(f *Feed, sanitized bool) *HTMLPage {
+	page := &HTMLPage{
+		Feed:        f,
+		Title:       strings.TrimSpace(f.Title),
+		Description: HTMLToText(f.Description),
+		Artwork:     feedArtworkURL(f),
+		Language:    strings.TrimSpace(f.Language),
+	}
+	if f.Link != nil {
+		page.Link = strings.TrimSpace(f.Link.Href)
+	}
+	for _, it := range f.Items {
+		if it == nil {
+			continue
+		}
+		desc := firstNonEmpty(it.Description, it.Content)
+		ep := HTMLEpisode{
+			Item:            it,
+			Title:           strings.TrimSpace(it.Title),
+			Description:     HTMLToText(desc),
+			DescriptionHTML: safeHTML(desc, sanitized),
+			Published:       it.Created,
+			ImageURL:        strings.TrimSpace(it.ImageURL),
+		}
+		if it.Link != nil {
+			ep.Link = strings.TrimSpace(it.Link.Href)
+		}
+		if it.DurationSeconds > 0 {
+			ep.Duration = FormatItunesDuration(it.DurationSeconds)
+		}
+		if e := it.Enclosure; e != nil {
+			ep.MediaURL = strings.TrimSpace(e.Url)
+			ep.MediaType = strings.TrimSpace(e.Type)
+			ep.Video = strings.HasPrefix(strings.ToLower(ep.MediaType), "video/")
+		}
+		page.Episodes = append(page.Episodes, ep)
+	}
+	return page
+}
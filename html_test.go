@@ -0,0 +1,73 @@
+package gofeedx_test
+
+import (
+	"html/template"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestToHTML_DefaultTemplate(t *testing.T) {
+	f := &gofeedx.Feed{
+		Title:       "Show <Live>",
+		Link:        &gofeedx.Link{Href: "https://example.org/"},
+		Description: "<p>A show about <b>things</b></p>",
+		Language:    "en",
+		Image:       &gofeedx.Image{Url: "https://example.org/art.jpg"},
+		Items: []*gofeedx.Item{
+			{
+				Title:           "Episode 1",
+				Link:            &gofeedx.Link{Href: "https://example.org/1"},
+				Description:     `<p>Hello<script>alert(1)</script></p>`,
+				Created:         time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC),
+				DurationSeconds: 125,
+				Enclosure:       &gofeedx.Enclosure{Url: "https://example.org/1.mp3", Type: "audio/mpeg", Length: 1},
+			},
+			{Title: "Video", Enclosure: &gofeedx.Enclosure{Url: "javascript:alert(1)", Type: "video/mp4", Length: 1}},
+		},
+	}
+	out, err := gofeedx.ToHTML(f, nil)
+	mustNoErr(t, err, "ToHTML failed")
+	mustContain(t, out, `<html lang="en">`, "expected lang")
+	mustContain(t, out, "<title>Show &lt;Live&gt;</title>", "expected escaped title")
+	mustContain(t, out, `<img src="https://example.org/art.jpg"`, "expected artwork")
+	mustContain(t, out, `<h2><a href="https://example.org/1">Episode 1</a></h2>`, "expected linked episode title")
+	mustContain(t, out, `<time datetime="2024-05-01T08:00:00Z">1 May 2024</time> · 00:02:05`, "expected date and duration")
+	mustContain(t, out, `<audio controls preload="none" src="https://example.org/1.mp3"></audio>`, "expected audio player")
+	mustContain(t, out, `<video controls preload="none" src="#ZgotmplZ"></video>`, "expected unsafe URL to be neutralized")
+	mustNotContain(t, out, "<script>", "description must be rendered as text")
+}
+
+func TestToHTML_CustomTemplate(t *testing.T) {
+	tmpl := template.Must(template.New("x").Parse(`{{.Title}}:{{range .Episodes}} {{.Title}}{{end}}`))
+	out, err := gofeedx.ToHTML(&gofeedx.Feed{Title: "T", Items: []*gofeedx.Item{{Title: "a"}, {Title: "b"}}}, tmpl)
+	mustNoErr(t, err, "ToHTML failed")
+	if out != "T: a b" {
+		t.Errorf("ToHTML = %q", out)
+	}
+	if _, err := gofeedx.ToHTML(nil, nil); err == nil {
+		t.Errorf("expected error for nil feed")
+	}
+}
+
+func TestToHTML_DescriptionHTMLEscapedWithoutSanitizer(t *testing.T) {
+	tmpl := template.Must(template.New("x").Parse(`{{range .Episodes}}{{.DescriptionHTML}}{{end}}`))
+	f := &gofeedx.Feed{Title: "T", Items: []*gofeedx.Item{{Title: "a", Description: `<p>Hi & <img src=x onerror=alert(1)></p>`}}}
+	out, err := gofeedx.ToHTML(f, tmpl)
+	mustNoErr(t, err, "ToHTML failed")
+	if out != "<p>Hi &amp;</p>" {
+		t.Errorf("expected escaped plain text without a sanitizer, got %q", out)
+	}
+	f.Items[0].Description = "<p>First paragraph.</p><p>Second paragraph.</p>"
+	out, err = gofeedx.ToHTML(f, tmpl)
+	mustNoErr(t, err, "ToHTML failed")
+	if out != "<p>First paragraph.</p><p>Second paragraph.</p>" {
+		t.Errorf("expected one <p> per paragraph without a sanitizer, got %q", out)
+	}
+	out, err = gofeedx.ToHTML(f.WithHTMLSanitizer(func(string) string { return "<p>clean</p>" }), tmpl)
+	mustNoErr(t, err, "ToHTML failed")
+	if out != "<p>clean</p>" {
+		t.Errorf("expected sanitized HTML, got %q", out)
+	}
+}
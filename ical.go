@@ -0,0 +1,104 @@
+package gofeedx
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// icalTimeFormat is the RFC 5545 UTC date-time form, e.g. "20240305T143000Z".
+const icalTimeFormat = "20060102T150405Z"
+
+// ToICal renders feed as an iCalendar (RFC 5545) document for feeds whose items are events
+// such as live shows or releases. Each item with a Created time becomes a VEVENT: Created is
+// DTSTART, DurationSeconds the DURATION, Title the SUMMARY, the description (as plain text)
+// the DESCRIPTION, Link the URL and the enclosure an ATTACH. Items without Created are
+// skipped. Times are written in UTC and lines are folded at 75 octets with CRLF breaks.
+func ToICal(feed *Feed) (string, error) {
+	if feed == nil {
+		return "", errors.New("nil feed")
+	}
+	feed = feed.renderView(profilePage)
+	w := &icalWriter{}
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:" + icalProductID(feed.Generator))
+	w.line("CALSCALE:GREGORIAN")
+	w.text("X-WR-CALNAME", feed.Title)
+	w.text("X-WR-CALDESC", HTMLToText(feed.Description))
+	for _, it := range feed.Items {
+		if it == nil || it.Created.IsZero() {
+			continue
+		}
+		uid := strings.TrimSpace(it.ID)
+		if uid == "" {
+			uid = fallbackItemGuid(it)
+		}
+		w.line("BEGIN:VEVENT")
+		w.text("UID", uid)
+		w.line("DTSTAMP:" + maxTime(it.Created, it.Updated).UTC().Format(icalTimeFormat))
+		w.line("DTSTART:" + it.Created.UTC().Format(icalTimeFormat))
+		if it.DurationSeconds > 0 {
+			w.line(fmt.Sprintf("DURATION:PT%dS", it.DurationSeconds))
+		}
+		w.text("SUMMARY", it.Title)
+		w.text("DESCRIPTION", HTMLToText(firstNonEmpty(it.Description, it.Content)))
+		if it.Link != nil && strings.TrimSpace(it.Link.Href) != "" {
+			w.line("URL:" + strings.TrimSpace(it.Link.Href))
+		}
+		if e := it.Enclosure; e != nil && strings.TrimSpace(e.Url) != "" {
+			attach := "ATTACH"
+			if t := strings.TrimSpace(e.Type); t != "" {
+				attach += ";FMTTYPE=" + t
+			}
+			w.line(attach + ":" + strings.TrimSpace(e.Url))
+		}
+		w.line("END:VEVENT")
+	}
+	w.line("END:VCALENDAR")
+	return w.sb.String(), nil
+}
+
+// icalProductID returns the PRODID value, e.g. "-//gofeedx//v1.2.0//EN".
+func icalProductID(g *Generator) string {
+	if g == nil || strings.TrimSpace(g.Name) == "" {
+		return "-//" + generatorName + "//EN"
+	}
+	id := "-//" + strings.TrimSpace(g.Name)
+	if v := strings.TrimSpace(g.Version); v != "" {
+		id += "//" + v
+	}
+	return id + "//EN"
+}
+
+type icalWriter struct {
+	sb strings.Builder
+}
+
+// text writes a TEXT property with RFC 5545 escaping; empty values are omitted.
+func (w *icalWriter) text(name, value string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+	w.line(name + ":" + r.Replace(value))
+}
+
+// line writes a content line folded at 75 octets without splitting UTF-8 sequences.
+func (w *icalWriter) line(s string) {
+	limit := 75
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		w.sb.WriteString(s[:cut])
+		w.sb.WriteString("\r\n ")
+		s = s[cut:]
+		limit = 74 // continuation lines start with a space
+	}
+	w.sb.WriteString(s)
+	w.sb.WriteString("\r\n")
+}
@@ -0,0 +1,62 @@
+package gofeedx_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestToICal(t *testing.T) {
+	start := time.Date(2024, 6, 1, 18, 0, 0, 0, time.FixedZone("CEST", 2*3600))
+	f := &gofeedx.Feed{
+		Title:     "Live Shows",
+		Generator: &gofeedx.Generator{Name: "gofeedx", Version: "v1.0.0"},
+		Items: []*gofeedx.Item{
+			{
+				Title:           "Launch; party, live",
+				ID:              "show-1",
+				Link:            &gofeedx.Link{Href: "https://example.org/show-1"},
+				Description:     "<p>Line one</p><p>Line two</p>",
+				Created:         start,
+				DurationSeconds: 5400,
+				Enclosure:       &gofeedx.Enclosure{Url: "https://example.org/show-1.mp3", Type: "audio/mpeg", Length: 1},
+			},
+			{Title: "Undated"},
+		},
+	}
+	out, err := gofeedx.ToICal(f)
+	mustNoErr(t, err, "ToICal failed")
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//gofeedx//v1.0.0//EN\r\n",
+		"X-WR-CALNAME:Live Shows\r\n",
+		"UID:show-1\r\n",
+		"DTSTART:20240601T160000Z\r\n",
+		"DURATION:PT5400S\r\n",
+		`SUMMARY:Launch\; party\, live` + "\r\n",
+		`DESCRIPTION:Line one\nLine two` + "\r\n",
+		"URL:https://example.org/show-1\r\n",
+		"ATTACH;FMTTYPE=audio/mpeg:https://example.org/show-1.mp3\r\n",
+		"END:VEVENT\r\nEND:VCALENDAR\r\n",
+	} {
+		mustContain(t, out, want, "expected "+want)
+	}
+	if strings.Count(out, "BEGIN:VEVENT") != 1 {
+		t.Errorf("expected undated item to be skipped:\n%s", out)
+	}
+
+	f.Items[0].Title = strings.Repeat("é", 60)
+	out, err = gofeedx.ToICal(f)
+	mustNoErr(t, err, "ToICal failed")
+	for _, line := range strings.Split(out, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 octets: %q", line)
+		}
+	}
+	mustContain(t, out, "\r\n é", "expected folded continuation line")
+
+	if _, err := gofeedx.ToICal(nil); err == nil {
+		t.Errorf("expected error for nil feed")
+	}
+}
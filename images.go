@@ -0,0 +1,81 @@
+package gofeedx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Images holds purpose-specific feed images so one generic Feed.Image does not have to serve
+// every format. Each writer picks the slot that suits it and falls back to Feed.Image.Url:
+//
+//   - Icon: small square icon (about 64px) for Atom <icon> and JSON Feed favicon
+//   - Logo: wide logo or banner for RSS <image> and Atom <logo>
+//   - Artwork: large square artwork for itunes:image (at least 1400px) and JSON Feed icon (512px)
+type Images struct {
+	Icon    string
+	Logo    string
+	Artwork string
+}
+
+// feedImageURL returns slot when set, otherwise the generic Feed.Image url.
+func feedImageURL(f *Feed, slot func(*Images) string) string {
+	if f.Images != nil {
+		if s := strings.TrimSpace(slot(f.Images)); s != "" {
+			return s
+		}
+	}
+	if f.Image != nil {
+		return strings.TrimSpace(f.Image.Url)
+	}
+	return ""
+}
+
+func feedIconURL(f *Feed) string {
+	return feedImageURL(f, func(i *Images) string { return i.Icon })
+}
+
+func feedLogoURL(f *Feed) string {
+	return feedImageURL(f, func(i *Images) string { return i.Logo })
+}
+
+func feedArtworkURL(f *Feed) string {
+	return feedImageURL(f, func(i *Images) string { return i.Artwork })
+}
+
+// WithIcon sets Images.Icon, the small square icon used for Atom <icon> and JSON Feed favicon.
+// An empty url clears it; a url that is not absolute is reported by Build in strict mode.
+func (b *FeedBuilder) WithIcon(url string) *FeedBuilder {
+	return b.setImageSlot("icon", url, func(i *Images, s string) { i.Icon = s })
+}
+
+// WithLogo sets Images.Logo, the wide logo used for RSS <image> and Atom <logo>.
+// An empty url clears it; a url that is not absolute is reported by Build in strict mode.
+func (b *FeedBuilder) WithLogo(url string) *FeedBuilder {
+	return b.setImageSlot("logo", url, func(i *Images, s string) { i.Logo = s })
+}
+
+// WithArtwork sets Images.Artwork, the square podcast artwork used for itunes:image and
+// JSON Feed icon. An empty url clears it; a url that is not absolute is reported by Build
+// in strict mode.
+func (b *FeedBuilder) WithArtwork(url string) *FeedBuilder {
+	return b.setImageSlot("artwork", url, func(i *Images, s string) { i.Artwork = s })
+}
+
+func (b *FeedBuilder) setImageSlot(name, url string, set func(*Images, string)) *FeedBuilder {
+	url = strings.TrimSpace(url)
+	if url != "" && !isAbsoluteURL(url) {
+		b.errs = append(b.errs, fmt.Errorf("images: %s url %q must be absolute", name, url))
+		return b
+	}
+	if b.feed.Images == nil {
+		if url == "" {
+			return b
+		}
+		b.feed.Images = &Images{}
+	}
+	set(b.feed.Images, url)
+	if *b.feed.Images == (Images{}) {
+		b.feed.Images = nil
+	}
+	return b
+}
@@ -0,0 +1,99 @@
+package gofeedx_test
+
+import (
+	"testing"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func newImagesFeed(t *testing.T) *gofeedx.Feed {
+	t.Helper()
+	b := gofeedx.NewFeed("Show").
+		WithLink("https://example.com/").
+		WithDescription("d").
+		WithLanguage("en").
+		WithFeedURL("https://example.com/feed.xml").
+		WithCategories("Technology").
+		WithAuthor("Host", "").
+		WithImage("https://example.com/generic.png", "", "").
+		WithIcon("https://example.com/favicon.png").
+		WithLogo("https://example.com/banner.png").
+		WithArtwork("https://example.com/artwork-3000.jpg")
+	b.AddItem(gofeedx.NewItem("E1").WithID("1").
+		WithEnclosure("https://example.com/e1.mp3", 1, "audio/mpeg"))
+	f, err := b.Build()
+	mustNoErr(t, err, "Build failed")
+	return f
+}
+
+func TestImages_PerFormatSlots(t *testing.T) {
+	f := newImagesFeed(t)
+
+	rss, err := gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS failed")
+	mustContain(t, rss, "<url>https://example.com/banner.png</url>", "expected RSS image from logo")
+	mustContain(t, rss, "<link>https://example.com/</link>", "expected RSS image link from channel")
+
+	atom, err := gofeedx.ToAtom(f)
+	mustNoErr(t, err, "ToAtom failed")
+	mustContain(t, atom, "<logo>https://example.com/banner.png</logo>", "expected Atom logo")
+	mustContain(t, atom, "<icon>https://example.com/favicon.png</icon>", "expected Atom icon")
+
+	psp, err := gofeedx.ToPSP(f)
+	mustNoErr(t, err, "ToPSP failed")
+	mustContain(t, psp, `<itunes:image href="https://example.com/artwork-3000.jpg">`, "expected itunes:image from artwork")
+
+	js, err := gofeedx.ToJSON(f)
+	mustNoErr(t, err, "ToJSON failed")
+	mustContain(t, js, `"icon": "https://example.com/artwork-3000.jpg"`, "expected JSON icon from artwork")
+	mustContain(t, js, `"favicon": "https://example.com/favicon.png"`, "expected JSON favicon from icon")
+}
+
+func TestImages_FallbackToImage(t *testing.T) {
+	f := newImagesFeed(t)
+	f.Images.Icon = ""
+
+	atom, err := gofeedx.ToAtom(f)
+	mustNoErr(t, err, "ToAtom failed")
+	mustContain(t, atom, "<icon>https://example.com/generic.png</icon>", "expected Atom icon to fall back to Image")
+
+	_, err = gofeedx.NewFeed("Show").WithArtwork("/art.jpg").Build()
+	mustErr(t, err, "expected error for relative artwork url")
+}
+
+func TestImages_ItemImageURL(t *testing.T) {
+	b := gofeedx.NewFeed("Show").
+		WithLink("https://example.com/").
+		WithDescription("d").
+		WithLanguage("en").
+		WithFeedURL("https://example.com/feed.xml").
+		WithCategories("Technology").
+		WithAuthor("Host", "").
+		WithArtwork("https://example.com/artwork.jpg")
+	b.AddItem(gofeedx.NewItem("E1").WithID("1").
+		WithEnclosure("https://example.com/e1.mp3", 1, "audio/mpeg").
+		WithImageURL("https://example.com/e1.jpg"))
+	f, err := b.Build()
+	mustNoErr(t, err, "Build failed")
+
+	rss, err := gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS failed")
+	mustContain(t, rss, `xmlns:media="http://search.yahoo.com/mrss/"`, "expected media namespace in RSS")
+	mustContain(t, rss, `<media:thumbnail url="https://example.com/e1.jpg"></media:thumbnail>`, "expected RSS media:thumbnail")
+
+	atom, err := gofeedx.ToAtom(f)
+	mustNoErr(t, err, "ToAtom failed")
+	mustContain(t, atom, `xmlns:media="http://search.yahoo.com/mrss/"`, "expected media namespace in Atom")
+	mustContain(t, atom, `<media:thumbnail url="https://example.com/e1.jpg">`, "expected Atom media:thumbnail")
+
+	psp, err := gofeedx.ToPSP(f)
+	mustNoErr(t, err, "ToPSP failed")
+	mustContain(t, psp, `<itunes:image href="https://example.com/e1.jpg">`, "expected item itunes:image")
+
+	js, err := gofeedx.ToJSON(f)
+	mustNoErr(t, err, "ToJSON failed")
+	mustContain(t, js, `"image": "https://example.com/e1.jpg"`, "expected JSON item image")
+
+	_, err = gofeedx.NewItem("E1").WithImageURL("e1.jpg").Build()
+	mustErr(t, err, "expected error for relative item image url")
+}
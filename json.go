@@ -62,7 +62,15 @@ type JSONItem struct {
 	ContentText string          `json:"content_text,omitempty"`
 	BannerImage string          `json:"banner_image,omitempty"`
 	Tags        []string        `json:"tags,omitempty"`
+	Language    string          `json:"language,omitempty"` // v1.1
 	Exts        []ExtensionNode `json:"-"`
+
+	// Custom holds structured extension objects keyed by their "_"-prefixed name (see WithJSONExtension)
+	Custom map[string]json.RawMessage `json:"-"`
+
+	// Location from georss:point, as custom (underscore-prefixed) JSON Feed keys
+	Latitude  *float64 `json:"_latitude,omitempty"`
+	Longitude *float64 `json:"_longitude,omitempty"`
 }
 
 // JSONHub describes an endpoint that can be used to subscribe to real-time notifications.
@@ -89,6 +97,9 @@ type JSONFeed struct {
 	Expired     *bool           `json:"expired,omitempty"`
 	Hubs        []*JSONHub      `json:"hubs,omitempty"`
 	Exts        []ExtensionNode `json:"-"`
+
+	// Custom holds structured extension objects keyed by their "_"-prefixed name (see WithJSONExtension)
+	Custom map[string]json.RawMessage `json:"-"`
 }
 
 // JSON is used to convert a generic Feed to a JSONFeed.
@@ -140,7 +151,7 @@ func (f *JSONFeed) MarshalJSON() ([]byte, error) {
 	if err := json.Unmarshal(base, &m); err != nil {
 		return nil, err
 	}
-	// Flatten extensions: name -> text (attributes/children ignored)
+	// Flatten extensions: "_"-prefixed name -> text (attributes/children ignored)
 	for _, n := range f.Exts {
 		if n.Name == "" || n.Text == "" {
 			continue
@@ -148,7 +159,10 @@ func (f *JSONFeed) MarshalJSON() ([]byte, error) {
 		if IsInternalExtensionName(n.Name) {
 			continue
 		}
-		m[n.Name] = n.Text
+		m[jsonExtensionKey(n.Name)] = n.Text
+	}
+	for k, v := range f.Custom {
+		m[k] = v
 	}
 	return json.Marshal(m)
 }
@@ -171,7 +185,9 @@ func jsonFeedBaseFromFeed(f *Feed) *JSONFeed {
 	if f.Author != nil {
 		feed.Authors = jsonAuthorsFromAuthor(f.Author)
 	}
-	applyFeedIconsFromImage(feed, f.Image)
+	applyFeedIconsFromImage(feed, f)
+	applyJSONLicense(feed, f.License)
+	applyJSONGenerator(feed, f.Generator)
 	return feed
 }
 
@@ -182,15 +198,12 @@ func jsonAuthorsFromAuthor(a *Author) []*JSONAuthor {
 	return []*JSONAuthor{{Name: a.Name}}
 }
 
-func applyFeedIconsFromImage(feed *JSONFeed, img *Image) {
-	if img == nil || strings.TrimSpace(img.Url) == "" {
-		return
-	}
+func applyFeedIconsFromImage(feed *JSONFeed, f *Feed) {
 	if strings.TrimSpace(feed.Icon) == "" {
-		feed.Icon = img.Url
+		feed.Icon = feedArtworkURL(f)
 	}
 	if strings.TrimSpace(feed.Favicon) == "" {
-		feed.Favicon = img.Url
+		feed.Favicon = feedIconURL(f)
 	}
 }
 
@@ -215,18 +228,12 @@ func mapFeedExtensionsToJSON(feed *JSONFeed, exts []ExtensionNode) {
 			return false
 		},
 		"_json:expired": func(f *JSONFeed, n ExtensionNode) bool {
-			switch strings.ToLower(strings.TrimSpace(n.Text)) {
-			case "true":
-				v := true
-				f.Expired = &v
-				return true
-			case "false":
-				v := false
-				f.Expired = &v
-				return true
-			default:
+			v, ok := ParseFeedBool(n.Text)
+			if !ok {
 				return false
 			}
+			f.Expired = &v
+			return true
 		},
 		"_json:hub": func(f *JSONFeed, n ExtensionNode) bool {
 			var ht, hu string
@@ -254,6 +261,16 @@ func mapFeedExtensionsToJSON(feed *JSONFeed, exts []ExtensionNode) {
 			}
 			return false
 		},
+		websubHubMarker: func(f *JSONFeed, n ExtensionNode) bool {
+			if s := strings.TrimSpace(n.Text); s != "" {
+				f.Hubs = append(f.Hubs, &JSONHub{Type: "WebSub", Url: s})
+				return true
+			}
+			return false
+		},
+		"_json:extension": func(f *JSONFeed, n ExtensionNode) bool {
+			return addJSONCustom(&f.Custom, n)
+		},
 	}
 	var extras []ExtensionNode
 	for _, n := range exts {
@@ -271,6 +288,31 @@ func mapFeedExtensionsToJSON(feed *JSONFeed, exts []ExtensionNode) {
 	feed.Exts = extras
 }
 
+// jsonExtensionKey returns the JSON Feed key for a flattened extension node. The spec
+// requires custom keys to start with "_", so names without it (e.g. "podcast:funding")
+// are prefixed; this also keeps extensions from overwriting spec-defined keys.
+func jsonExtensionKey(name string) string {
+	name = strings.TrimSpace(name)
+	if strings.HasPrefix(name, "_") {
+		return name
+	}
+	return "_" + name
+}
+
+// addJSONCustom stores a _json:extension marker (key attr, raw JSON text) into custom.
+func addJSONCustom(custom *map[string]json.RawMessage, n ExtensionNode) bool {
+	key := strings.TrimSpace(n.Attrs["key"])
+	raw := json.RawMessage(strings.TrimSpace(n.Text))
+	if len(key) < 2 || !strings.HasPrefix(key, "_") || !json.Valid(raw) {
+		return false
+	}
+	if *custom == nil {
+		*custom = map[string]json.RawMessage{}
+	}
+	(*custom)[key] = raw
+	return true
+}
+
 func jsonItemBase(i *Item) *JSONItem {
 	id := strings.TrimSpace(i.ID)
 	if id == "" {
@@ -281,6 +323,8 @@ func jsonItemBase(i *Item) *JSONItem {
 		Title:       i.Title,
 		Summary:     i.Description,
 		ContentHTML: i.Content,
+		Language:    strings.TrimSpace(i.Language),
+		Image:       strings.TrimSpace(i.ImageURL),
 	}
 	if i.Link != nil {
 		item.Url = i.Link.Href
@@ -304,9 +348,11 @@ func addItemEnclosure(j *JSONItem, i *Item) {
 	if i.Enclosure == nil {
 		return
 	}
-	// If it's an image, map to JSON Feed's "image"
+	// If it's an image, map to JSON Feed's "image" unless Item.ImageURL already set it
 	if strings.HasPrefix(i.Enclosure.Type, "image/") {
-		j.Image = i.Enclosure.Url
+		if j.Image == "" {
+			j.Image = i.Enclosure.Url
+		}
 		return
 	}
 	// Otherwise, add as an attachment with optional duration
@@ -331,7 +377,7 @@ func mapItemExtensionsToJSON(ji *JSONItem, exts []ExtensionNode) {
 	if len(exts) == 0 {
 		return
 	}
-		var extras []ExtensionNode
+	var extras []ExtensionNode
 	for _, n := range exts {
 		name := strings.TrimSpace(strings.ToLower(n.Name))
 		switch name {
@@ -370,6 +416,22 @@ func mapItemExtensionsToJSON(ji *JSONItem, exts []ExtensionNode) {
 			} else {
 				extras = append(extras, n)
 			}
+		case "_json:external_url":
+			if s := strings.TrimSpace(n.Text); s != "" {
+				ji.ExternalUrl = s
+			} else {
+				extras = append(extras, n)
+			}
+		case "_json:extension":
+			if !addJSONCustom(&ji.Custom, n) {
+				extras = append(extras, n)
+			}
+		case "georss:point":
+			if p, err := parseGeoPoint(n.Text); err == nil {
+				ji.Latitude, ji.Longitude = &p.Lat, &p.Lon
+			} else {
+				extras = append(extras, n)
+			}
 		default:
 			if IsInternalExtensionName(name) {
 				continue
@@ -382,16 +444,17 @@ func mapItemExtensionsToJSON(ji *JSONItem, exts []ExtensionNode) {
 
 // JSONFeed creates a new JSONFeed with a generic Feed struct's data.
 func (f *JSON) JSONFeed() *JSONFeed {
+	f = &JSON{f.renderView(ProfileJSON)}
 	feed := jsonFeedBaseFromFeed(f.Feed)
 
 	// Items
+	derive := hasJSONDeriveContentText(f.allExtensions())
 	for _, e := range f.Items {
-		ji := newJSONItem(e)
-		feed.Items = append(feed.Items, ji)
+		feed.Items = append(feed.Items, newJSONFeedItem(e, derive))
 	}
 
 	// Extensions mapping and flattening extras
-	mapFeedExtensionsToJSON(feed, f.Extensions)
+	mapFeedExtensionsToJSON(feed, f.allExtensions())
 	return feed
 }
 
@@ -408,7 +471,7 @@ func (ji *JSONItem) MarshalJSON() ([]byte, error) {
 	if err := json.Unmarshal(base, &m); err != nil {
 		return nil, err
 	}
-	// Flatten extensions: name -> text (attributes/children ignored)
+	// Flatten extensions: "_"-prefixed name -> text (attributes/children ignored)
 	for _, n := range ji.Exts {
 		if n.Name == "" || n.Text == "" {
 			continue
@@ -416,15 +479,39 @@ func (ji *JSONItem) MarshalJSON() ([]byte, error) {
 		if IsInternalExtensionName(n.Name) {
 			continue
 		}
-		m[n.Name] = n.Text
+		m[jsonExtensionKey(n.Name)] = n.Text
+	}
+	for k, v := range ji.Custom {
+		m[k] = v
 	}
 	return json.Marshal(m)
 }
 
+// jsonDeriveContentTextMarker enables content_text derivation (see WithJSONDerivedContentText).
+const jsonDeriveContentTextMarker = "_json:derive_content_text"
+
+func hasJSONDeriveContentText(exts []ExtensionNode) bool {
+	for _, n := range exts {
+		if strings.EqualFold(strings.TrimSpace(n.Name), jsonDeriveContentTextMarker) {
+			return true
+		}
+	}
+	return false
+}
+
+// newJSONFeedItem converts i like newJSONItem and derives content_text when requested.
+func newJSONFeedItem(i *Item, deriveContentText bool) *JSONItem {
+	ji := newJSONItem(i)
+	if deriveContentText && ji.ContentText == "" {
+		ji.ContentText = HTMLToText(firstNonEmpty(ji.ContentHTML, ji.Summary))
+	}
+	return ji
+}
+
 func newJSONItem(i *Item) *JSONItem {
 	item := jsonItemBase(i)
 	addItemEnclosure(item, i)
-	mapItemExtensionsToJSON(item, i.Extensions)
+	mapItemExtensionsToJSON(item, i.allExtensions())
 	return item
 }
 
@@ -432,13 +519,19 @@ func newJSONItem(i *Item) *JSONItem {
 func ValidateJSON(f *Feed) error {
 	// Top-level required: title (version is set by the writer), items must be present
 	if strings.TrimSpace(f.Title) == "" {
-		return errors.New("json: feed title required")
+		return feedError(ProfileJSON, "Title", ErrMissingTitle, "json: feed title required")
+	}
+	if err := validateLanguageIfSet(ProfileJSON, -1, f.Language); err != nil {
+		return err
 	}
 
 	// Item-level: id is required by spec
 	for i, it := range f.Items {
 		if strings.TrimSpace(it.ID) == "" {
-			return fmt.Errorf("json: item[%d] id required", i)
+			return itemError(ProfileJSON, i, "ID", ErrMissingID, "json: item[%d] id required", i)
+		}
+		if err := validateLanguageIfSet(ProfileJSON, i, it.Language); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -453,7 +546,7 @@ func (b *FeedBuilder) WithJSONUserComment(text string) *FeedBuilder {
 	if text == "" {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_json:user_comment", Text: text})
+	return b.withOption(ExtensionNode{Name: "_json:user_comment", Text: text})
 }
 
 // WithJSONNextURL sets feed-level next_url.
@@ -462,7 +555,7 @@ func (b *FeedBuilder) WithJSONNextURL(url string) *FeedBuilder {
 	if url == "" {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_json:next_url", Text: url})
+	return b.withOption(ExtensionNode{Name: "_json:next_url", Text: url})
 }
 
 // WithJSONExpired sets feed-level expired flag.
@@ -471,7 +564,14 @@ func (b *FeedBuilder) WithJSONExpired(expired bool) *FeedBuilder {
 	if expired {
 		val = "true"
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_json:expired", Text: val})
+	return b.withOption(ExtensionNode{Name: "_json:expired", Text: val})
+}
+
+// WithJSONDerivedContentText fills each item's content_text with plain text derived from its
+// HTML content (or, without content, its summary) unless WithJSONContentText set one.
+// See HTMLToText.
+func (b *FeedBuilder) WithJSONDerivedContentText() *FeedBuilder {
+	return b.withOption(ExtensionNode{Name: jsonDeriveContentTextMarker, Text: "true"})
 }
 
 // WithJSONHub adds a PubSub hub.
@@ -481,7 +581,7 @@ func (b *FeedBuilder) WithJSONHub(hubType, url string) *FeedBuilder {
 	if hubType == "" || url == "" {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_json:hub", Attrs: map[string]string{"type": hubType, "url": url}})
+	return b.withOption(ExtensionNode{Name: "_json:hub", Attrs: map[string]string{"type": hubType, "url": url}})
 }
 
 // WithJSONIcon overrides feed icon.
@@ -490,7 +590,7 @@ func (b *FeedBuilder) WithJSONIcon(url string) *FeedBuilder {
 	if url == "" {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_json:icon", Text: url})
+	return b.withOption(ExtensionNode{Name: "_json:icon", Text: url})
 }
 
 // WithJSONFavicon overrides feed favicon.
@@ -499,7 +599,7 @@ func (b *FeedBuilder) WithJSONFavicon(url string) *FeedBuilder {
 	if url == "" {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_json:favicon", Text: url})
+	return b.withOption(ExtensionNode{Name: "_json:favicon", Text: url})
 }
 
 // Item-level helpers:
@@ -510,7 +610,7 @@ func (b *ItemBuilder) WithJSONContentText(text string) *ItemBuilder {
 	if text == "" {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_json:content_text", Text: text})
+	return b.withOption(ExtensionNode{Name: "_json:content_text", Text: text})
 }
 
 // WithJSONBannerImage sets item banner_image.
@@ -519,7 +619,7 @@ func (b *ItemBuilder) WithJSONBannerImage(url string) *ItemBuilder {
 	if url == "" {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_json:banner_image", Text: url})
+	return b.withOption(ExtensionNode{Name: "_json:banner_image", Text: url})
 }
 
 // WithJSONTags sets item tags from a list.
@@ -536,7 +636,7 @@ func (b *ItemBuilder) WithJSONTags(tags ...string) *ItemBuilder {
 	if len(trimmed) == 0 {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_json:tags", Text: strings.Join(trimmed, ",")})
+	return b.withOption(ExtensionNode{Name: "_json:tags", Text: strings.Join(trimmed, ",")})
 }
 
 // WithJSONTag appends a single tag.
@@ -545,7 +645,7 @@ func (b *ItemBuilder) WithJSONTag(tag string) *ItemBuilder {
 	if tag == "" {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_json:tag", Text: tag})
+	return b.withOption(ExtensionNode{Name: "_json:tag", Text: tag})
 }
 
 // WithJSONImage overrides item image.
@@ -554,5 +654,65 @@ func (b *ItemBuilder) WithJSONImage(url string) *ItemBuilder {
 	if url == "" {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_json:image", Text: url})
+	return b.withOption(ExtensionNode{Name: "_json:image", Text: url})
+}
+
+// WithJSONExternalURL sets item external_url, overriding the value derived from Item.Source.
+func (b *ItemBuilder) WithJSONExternalURL(url string) *ItemBuilder {
+	url = strings.TrimSpace(url)
+	if url == "" {
+		return b
+	}
+	return b.withOption(ExtensionNode{Name: "_json:external_url", Text: url})
+}
+
+// WithJSONLanguage sets Item.Language like WithLanguage, written as the JSON Feed 1.1 item
+// language, but normalizes the tag via NormalizeLanguage and ignores values that are not
+// valid BCP 47 tags.
+func (b *ItemBuilder) WithJSONLanguage(tag string) *ItemBuilder {
+	tag = NormalizeLanguage(tag)
+	if tag == "" || !IsValidLanguage(tag) {
+		return b
+	}
+	b.item.Language = tag
+	return b
+}
+
+// jsonExtensionNode encodes value as a _json:extension marker. Keys are prefixed with "_"
+// when missing, as JSON Feed requires for custom extensions.
+func jsonExtensionNode(key string, value any) (ExtensionNode, error) {
+	key = strings.TrimSpace(key)
+	if !strings.HasPrefix(key, "_") {
+		key = "_" + key
+	}
+	if key == "_" {
+		return ExtensionNode{}, errors.New("json: extension key required")
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ExtensionNode{}, fmt.Errorf("json: extension %q: %w", key, err)
+	}
+	return ExtensionNode{Name: "_json:extension", Attrs: map[string]string{"key": key}, Text: string(data)}, nil
+}
+
+// WithJSONExtension adds a structured top-level extension object; value must be JSON-marshalable.
+// Marshalling errors are reported by Build in strict mode.
+func (b *FeedBuilder) WithJSONExtension(key string, value any) *FeedBuilder {
+	n, err := jsonExtensionNode(key, value)
+	if err != nil {
+		b.errs = append(b.errs, err)
+		return b
+	}
+	return b.withOption(n)
+}
+
+// WithJSONExtension adds a structured item extension object; value must be JSON-marshalable.
+// Marshalling errors are reported by Build in strict mode.
+func (b *ItemBuilder) WithJSONExtension(key string, value any) *ItemBuilder {
+	n, err := jsonExtensionNode(key, value)
+	if err != nil {
+		b.errs = append(b.errs, err)
+		return b
+	}
+	return b.withOption(n)
 }
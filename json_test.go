@@ -408,6 +408,8 @@ func TestJSONFeedExtensionsFlattened(t *testing.T) {
 	f := newJSONBaseFeed()
 	f.Extensions = []gofeedx.ExtensionNode{
 		{Name: "x-top", Text: "top"},
+		{Name: "_already", Text: "kept"},
+		{Name: "title", Text: "must not clobber"},
 	}
 	item := newJSONBaseItem()
 	item.Extensions = []gofeedx.ExtensionNode{
@@ -425,8 +427,18 @@ func TestJSONFeedExtensionsFlattened(t *testing.T) {
 	if err := json.Unmarshal([]byte(js), &doc); err != nil {
 		t.Fatalf("unmarshal: %v", err)
 	}
-	if v, ok := doc["x-top"].(string); !ok || v != "top" {
-		t.Errorf("expected flattened extension x-top at top-level, got %#v", doc["x-top"])
+	// JSON Feed custom keys must start with "_"; missing prefixes are added
+	if v, ok := doc["_x-top"].(string); !ok || v != "top" {
+		t.Errorf("expected flattened extension _x-top at top-level, got %#v", doc["_x-top"])
+	}
+	if _, ok := doc["x-top"]; ok {
+		t.Errorf("did not expect unprefixed x-top key")
+	}
+	if v, _ := doc["_already"].(string); v != "kept" {
+		t.Errorf("expected _already to keep its name, got %#v", doc["_already"])
+	}
+	if doc["title"] != "Example JSON Feed" || doc["_title"] != "must not clobber" {
+		t.Errorf("expected extension named title to land under _title, got title=%#v _title=%#v", doc["title"], doc["_title"])
 	}
 	arr, ok := doc["items"].([]any)
 	if !ok || len(arr) == 0 {
@@ -436,8 +448,8 @@ func TestJSONFeedExtensionsFlattened(t *testing.T) {
 	if !ok {
 		t.Fatalf("expected first item to be object")
 	}
-	if v, ok := first["x-item"].(string); !ok || v != "ival" {
-		t.Errorf("expected flattened extension x-item at item-level, got %#v", first["x-item"])
+	if v, ok := first["_x-item"].(string); !ok || v != "ival" {
+		t.Errorf("expected flattened extension _x-item at item-level, got %#v", first["_x-item"])
 	}
 }
 
@@ -699,14 +711,14 @@ func TestJSONFlattenSkipsEmptyExtensionValues(t *testing.T) {
 		t.Fatalf("unmarshal: %v", err)
 	}
 	// top-level: expect x-valid only
-	if _, ok := obj["x-empty"]; ok {
+	if _, ok := obj["_x-empty"]; ok {
 		t.Errorf("did not expect x-empty key (empty text should be skipped)")
 	}
 	if _, ok := obj[""]; ok {
 		t.Errorf("did not expect empty-name key")
 	}
-	if obj["x-valid"] != "val" {
-		t.Errorf("expected x-valid flattened with val, got %v", obj["x-valid"])
+	if obj["_x-valid"] != "val" {
+		t.Errorf("expected x-valid flattened with val, got %v", obj["_x-valid"])
 	}
 	// item-level: expect y-valid only
 	items, _ := obj["items"].([]any)
@@ -714,13 +726,114 @@ func TestJSONFlattenSkipsEmptyExtensionValues(t *testing.T) {
 		t.Fatalf("expected items array")
 	}
 	first, _ := items[0].(map[string]any)
-	if _, ok := first["y-empty"]; ok {
+	if _, ok := first["_y-empty"]; ok {
 		t.Errorf("did not expect y-empty key (empty text should be skipped)")
 	}
 	if _, ok := first[""]; ok {
 		t.Errorf("did not expect empty-name key at item level")
 	}
-	if first["y-valid"] != "ival" {
-		t.Errorf("expected y-valid flattened with ival, got %v", first["y-valid"])
+	if first["_y-valid"] != "ival" {
+		t.Errorf("expected y-valid flattened with ival, got %v", first["_y-valid"])
+	}
+}
+
+func TestJSONItem_LanguageAndExternalURL(t *testing.T) {
+	ib := gofeedx.NewItem("Hallo").
+		WithID("id-de").
+		WithSource("https://example.org/source").
+		WithJSONExternalURL("https://elsewhere.example/post").
+		WithJSONLanguage("DE_at")
+	f, err := gofeedx.NewFeed("JSON Title").
+		WithLink("https://example.org/").
+		AddItem(ib).
+		WithProfiles(gofeedx.ProfileJSON).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() unexpected error: %v", err)
+	}
+	js, err := gofeedx.ToJSON(f)
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	if !strings.Contains(js, `"external_url": "https://elsewhere.example/post"`) {
+		t.Errorf("expected external_url from WithJSONExternalURL to override Item.Source, got:\n%s", js)
+	}
+	if !strings.Contains(js, `"language": "de-at"`) {
+		t.Errorf("expected normalized item language, got:\n%s", js)
+	}
+	if f.Items[0].Language != "de-at" || len(f.Items[0].Extensions) != 0 {
+		t.Errorf("expected Item.Language set without extension markers, got %q %+v", f.Items[0].Language, f.Items[0].Extensions)
+	}
+
+	// invalid language tags are ignored
+	it, err := gofeedx.NewItem("x").WithJSONLanguage("not a tag").Build()
+	if err != nil {
+		t.Fatalf("Build() unexpected error: %v", err)
+	}
+	if it.Language != "" {
+		t.Errorf("expected invalid language to be ignored, got %q", it.Language)
+	}
+}
+
+func TestJSONExtension_StructuredValues(t *testing.T) {
+	type rating struct {
+		Stars int    `json:"stars"`
+		By    string `json:"by"`
+	}
+	ib := gofeedx.NewItem("Item 1").
+		WithID("id-1").
+		WithJSONExtension("rating", rating{Stars: 4, By: "editor"})
+	f, err := gofeedx.NewFeed("JSON Title").
+		WithLink("https://example.org/").
+		WithJSONExtension("_blue_shed", map[string]any{"about": "https://blueshed-podcasts.com/json-feed-extension-docs", "explicit": false}).
+		AddItem(ib).
+		WithProfiles(gofeedx.ProfileJSON).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() unexpected error: %v", err)
+	}
+	js, err := gofeedx.ToJSON(f)
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	var doc struct {
+		BlueShed struct {
+			About    string `json:"about"`
+			Explicit *bool  `json:"explicit"`
+		} `json:"_blue_shed"`
+		Items []struct {
+			Rating rating `json:"_rating"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(js), &doc); err != nil {
+		t.Fatalf("unmarshal output: %v", err)
+	}
+	if doc.BlueShed.About == "" || doc.BlueShed.Explicit == nil || *doc.BlueShed.Explicit {
+		t.Errorf("expected nested _blue_shed object, got:\n%s", js)
+	}
+	if len(doc.Items) != 1 || doc.Items[0].Rating != (rating{Stars: 4, By: "editor"}) {
+		t.Errorf("expected nested _rating object on item (key auto-prefixed), got:\n%s", js)
+	}
+
+	// The marker must not leak into XML outputs
+	rss, err := gofeedx.ToRSS(f)
+	if err != nil {
+		t.Fatalf("ToRSS failed: %v", err)
+	}
+	if strings.Contains(rss, "_json") || strings.Contains(rss, "stars") {
+		t.Errorf("did not expect JSON extension in RSS output")
+	}
+}
+
+func TestJSONExtension_UnmarshalableValueFailsStrictBuild(t *testing.T) {
+	_, err := gofeedx.NewFeed("JSON Title").
+		WithJSONExtension("bad", func() {}).
+		Build()
+	if err == nil || !strings.Contains(err.Error(), `json: extension "_bad"`) {
+		t.Fatalf("expected extension marshal error, got %v", err)
+	}
+	_, err = gofeedx.NewItem("x").WithJSONExtension("  ", 1).Build()
+	if err == nil {
+		t.Fatalf("expected error for empty extension key")
 	}
 }
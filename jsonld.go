@@ -0,0 +1,144 @@
+package gofeedx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LDPodcastSeries is a schema.org PodcastSeries for JSON-LD structured data.
+type LDPodcastSeries struct {
+	Context     string              `json:"@context"`
+	Type        string              `json:"@type"`
+	Name        string              `json:"name"`
+	URL         string              `json:"url,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Image       string              `json:"image,omitempty"`
+	WebFeed     string              `json:"webFeed,omitempty"`
+	InLanguage  string              `json:"inLanguage,omitempty"`
+	Author      *LDPerson           `json:"author,omitempty"`
+	Episodes    []*LDPodcastEpisode `json:"episode,omitempty"`
+}
+
+// LDPerson is a schema.org Person.
+type LDPerson struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+// LDPodcastEpisode is a schema.org PodcastEpisode.
+type LDPodcastEpisode struct {
+	Type            string         `json:"@type"`
+	Name            string         `json:"name"`
+	URL             string         `json:"url,omitempty"`
+	Identifier      string         `json:"identifier,omitempty"`
+	Description     string         `json:"description,omitempty"`
+	DatePublished   string         `json:"datePublished,omitempty"`
+	DateModified    string         `json:"dateModified,omitempty"`
+	TimeRequired    string         `json:"timeRequired,omitempty"` // ISO 8601 duration
+	Image           string         `json:"image,omitempty"`
+	AssociatedMedia *LDMediaObject `json:"associatedMedia,omitempty"`
+}
+
+// LDMediaObject is the schema.org MediaObject of an episode's audio or video file.
+type LDMediaObject struct {
+	Type           string `json:"@type"`
+	ContentURL     string `json:"contentUrl"`
+	EncodingFormat string `json:"encodingFormat,omitempty"`
+	ContentSize    string `json:"contentSize,omitempty"` // bytes
+	Duration       string `json:"duration,omitempty"`    // ISO 8601 duration
+}
+
+// ToJSONLD renders feed as a schema.org PodcastSeries with PodcastEpisode entries in JSON-LD,
+// for embedding in a <script type="application/ld+json"> element on the show website.
+// Descriptions are plain text; durations use ISO 8601 (e.g. "PT1H2M5S"). encoding/json
+// escapes "<", so text such as "</script>" cannot end the embedding element.
+func ToJSONLD(feed *Feed) (string, error) {
+	s, err := NewLDPodcastSeries(feed)
+	if err != nil {
+		return "", err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// NewLDPodcastSeries returns the structured value that ToJSONLD encodes.
+func NewLDPodcastSeries(feed *Feed) (*LDPodcastSeries, error) {
+	if feed == nil {
+		return nil, errors.New("nil feed")
+	}
+	feed = feed.renderView(profilePage)
+	s := &LDPodcastSeries{
+		Context:     "https://schema.org",
+		Type:        "PodcastSeries",
+		Name:        strings.TrimSpace(feed.Title),
+		Description: HTMLToText(feed.Description),
+		Image:       feedArtworkURL(feed),
+		WebFeed:     strings.TrimSpace(feed.FeedURL),
+		InLanguage:  strings.TrimSpace(feed.Language),
+	}
+	if feed.Link != nil {
+		s.URL = strings.TrimSpace(feed.Link.Href)
+	}
+	if name := authorName(feed.Author); name != "" {
+		s.Author = &LDPerson{Type: "Person", Name: name}
+	}
+	for _, it := range feed.Items {
+		if it == nil {
+			continue
+		}
+		ep := &LDPodcastEpisode{
+			Type:          "PodcastEpisode",
+			Name:          strings.TrimSpace(it.Title),
+			Identifier:    strings.TrimSpace(it.ID),
+			Description:   HTMLToText(firstNonEmpty(it.Description, it.Content)),
+			DatePublished: anyTimeFormat(time.RFC3339, it.Created),
+			Image:         strings.TrimSpace(it.ImageURL),
+		}
+		if !it.Updated.IsZero() && it.Updated.After(it.Created) {
+			ep.DateModified = it.Updated.Format(time.RFC3339)
+		}
+		if it.Link != nil {
+			ep.URL = strings.TrimSpace(it.Link.Href)
+		}
+		if it.DurationSeconds > 0 {
+			ep.TimeRequired = isoDuration(it.DurationSeconds)
+		}
+		if e := it.Enclosure; e != nil && strings.TrimSpace(e.Url) != "" {
+			ep.AssociatedMedia = &LDMediaObject{
+				Type:           "MediaObject",
+				ContentURL:     strings.TrimSpace(e.Url),
+				EncodingFormat: strings.TrimSpace(e.Type),
+				Duration:       ep.TimeRequired,
+			}
+			if e.Length > 0 {
+				ep.AssociatedMedia.ContentSize = fmt.Sprint(e.Length)
+			}
+		}
+		s.Episodes = append(s.Episodes, ep)
+	}
+	return s, nil
+}
+
+// isoDuration formats sec as an ISO 8601 duration such as "PT1H2M5S", omitting zero parts.
+func isoDuration(sec int) string {
+	if sec <= 0 {
+		return "PT0S"
+	}
+	out := "PT"
+	if h := sec / 3600; h > 0 {
+		out += fmt.Sprintf("%dH", h)
+	}
+	if m := sec / 60 % 60; m > 0 {
+		out += fmt.Sprintf("%dM", m)
+	}
+	if s := sec % 60; s > 0 {
+		out += fmt.Sprintf("%dS", s)
+	}
+	return out
+}
@@ -0,0 +1,57 @@
+package gofeedx_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestToJSONLD(t *testing.T) {
+	f := &gofeedx.Feed{
+		Title:       "Show",
+		Link:        &gofeedx.Link{Href: "https://example.org/"},
+		Description: "<p>About things</p>",
+		FeedURL:     "https://example.org/feed.xml",
+		Language:    "en",
+		Author:      &gofeedx.Author{Name: "Host"},
+		Image:       &gofeedx.Image{Url: "https://example.org/art.jpg"},
+		Items: []*gofeedx.Item{{
+			Title:           "Pilot </script>",
+			ID:              "ep-1",
+			Link:            &gofeedx.Link{Href: "https://example.org/1"},
+			Description:     "<p>Hi</p>",
+			Created:         time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC),
+			DurationSeconds: 3725,
+			Enclosure:       &gofeedx.Enclosure{Url: "https://example.org/1.mp3", Type: "audio/mpeg", Length: 1234},
+		}},
+	}
+	out, err := gofeedx.ToJSONLD(f)
+	mustNoErr(t, err, "ToJSONLD failed")
+	mustNotContain(t, out, "</script>", "closing script tags must be escaped")
+	mustContain(t, out, `"timeRequired": "PT1H2M5S"`, "expected ISO 8601 duration")
+
+	var s gofeedx.LDPodcastSeries
+	mustNoErr(t, json.Unmarshal([]byte(out), &s), "invalid JSON")
+	if s.Context != "https://schema.org" || s.Type != "PodcastSeries" || s.WebFeed != "https://example.org/feed.xml" ||
+		s.Description != "About things" || s.Image != "https://example.org/art.jpg" || s.Author.Name != "Host" {
+		t.Errorf("unexpected series %+v", s)
+	}
+	if len(s.Episodes) != 1 {
+		t.Fatalf("expected one episode, got %d", len(s.Episodes))
+	}
+	ep := s.Episodes[0]
+	if ep.Type != "PodcastEpisode" || ep.URL != "https://example.org/1" || ep.DatePublished != "2024-05-01T08:00:00Z" ||
+		ep.Name != "Pilot </script>" || ep.Description != "Hi" {
+		t.Errorf("unexpected episode %+v", ep)
+	}
+	m := ep.AssociatedMedia
+	if m == nil || m.ContentURL != "https://example.org/1.mp3" || m.EncodingFormat != "audio/mpeg" || m.ContentSize != "1234" || m.Duration != "PT1H2M5S" {
+		t.Errorf("unexpected media %+v", m)
+	}
+
+	if _, err := gofeedx.ToJSONLD(nil); err == nil {
+		t.Errorf("expected error for nil feed")
+	}
+}
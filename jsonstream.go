@@ -0,0 +1,95 @@
+package gofeedx
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// WriteJSONStream writes feed as JSON Feed 1.1 to w, converting and encoding one item at a
+// time, so memory stays bounded by the largest item rather than the whole document. The bytes
+// written equal ToJSON's output.
+func WriteJSONStream(feed *Feed, w io.Writer) error {
+	if feed == nil {
+		return errors.New("nil feed")
+	}
+	feed = feed.renderView(ProfileJSON)
+	// The feed without items, with a placeholder under "items" so the key lands at the
+	// position ToJSON gives it (keys are written in sorted order).
+	items := feed.Items
+	shallow := *feed
+	shallow.Items = nil
+	skeleton := (&JSON{Feed: &shallow}).JSONFeed()
+	var placeholder []byte
+	if len(items) > 0 {
+		placeholder, _ = json.Marshal("gofeedx:items:" + rand.Text())
+		custom := make(map[string]json.RawMessage, len(skeleton.Custom)+1)
+		for k, v := range skeleton.Custom {
+			custom[k] = v
+		}
+		custom["items"] = placeholder
+		skeleton.Custom = custom
+	}
+	head, err := json.MarshalIndent(skeleton, "", "  ")
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	if len(items) == 0 {
+		if _, err := bw.Write(head); err != nil {
+			return err
+		}
+		return bw.Flush()
+	}
+	i := bytes.Index(head, placeholder)
+	if i < 0 {
+		return errors.New("json: stream item placeholder not found")
+	}
+	if _, err := bw.Write(head[:i]); err != nil {
+		return err
+	}
+	if _, err := bw.WriteString("["); err != nil {
+		return err
+	}
+	derive := hasJSONDeriveContentText(feed.allExtensions())
+	for n, it := range items {
+		data, err := json.MarshalIndent(newJSONFeedItem(it, derive), "    ", "  ")
+		if err != nil {
+			return err
+		}
+		sep := ",\n    "
+		if n == 0 {
+			sep = "\n    "
+		}
+		if _, err := bw.WriteString(sep); err != nil {
+			return err
+		}
+		if _, err := bw.Write(data); err != nil {
+			return err
+		}
+	}
+	if _, err := bw.WriteString("\n  ]"); err != nil {
+		return err
+	}
+	if _, err := bw.Write(head[i+len(placeholder):]); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// WriteJSONStreamGzip is WriteJSONStream with gzip compression at the given level
+// (gzip.DefaultCompression, gzip.BestSpeed, ...).
+func WriteJSONStreamGzip(feed *Feed, w io.Writer, level int) error {
+	zw, err := gzip.NewWriterLevel(w, level)
+	if err != nil {
+		return err
+	}
+	if err := WriteJSONStream(feed, zw); err != nil {
+		return err
+	}
+	return zw.Close()
+}
@@ -0,0 +1,46 @@
+package gofeedx_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestWriteJSONStream_MatchesToJSON(t *testing.T) {
+	f := newRSSBaseFeed()
+	f.Extensions = append(f.Extensions, gofeedx.ExtensionNode{Name: "_json:extension", Attrs: map[string]string{"key": "_meta"}, Text: `{"items": null}`})
+	f.Items = nil
+	for _, id := range []string{"a", "b", "c"} {
+		it := newRSSBaseItem()
+		it.ID = id
+		f.Items = append(f.Items, it)
+	}
+	for _, items := range [][]*gofeedx.Item{f.Items, nil} {
+		f.Items = items
+		want, err := gofeedx.ToJSON(f)
+		mustNoErr(t, err, "ToJSON")
+		var buf bytes.Buffer
+		mustNoErr(t, gofeedx.WriteJSONStream(f, &buf), "WriteJSONStream")
+		if buf.String() != want {
+			t.Fatalf("stream output differs\n got %s\nwant %s", buf.String(), want)
+		}
+	}
+}
+
+func TestWriteJSONStreamGzip(t *testing.T) {
+	f := newRSSBaseFeed()
+	want, err := gofeedx.ToJSON(f)
+	mustNoErr(t, err, "ToJSON")
+	var buf bytes.Buffer
+	mustNoErr(t, gofeedx.WriteJSONStreamGzip(f, &buf, gzip.BestSpeed), "WriteJSONStreamGzip")
+	zr, err := gzip.NewReader(&buf)
+	mustNoErr(t, err, "gzip.NewReader")
+	got, err := io.ReadAll(zr)
+	mustNoErr(t, err, "read gzip")
+	if string(got) != want {
+		t.Fatalf("gzip stream output differs\n got %s\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,62 @@
+package gofeedx
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// languageTagPattern matches well-formed RFC 5646 (BCP 47) language tags in their
+// case-insensitive form:
+//   - primary language: 2-3 letters (ISO 639-1/2/3) or 4-8 letters (registered)
+//   - optional extlang (up to three 3-letter subtags), script (4 letters),
+//     region (2 letters or 3 digits), variants, extensions and private use
+//
+// A bare private-use tag ("x-...") is accepted as well.
+var languageTagPattern = regexp.MustCompile(`^(?i:` +
+	`(?:[a-z]{2,3}(?:-[a-z]{3}){0,3}|[a-z]{4,8})` + // language (+ extlang)
+	`(?:-[a-z]{4})?` + // script
+	`(?:-(?:[a-z]{2}|[0-9]{3}))?` + // region
+	`(?:-(?:[a-z0-9]{5,8}|[0-9][a-z0-9]{3}))*` + // variants
+	`(?:-[a-wyz0-9](?:-[a-z0-9]{2,8})+)*` + // extensions
+	`(?:-x(?:-[a-z0-9]{1,8})+)?` + // private use
+	`|x(?:-[a-z0-9]{1,8})+` + // private use only
+	`)$`)
+
+// NormalizeLanguage returns the language tag in the lowercase, hyphen-separated form
+// commonly used by feeds (e.g., "EN_us" -> "en-us"). Surrounding whitespace is removed.
+// The result is not validated; use IsValidLanguage for that.
+func NormalizeLanguage(tag string) string {
+	s := strings.TrimSpace(tag)
+	s = strings.ReplaceAll(s, "_", "-")
+	return strings.ToLower(s)
+}
+
+// IsValidLanguage reports whether tag is a well-formed BCP 47 (RFC 5646) language tag
+// with an ISO 639 primary language subtag. Matching is case-insensitive and underscores
+// are not accepted; call NormalizeLanguage first to repair "en_US" style input.
+func IsValidLanguage(tag string) bool {
+	s := strings.TrimSpace(tag)
+	if s == "" {
+		return false
+	}
+	return languageTagPattern.MatchString(s)
+}
+
+// validateLanguageIfSet returns a ProfileError when lang is non-empty and not a well-formed
+// language tag. index is the item index, or -1 for the feed language.
+func validateLanguageIfSet(p Profile, index int, lang string) error {
+	s := strings.TrimSpace(lang)
+	if s == "" || IsValidLanguage(s) {
+		return nil
+	}
+	const format = "%s: language %q is not a valid BCP 47 language tag (e.g. \"en-us\")"
+	if index < 0 {
+		return feedError(p, "Language", ErrInvalidLanguage, format, p, s)
+	}
+	scope := fmt.Sprintf("%s: item[%d]", p, index)
+	if p == ProfileAtom {
+		scope = fmt.Sprintf("%s: entry[%d]", p, index)
+	}
+	return itemError(p, index, "Language", ErrInvalidLanguage, format, scope, s)
+}
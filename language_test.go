@@ -0,0 +1,140 @@
+package gofeedx
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNormalizeLanguage(t *testing.T) {
+	cases := map[string]string{
+		"EN_us":      "en-us",
+		"  de-DE ":   "de-de",
+		"fr":         "fr",
+		"zh_Hant_TW": "zh-hant-tw",
+		"":           "",
+	}
+	for in, want := range cases {
+		if got := NormalizeLanguage(in); got != want {
+			t.Errorf("NormalizeLanguage(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestIsValidLanguage(t *testing.T) {
+	valid := []string{"en", "en-us", "EN-US", "de-CH-1996", "zh-Hant-TW", "es-419", "haw", "sgn-ase", "en-a-bbb-x-a-ccc", "x-private"}
+	for _, s := range valid {
+		if !IsValidLanguage(s) {
+			t.Errorf("IsValidLanguage(%q) expected true", s)
+		}
+	}
+	invalid := []string{"", "e", "en_US", "english language", "en-", "en--us", "123", "en-us-"}
+	for _, s := range invalid {
+		if IsValidLanguage(s) {
+			t.Errorf("IsValidLanguage(%q) expected false", s)
+		}
+	}
+}
+
+func TestValidateProfiles_LanguageTag(t *testing.T) {
+	f := &Feed{
+		Title:       "T",
+		Link:        &Link{Href: "https://example.org/"},
+		Description: "D",
+		Language:    "en_US",
+		FeedURL:     "https://example.org/feed.xml",
+		Categories:  []*Category{{Text: "Technology"}},
+	}
+	if err := ValidateRSS(f); err == nil || !strings.Contains(err.Error(), "rss: language") {
+		t.Errorf("ValidateRSS expected language error, got %v", err)
+	}
+	if err := ValidateJSON(f); err == nil || !strings.Contains(err.Error(), "json: language") {
+		t.Errorf("ValidateJSON expected language error, got %v", err)
+	}
+	if err := ValidatePSP(f); err == nil || !strings.Contains(err.Error(), "psp: language") {
+		t.Errorf("ValidatePSP expected language error, got %v", err)
+	}
+
+	f.Language = NormalizeLanguage(f.Language)
+	if err := ValidateRSS(f); err != nil {
+		t.Errorf("ValidateRSS unexpected error after normalization: %v", err)
+	}
+	if err := ValidateJSON(f); err != nil {
+		t.Errorf("ValidateJSON unexpected error after normalization: %v", err)
+	}
+	if err := ValidatePSP(f); err != nil {
+		t.Errorf("ValidatePSP unexpected error after normalization: %v", err)
+	}
+
+	// Language remains optional for RSS/JSON
+	f.Language = ""
+	if err := ValidateRSS(f); err != nil {
+		t.Errorf("ValidateRSS should accept empty language: %v", err)
+	}
+	if err := ValidateJSON(f); err != nil {
+		t.Errorf("ValidateJSON should accept empty language: %v", err)
+	}
+}
+
+func TestItemLanguage_MappedPerFormat(t *testing.T) {
+	now := time.Now().UTC()
+	f := &Feed{
+		Title:       "T",
+		Link:        &Link{Href: "https://example.org/"},
+		Description: "D",
+		Author:      &Author{Name: "A"},
+		Created:     now,
+		Items: []*Item{
+			{Title: "Hallo", ID: "de-1", Created: now, Language: "de-at"},
+			{Title: "Hello", ID: "en-1", Created: now},
+		},
+	}
+
+	rss, err := ToRSS(f)
+	if err != nil {
+		t.Fatalf("ToRSS failed: %v", err)
+	}
+	if !strings.Contains(rss, `xmlns:dc="http://purl.org/dc/elements/1.1/"`) || !strings.Contains(rss, "<dc:language>de-at</dc:language>") {
+		t.Errorf("expected dc:language with namespace in RSS, got:\n%s", rss)
+	}
+	if strings.Count(rss, "<dc:language>") != 1 {
+		t.Errorf("expected dc:language only on the item that sets it")
+	}
+
+	atom, err := ToAtom(f)
+	if err != nil {
+		t.Fatalf("ToAtom failed: %v", err)
+	}
+	if !strings.Contains(atom, `xml:lang="de-at"`) {
+		t.Errorf("expected xml:lang on atom entry, got:\n%s", atom)
+	}
+
+	js, err := ToJSON(f)
+	if err != nil {
+		t.Fatalf("ToJSON failed: %v", err)
+	}
+	if !strings.Contains(js, `"language": "de-at"`) {
+		t.Errorf("expected item language in JSON, got:\n%s", js)
+	}
+
+	f.Items[0].Language = "de_AT"
+	if err := ValidateRSS(f); err == nil || !strings.Contains(err.Error(), "rss: item[0]: language") {
+		t.Errorf("ValidateRSS expected item language error, got %v", err)
+	}
+	if err := ValidateAtom(f); err == nil || !strings.Contains(err.Error(), "atom: entry[0]: language") {
+		t.Errorf("ValidateAtom expected entry language error, got %v", err)
+	}
+	if err := ValidateJSON(f); err == nil || !strings.Contains(err.Error(), "json: item[0]: language") {
+		t.Errorf("ValidateJSON expected item language error, got %v", err)
+	}
+}
+
+func TestItemBuilder_WithLanguage(t *testing.T) {
+	it, err := NewItem("x").WithLanguage(" fr-CA ").Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if it.Language != "fr-CA" {
+		t.Errorf("Language = %q, want fr-CA", it.Language)
+	}
+}
@@ -0,0 +1,96 @@
+package gofeedx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// License describes the feed's license once for all formats: RSS <copyright>, Atom <rights>,
+// PSP <copyright> plus podcast:license, and a "_license" object in JSON Feed.
+// Name is ideally an SPDX identifier (e.g. "CC-BY-4.0"); URL points to the license text.
+type License struct {
+	Name string
+	URL  string
+}
+
+// copyrightText returns the human-readable form used for copyright/rights: "Name (URL)",
+// or whichever of the two is set.
+func (l *License) copyrightText() string {
+	if l == nil {
+		return ""
+	}
+	name, url := strings.TrimSpace(l.Name), strings.TrimSpace(l.URL)
+	switch {
+	case name != "" && url != "":
+		return name + " (" + url + ")"
+	case name != "":
+		return name
+	}
+	return url
+}
+
+// feedRights returns the copyright text for feed: an explicit Copyright wins over License.
+func feedRights(f *Feed) string {
+	if strings.TrimSpace(f.Copyright) != "" {
+		return f.Copyright
+	}
+	return f.License.copyrightText()
+}
+
+// addPodcastLicenseFromFeed appends podcast:license for feed.License unless the feed already
+// sets one through an extension. Licenses podcast:license cannot express are skipped.
+func addPodcastLicenseFromFeed(p *PSP, ch *PSPChannel) {
+	if p.License == nil || hasExtensionNamed(p.Extensions, "podcast:license") {
+		return
+	}
+	if n, ok := podcastLicenseNode(p.License.Name, p.License.URL); ok {
+		ch.Extra = append(ch.Extra, n)
+	}
+}
+
+// applyJSONLicense sets the "_license" object from feed.License; a "_license" set through
+// WithJSONExtension is applied later and wins.
+func applyJSONLicense(feed *JSONFeed, l *License) {
+	if l == nil || (strings.TrimSpace(l.Name) == "" && strings.TrimSpace(l.URL) == "") {
+		return
+	}
+	data, err := json.Marshal(struct {
+		Name string `json:"name,omitempty"`
+		URL  string `json:"url,omitempty"`
+	}{strings.TrimSpace(l.Name), strings.TrimSpace(l.URL)})
+	if err != nil {
+		return
+	}
+	if feed.Custom == nil {
+		feed.Custom = map[string]json.RawMessage{}
+	}
+	feed.Custom["_license"] = data
+}
+
+func hasExtensionNamed(exts []ExtensionNode, name string) bool {
+	for _, n := range exts {
+		if strings.EqualFold(strings.TrimSpace(n.Name), name) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithLicense sets Feed.License, which every writer maps to its own rights mechanism.
+// An explicit WithCopyright still takes precedence for copyright/rights text.
+// A missing name and url, or a url that is not absolute, is reported by Build in strict mode.
+func (b *FeedBuilder) WithLicense(name, url string) *FeedBuilder {
+	name, url = strings.TrimSpace(name), strings.TrimSpace(url)
+	if name == "" && url == "" {
+		b.errs = append(b.errs, errors.New("license: name or url required"))
+		return b
+	}
+	if url != "" && !isAbsoluteURL(url) {
+		b.errs = append(b.errs, fmt.Errorf("license: url %q must be absolute", url))
+		return b
+	}
+	b.feed.License = &License{Name: name, URL: url}
+	return b
+}
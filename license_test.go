@@ -0,0 +1,55 @@
+package gofeedx_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestFeedBuilder_WithLicense(t *testing.T) {
+	b := gofeedx.NewFeed("Show").
+		WithLink("https://example.com/").
+		WithDescription("d").
+		WithAuthor("Host", "").
+		WithFeedURL("https://example.com/feed.xml").
+		WithLanguage("en").
+		WithLicense("cc-by-4.0", "https://creativecommons.org/licenses/by/4.0/").
+		WithProfiles(gofeedx.ProfileRSS, gofeedx.ProfileAtom, gofeedx.ProfileJSON)
+	b.AddItem(gofeedx.NewItem("e1").WithID("1").WithCreated(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	f, err := b.Build()
+	mustNoErr(t, err, "Build")
+
+	rss, err := gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS")
+	mustContain(t, rss, "cc-by-4.0 (https://creativecommons.org/licenses/by/4.0/)", "expected license as RSS copyright")
+
+	atom, err := gofeedx.ToAtom(f)
+	mustNoErr(t, err, "ToAtom")
+	mustContain(t, atom, "<rights>", "expected Atom rights")
+
+	js, err := gofeedx.ToJSON(f)
+	mustNoErr(t, err, "ToJSON")
+	mustContain(t, js, `"_license"`, "expected _license object")
+	mustContain(t, js, `"name": "cc-by-4.0"`, "expected license name")
+
+	psp, err := gofeedx.ToPSP(f)
+	mustNoErr(t, err, "ToPSP")
+	mustContain(t, psp, "<copyright>", "expected PSP copyright")
+	mustContain(t, psp, `<podcast:license url="https://creativecommons.org/licenses/by/4.0/">CC-BY-4.0</podcast:license>`, "expected podcast:license")
+
+	f.Copyright = "© Example"
+	rss, err = gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS")
+	mustContain(t, rss, "© Example", "explicit copyright must win")
+	mustNotContain(t, rss, "cc-by-4.0 (", "license must not override explicit copyright")
+}
+
+func TestFeedBuilder_WithLicense_Invalid(t *testing.T) {
+	_, err := gofeedx.NewFeed("T").WithLink("https://example.com/").WithDescription("d").
+		WithLicense("MIT", "licenses/mit").WithProfiles(gofeedx.ProfileRSS).Build()
+	mustErr(t, err, "expected error for relative license url")
+	_, err = gofeedx.NewFeed("T").WithLink("https://example.com/").WithDescription("d").
+		WithLicense(" ", "").WithProfiles(gofeedx.ProfileRSS).Build()
+	mustErr(t, err, "expected error for empty license")
+}
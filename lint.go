@@ -0,0 +1,289 @@
+package gofeedx
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Severity ranks a lint issue.
+type Severity string
+
+const (
+	// SeverityError marks data that is wrong and should be fixed before publishing.
+	SeverityError Severity = "error"
+	// SeverityWarning marks data that is valid but likely to surprise readers or apps.
+	SeverityWarning Severity = "warning"
+)
+
+// Issue is one finding of an optional Check.
+type Issue struct {
+	Path     string // e.g. "Feed.Updated", "Items[2].Created"
+	Severity Severity
+	Message  string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s %s: %s", i.Path, i.Severity, i.Message)
+}
+
+// Check inspects a feed and returns its findings. Checks are opt-in and independent of the
+// profile validators, which reject feeds outright.
+type Check func(feed *Feed) []Issue
+
+// Lint runs checks on feed in order and returns all issues; an empty result means none found.
+func Lint(feed *Feed, checks ...Check) []Issue {
+	if feed == nil {
+		return nil
+	}
+	var out []Issue
+	for _, c := range checks {
+		if c != nil {
+			out = append(out, c(feed)...)
+		}
+	}
+	return out
+}
+
+// HasLintErrors reports whether any issue has SeverityError.
+func HasLintErrors(issues []Issue) bool {
+	for _, i := range issues {
+		if i.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// DateCheckOptions configures CheckDates.
+type DateCheckOptions struct {
+	Now       time.Time     // reference time; zero uses time.Now()
+	Tolerance time.Duration // how far in the future a date may lie before it is reported
+}
+
+// CheckDates reports dates on the feed and its items that lie more than Tolerance after Now
+// (warnings: some podcast apps hide episodes with future pubDates until then) and Updated
+// values earlier than Created (errors).
+func CheckDates(opts DateCheckOptions) Check {
+	return func(feed *Feed) []Issue {
+		now := opts.Now
+		if now.IsZero() {
+			now = time.Now()
+		}
+		limit := now.Add(opts.Tolerance)
+		var out []Issue
+		check := func(path string, created, updated time.Time) {
+			for _, d := range []struct {
+				field string
+				t     time.Time
+			}{{"Created", created}, {"Updated", updated}} {
+				if !d.t.IsZero() && d.t.After(limit) {
+					out = append(out, Issue{Path: path + "." + d.field, Severity: SeverityWarning,
+						Message: fmt.Sprintf("%s is in the future", d.t.Format(time.RFC3339))})
+				}
+			}
+			if !created.IsZero() && !updated.IsZero() && updated.Before(created) {
+				out = append(out, Issue{Path: path + ".Updated", Severity: SeverityError,
+					Message: fmt.Sprintf("%s is before Created %s", updated.Format(time.RFC3339), created.Format(time.RFC3339))})
+			}
+		}
+		check("Feed", feed.Created, feed.Updated)
+		for i, it := range feed.Items {
+			if it != nil {
+				check(fmt.Sprintf("Items[%d]", i), it.Created, it.Updated)
+			}
+		}
+		return out
+	}
+}
+
+// urlAttrNames are extension attributes holding URLs (podcast:funding url, atom:link href, ...).
+var urlAttrNames = []string{"href", "url", "src"}
+
+// urlMarkerSuffixes identify internal markers whose text is a URL (e.g. _json:icon, _rss:docs).
+var urlMarkerSuffixes = []string{"url", ":icon", ":favicon", ":image", ":banner_image", ":docs", ":comments", ":base"}
+
+// itemRelativeMarkers are item markers that may hold relative references: an entry xml:base
+// resolves against the feed's.
+var itemRelativeMarkers = map[string]bool{"_atom:base": true}
+
+// CheckURLs reports URL-bearing fields that do not parse as absolute http or https URLs with
+// a host: links, feed URL, image, enclosures, source feed and comment links, and href/url/src
+// attributes of extension nodes (funding, transcripts, atom:link, ...) at any depth, plus
+// builder markers that carry a URL. A relative entry xml:base (ItemBuilder.WithAtomBase) is
+// accepted.
+func CheckURLs() Check {
+	return func(feed *Feed) []Issue {
+		var out []Issue
+		check := func(path, raw string) {
+			if raw = strings.TrimSpace(raw); raw == "" {
+				return
+			}
+			if msg := urlProblem(raw); msg != "" {
+				out = append(out, Issue{Path: path, Severity: SeverityError, Message: fmt.Sprintf("%q %s", raw, msg)})
+			}
+		}
+		if feed.Link != nil {
+			check("Feed.Link.Href", feed.Link.Href)
+		}
+		check("Feed.FeedURL", feed.FeedURL)
+		if feed.Image != nil {
+			check("Feed.Image.Url", feed.Image.Url)
+			check("Feed.Image.Link", feed.Image.Link)
+		}
+		if feed.Images != nil {
+			check("Feed.Images.Icon", feed.Images.Icon)
+			check("Feed.Images.Logo", feed.Images.Logo)
+			check("Feed.Images.Artwork", feed.Images.Artwork)
+		}
+		checkExtensionURLs("Feed", feed.allExtensions(), nil, check)
+		for i, it := range feed.Items {
+			if it == nil {
+				continue
+			}
+			p := fmt.Sprintf("Items[%d]", i)
+			if it.Link != nil {
+				check(p+".Link.Href", it.Link.Href)
+			}
+			if it.Source != nil {
+				check(p+".Source.Href", it.Source.Href)
+			}
+			check(p+".ImageURL", it.ImageURL)
+			if it.Enclosure != nil {
+				check(p+".Enclosure.Url", it.Enclosure.Url)
+			}
+			if sf := it.SourceFeed; sf != nil {
+				check(p+".SourceFeed.Link", sf.Link)
+				check(p+".SourceFeed.FeedURL", sf.FeedURL)
+			}
+			check(p+".CommentsURL", it.CommentsURL)
+			check(p+".CommentsFeedURL", it.CommentsFeedURL)
+			checkExtensionURLs(p, it.allExtensions(), itemRelativeMarkers, check)
+		}
+		return out
+	}
+}
+
+// checkExtensionURLs checks the URLs in exts; markers named in relative may also hold
+// relative references.
+func checkExtensionURLs(scope string, exts []ExtensionNode, relative map[string]bool, check func(path, raw string)) {
+	for _, n := range exts {
+		name := strings.TrimSpace(n.Name)
+		path := fmt.Sprintf("%s.Extensions[%s]", scope, name)
+		for _, a := range urlAttrNames {
+			if v, ok := n.Attrs[a]; ok {
+				check(path+"."+a, v)
+			}
+		}
+		if IsInternalExtensionName(name) {
+			lower := strings.ToLower(name)
+			if relative[lower] && isRelativeReference(n.Text) {
+				continue
+			}
+			for _, s := range urlMarkerSuffixes {
+				if strings.HasSuffix(lower, s) {
+					check(path, n.Text)
+					break
+				}
+			}
+		}
+		checkExtensionURLs(path, n.Children, nil, check)
+	}
+}
+
+// isRelativeReference reports whether raw parses as a URL reference without a scheme.
+func isRelativeReference(raw string) bool {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	return err == nil && u.Scheme == ""
+}
+
+// urlProblem describes why raw is not an absolute http(s) URL, or returns "".
+func urlProblem(raw string) string {
+	u, err := url.Parse(raw)
+	switch {
+	case err != nil:
+		return "does not parse: " + err.Error()
+	case u.Scheme != "http" && u.Scheme != "https":
+		return "must use http or https"
+	case u.Host == "":
+		return "has no host"
+	}
+	return ""
+}
+
+// ItunesCheckOptions configures CheckItunes; zero values use the defaults below.
+type ItunesCheckOptions struct {
+	MaxTitleLength int // characters per feed or episode title; zero uses 150
+	MaxKeywords    int // comma-separated itunes:keywords values; zero uses 12
+}
+
+// episodeNumberTitlePattern matches titles that open with an episode number ("Ep 12:",
+// "Episode 3 -", "#42 |"), which Apple asks publishers to move into itunes:episode.
+var episodeNumberTitlePattern = regexp.MustCompile(`(?i)^\s*(?:ep(?:isode)?\.?\s*#?|#)\s*\d+\s*(?:[:|.\-\x{2013}\x{2014}]|$)`)
+
+// CheckItunes reports, as warnings, data that Apple Podcasts accepts but discourages: titles
+// longer than MaxTitleLength, episode titles that start with an episode number, more than
+// MaxKeywords itunes:keywords and itunes:summary text over PSPDescriptionMaxBytes.
+func CheckItunes(opts ItunesCheckOptions) Check {
+	maxTitle, maxKeywords := opts.MaxTitleLength, opts.MaxKeywords
+	if maxTitle <= 0 {
+		maxTitle = 150
+	}
+	if maxKeywords <= 0 {
+		maxKeywords = 12
+	}
+	return func(feed *Feed) []Issue {
+		var out []Issue
+		warn := func(path, format string, args ...any) {
+			out = append(out, Issue{Path: path, Severity: SeverityWarning, Message: fmt.Sprintf(format, args...)})
+		}
+		title := func(path, t string) {
+			if n := utf8.RuneCountInString(strings.TrimSpace(t)); n > maxTitle {
+				warn(path, "title has %d characters; keep it under %d", n, maxTitle)
+			}
+		}
+		exts := func(scope string, nodes []ExtensionNode) {
+			for _, n := range nodes {
+				path := fmt.Sprintf("%s.Extensions[%s]", scope, strings.TrimSpace(n.Name))
+				switch textLowerTrim(n.Name) {
+				case "itunes:keywords":
+					if k := len(splitKeywords(n.Text)); k > maxKeywords {
+						warn(path, "%d keywords; Apple Podcasts uses at most %d", k, maxKeywords)
+					}
+				case "itunes:summary":
+					if l := len(strings.TrimSpace(n.Text)); l > PSPDescriptionMaxBytes {
+						warn(path, "summary has %d bytes; Apple Podcasts truncates after %d", l, PSPDescriptionMaxBytes)
+					}
+				}
+			}
+		}
+		title("Feed.Title", feed.Title)
+		exts("Feed", feed.allExtensions())
+		for i, it := range feed.Items {
+			if it == nil {
+				continue
+			}
+			p := fmt.Sprintf("Items[%d]", i)
+			title(p+".Title", it.Title)
+			if episodeNumberTitlePattern.MatchString(it.Title) {
+				warn(p+".Title", "%q starts with an episode number; set itunes:episode (WithPSPEpisode) instead", strings.TrimSpace(it.Title))
+			}
+			exts(p, it.allExtensions())
+		}
+		return out
+	}
+}
+
+// splitKeywords returns the non-empty comma-separated values of s.
+func splitKeywords(s string) []string {
+	var out []string
+	for _, k := range strings.Split(s, ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			out = append(out, k)
+		}
+	}
+	return out
+}
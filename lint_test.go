@@ -0,0 +1,134 @@
+package gofeedx_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestCheckDates(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	f := &gofeedx.Feed{
+		Title:   "Dates",
+		Created: now.Add(-time.Hour),
+		Updated: now,
+		Items: []*gofeedx.Item{
+			{Title: "ok", Created: now.Add(-time.Hour)},
+			{Title: "soon", Created: now.Add(30 * time.Minute)},
+			{Title: "future", Created: now.Add(48 * time.Hour)},
+			{Title: "backwards", Created: now.Add(-time.Hour), Updated: now.Add(-2 * time.Hour)},
+		},
+	}
+	issues := gofeedx.Lint(f, gofeedx.CheckDates(gofeedx.DateCheckOptions{Now: now, Tolerance: time.Hour}))
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %v", issues)
+	}
+	if issues[0].Path != "Items[2].Created" || issues[0].Severity != gofeedx.SeverityWarning {
+		t.Errorf("unexpected future-date issue: %v", issues[0])
+	}
+	if issues[1].Path != "Items[3].Updated" || issues[1].Severity != gofeedx.SeverityError {
+		t.Errorf("unexpected ordering issue: %v", issues[1])
+	}
+	if !gofeedx.HasLintErrors(issues) {
+		t.Error("expected HasLintErrors to report the Updated-before-Created error")
+	}
+
+	issues = gofeedx.Lint(f, gofeedx.CheckDates(gofeedx.DateCheckOptions{Now: now}))
+	if len(issues) != 3 {
+		t.Fatalf("expected soon item to be reported without tolerance, got %v", issues)
+	}
+}
+
+func TestCheckURLs(t *testing.T) {
+	f := &gofeedx.Feed{
+		Title:   "URLs",
+		Link:    &gofeedx.Link{Href: "https://example.org/"},
+		FeedURL: "/feed.xml",
+		Image:   &gofeedx.Image{Url: "ftp://example.org/logo.png"},
+		Extensions: []gofeedx.ExtensionNode{
+			{Name: "podcast:funding", Attrs: map[string]string{"url": "https://example.org/fund"}, Text: "Support"},
+			{Name: "_json:icon", Text: "icon.png"},
+			{Name: "podcast:value", Children: []gofeedx.ExtensionNode{{Name: "atom:link", Attrs: map[string]string{"href": "https://"}}}},
+		},
+		Items: []*gofeedx.Item{{
+			Title:       "Ep",
+			Link:        &gofeedx.Link{Href: "https://example.org/ep"},
+			Enclosure:   &gofeedx.Enclosure{Url: "http://cdn.example.org/ep.mp3"},
+			CommentsURL: "http://%zz",
+			Extensions:  []gofeedx.ExtensionNode{{Name: "podcast:transcript", Attrs: map[string]string{"url": "mailto:x@example.org"}}},
+		}},
+	}
+	relBase, err := gofeedx.NewItem("Rel").WithID("rel").WithAtomBase("posts/").Build()
+	mustNoErr(t, err, "item Build failed")
+	badBase, err := gofeedx.NewItem("Bad").WithID("bad").WithAtomBase("ftp://example.org/").Build()
+	mustNoErr(t, err, "item Build failed")
+	f.Items = append(f.Items, relBase, badBase)
+	issues := gofeedx.Lint(f, gofeedx.CheckURLs())
+	got := map[string]bool{}
+	for _, i := range issues {
+		if i.Severity != gofeedx.SeverityError {
+			t.Errorf("expected error severity: %v", i)
+		}
+		got[i.Path] = true
+	}
+	want := []string{
+		"Feed.FeedURL",
+		"Feed.Image.Url",
+		"Feed.Extensions[_json:icon]",
+		"Feed.Extensions[podcast:value].Extensions[atom:link].href",
+		"Items[0].CommentsURL",
+		"Items[0].Extensions[podcast:transcript].url",
+		"Items[2].Extensions[_atom:base]",
+	}
+	for _, p := range want {
+		if !got[p] {
+			t.Errorf("missing issue for %s in %v", p, issues)
+		}
+	}
+	if len(issues) != len(want) {
+		t.Errorf("expected %d issues, got %v", len(want), issues)
+	}
+}
+
+func TestCheckItunes(t *testing.T) {
+	f := &gofeedx.Feed{
+		Title: strings.Repeat("T", 151),
+		Extensions: []gofeedx.ExtensionNode{
+			{Name: "itunes:keywords", Text: "a, b, c, d, e, f, g, h, i, j, k, l, m"},
+			{Name: "itunes:summary", Text: strings.Repeat("s", gofeedx.PSPDescriptionMaxBytes+1)},
+		},
+		Items: []*gofeedx.Item{
+			{Title: "Ep 12: Pilot"},
+			{Title: "Episode 3 - Return"},
+			{Title: "#42"},
+			{Title: "Epic stories", Extensions: []gofeedx.ExtensionNode{{Name: "itunes:keywords", Text: "x,y"}}},
+			{Title: "Top 10 episodes"},
+		},
+	}
+	issues := gofeedx.Lint(f, gofeedx.CheckItunes(gofeedx.ItunesCheckOptions{}))
+	paths := []string{}
+	for _, i := range issues {
+		if i.Severity != gofeedx.SeverityWarning {
+			t.Errorf("expected warnings only, got %v", i)
+		}
+		paths = append(paths, i.Path)
+	}
+	want := []string{
+		"Feed.Title",
+		"Feed.Extensions[itunes:keywords]",
+		"Feed.Extensions[itunes:summary]",
+		"Items[0].Title",
+		"Items[1].Title",
+		"Items[2].Title",
+	}
+	if strings.Join(paths, " ") != strings.Join(want, " ") {
+		t.Errorf("paths = %v, want %v", paths, want)
+	}
+
+	issues = gofeedx.Lint(f, gofeedx.CheckItunes(gofeedx.ItunesCheckOptions{MaxTitleLength: 200, MaxKeywords: 20}))
+	if len(issues) != 4 {
+		t.Errorf("expected only summary and numbering warnings with raised limits, got %v", issues)
+	}
+}
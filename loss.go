@@ -0,0 +1,343 @@
+package gofeedx
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+)
+
+// LossKind tells how a renderer treats a piece of feed data it cannot fully express.
+type LossKind string
+
+const (
+	// LossDropped means the value does not appear in the output at all.
+	LossDropped LossKind = "dropped"
+	// LossDowngraded means the value is emitted only partially (e.g. first category only).
+	LossDowngraded LossKind = "downgraded"
+	// LossUndeclared means an extension is emitted with a namespace prefix the output does
+	// not declare, so namespace-aware readers reject the document or ignore the element.
+	LossUndeclared LossKind = "undeclared"
+)
+
+// LossEntry describes one field or extension that a renderer drops or downgrades.
+type LossEntry struct {
+	Path   string // e.g. "Feed.ID", "Items[0].DurationSeconds", "Items[1].Extensions[_json:tag]"
+	Kind   LossKind
+	Reason string
+}
+
+func (e LossEntry) String() string {
+	return fmt.Sprintf("%s %s: %s", e.Path, e.Kind, e.Reason)
+}
+
+// markerPrefixesByProfile lists the internal marker prefixes each renderer consumes;
+// markers with any other internal prefix are ignored by that renderer.
+var markerPrefixesByProfile = map[Profile][]string{
+	ProfileRSS:  {"_rss:", "_xml:", "_websub:"},
+	ProfileAtom: {"_atom:", "_xml:", "_websub:"},
+	ProfilePSP:  {"_psp:", "_xml:", "_websub:"},
+	ProfileJSON: {"_json:", "_xml:", "_websub:"},
+}
+
+var profileNames = map[Profile]string{
+	ProfileRSS:  "RSS",
+	ProfileAtom: "Atom",
+	ProfilePSP:  "PSP",
+	ProfileJSON: "JSON Feed",
+}
+
+// LossReport lists the data in feed that the renderer for profile would drop or downgrade,
+// so callers can pick formats knowingly. It does not validate the feed; an empty report
+// means nothing set on feed is lost in that format.
+func LossReport(feed *Feed, profile Profile) []LossEntry {
+	if feed == nil {
+		return nil
+	}
+	r := &lossReporter{profile: profile, declared: declaredPrefixes(feed, profile)}
+	switch profile {
+	case ProfileRSS:
+		r.rssFeed(feed)
+	case ProfileAtom:
+		r.atomFeed(feed)
+	case ProfilePSP:
+		r.pspFeed(feed)
+	case ProfileJSON:
+		r.jsonFeed(feed)
+	default:
+		return nil
+	}
+	r.extensions("Feed", feed.allExtensions())
+	for i, it := range feed.Items {
+		if it == nil {
+			continue
+		}
+		path := fmt.Sprintf("Items[%d]", i)
+		switch profile {
+		case ProfileRSS:
+			r.rssItem(path, it)
+		case ProfileAtom:
+			r.atomItem(path, it)
+		case ProfilePSP:
+			r.pspItem(path, it)
+		case ProfileJSON:
+			r.jsonItem(path, it)
+		}
+		r.extensions(path, it.allExtensions())
+	}
+	return r.entries
+}
+
+type lossReporter struct {
+	profile  Profile
+	declared map[string]bool // namespace prefixes the XML output declares; nil for JSON Feed
+	entries  []LossEntry
+}
+
+// declaredPrefixes returns the namespace prefixes the writer for profile declares on the root
+// element when rendering feed, or nil for formats without XML namespaces.
+func declaredPrefixes(feed *Feed, profile Profile) map[string]bool {
+	declared := map[string]bool{"xml": true}
+	set := func(prefix, uri string) {
+		if uri != "" {
+			declared[prefix] = true
+		}
+	}
+	switch profile {
+	case ProfileRSS:
+		x := (&Rss{feed}).RssFeed().FeedXml().(*RssFeedXml)
+		set("content", x.ContentNamespace)
+		set("sy", x.SyNamespace)
+		set("georss", x.GeoRSSNamespace)
+		set("dc", x.DcNamespace)
+		set("atom", x.AtomNamespace)
+		set("thr", x.ThrNamespace)
+		set("slash", x.SlashNamespace)
+		set("wfw", x.WfwNamespace)
+		set("media", x.MediaNamespace)
+	case ProfileAtom:
+		x := (&Atom{feed}).AtomFeed()
+		set("georss", x.XmlnsGeoRSS)
+		set("thr", x.XmlnsThr)
+		set("media", x.XmlnsMedia)
+	case ProfilePSP:
+		x := (&PSP{feed}).FeedXml().(*PSPRSSRoot)
+		set("itunes", x.NSItunes)
+		set("podcast", x.NSPodcast)
+		set("atom", x.NSAtom)
+		set("content", x.NSContent)
+	default:
+		return nil
+	}
+	return declared
+}
+
+// undeclaredPrefix returns the first namespace prefix in n or its children that is neither
+// declared by the output nor on the node or an ancestor (xmlns:prefix attributes), or "".
+func (r *lossReporter) undeclaredPrefix(n ExtensionNode, inScope map[string]bool) string {
+	var own []string
+	for k := range n.Attrs {
+		if p, ok := strings.CutPrefix(k, "xmlns:"); ok {
+			own = append(own, p)
+		}
+	}
+	if len(own) > 0 {
+		scope := make(map[string]bool, len(inScope)+len(own))
+		maps.Copy(scope, inScope)
+		for _, p := range own {
+			scope[p] = true
+		}
+		inScope = scope
+	}
+	if p, _, ok := strings.Cut(strings.TrimSpace(n.Name), ":"); ok && !r.declared[p] && !inScope[p] {
+		return p
+	}
+	for _, c := range n.Children {
+		if p := r.undeclaredPrefix(c, inScope); p != "" {
+			return p
+		}
+	}
+	return ""
+}
+
+func (r *lossReporter) add(cond bool, path string, kind LossKind, reason string) {
+	if cond {
+		r.entries = append(r.entries, LossEntry{Path: path, Kind: kind, Reason: reason})
+	}
+}
+
+func (r *lossReporter) unsupported(cond bool, path string) {
+	r.add(cond, path, LossDropped, "not supported by "+profileNames[r.profile])
+}
+
+func (r *lossReporter) extraCategories(cats []*Category) {
+	n := 0
+	for _, c := range cats {
+		if c != nil && strings.TrimSpace(c.Text) != "" {
+			n++
+		}
+	}
+	r.add(n > 1, "Feed.Categories", LossDowngraded, "only the first category is emitted")
+}
+
+func authorEmail(a *Author) string {
+	if a == nil {
+		return ""
+	}
+	return strings.TrimSpace(a.Email)
+}
+
+func authorName(a *Author) string {
+	if a == nil {
+		return ""
+	}
+	return strings.TrimSpace(a.Name)
+}
+
+func (r *lossReporter) rssFeed(f *Feed) {
+	r.unsupported(strings.TrimSpace(f.ID) != "", "Feed.ID")
+	r.unsupported(strings.TrimSpace(f.FeedURL) != "" && len(hubURLs(f.allExtensions())) == 0, "Feed.FeedURL")
+	r.add(authorName(f.Author) != "" && authorEmail(f.Author) == "", "Feed.Author", LossDropped, "managingEditor requires an email address")
+	r.extraCategories(f.Categories)
+}
+
+func (r *lossReporter) rssItem(path string, it *Item) {
+	r.unsupported(it.DurationSeconds > 0, path+".DurationSeconds")
+	if sf := it.SourceFeed; sf != nil {
+		r.add(strings.TrimSpace(sf.FeedURL) == "" && (it.Source == nil || strings.TrimSpace(it.Source.Href) == ""),
+			path+".SourceFeed", LossDropped, "source requires a feed URL")
+		r.add(strings.TrimSpace(sf.ID) != "" || strings.TrimSpace(sf.Link) != "" || !sf.Updated.IsZero(),
+			path+".SourceFeed", LossDowngraded, "source carries only the title and feed URL")
+	}
+	r.add(authorName(it.Author) != "" && authorEmail(it.Author) == "", path+".Author", LossDropped, "author requires an email address")
+	if e := it.Enclosure; e != nil && (strings.TrimSpace(e.Url) == "" || strings.TrimSpace(e.Type) == "" || e.Length <= 0) {
+		r.add(true, path+".Enclosure", LossDropped, "enclosure requires url, type and length")
+	}
+}
+
+func (r *lossReporter) atomFeed(f *Feed) {
+	r.unsupported(strings.TrimSpace(f.FeedURL) != "" && len(hubURLs(f.allExtensions())) == 0, "Feed.FeedURL")
+}
+
+// comments reports the item's comment fields for formats without a comments element.
+func (r *lossReporter) comments(path string, it *Item) {
+	r.unsupported(strings.TrimSpace(it.CommentsURL) != "", path+".CommentsURL")
+	r.unsupported(strings.TrimSpace(it.CommentsFeedURL) != "", path+".CommentsFeedURL")
+	r.unsupported(it.CommentCount != nil, path+".CommentCount")
+}
+
+func (r *lossReporter) atomItem(path string, it *Item) {
+	r.add(it.CommentCount != nil && strings.TrimSpace(it.CommentsURL) == "" && strings.TrimSpace(it.CommentsFeedURL) == "",
+		path+".CommentCount", LossDropped, "thr:count needs a comments URL or comments feed URL")
+	r.unsupported(it.DurationSeconds > 0, path+".DurationSeconds")
+	r.unsupported(strings.TrimSpace(it.IsPermaLink) != "", path+".IsPermaLink")
+	r.add(it.Enclosure != nil && it.Enclosure.Length > 0, path+".Enclosure.Length", LossDropped, "enclosure links are emitted without length")
+}
+
+func (r *lossReporter) pspFeed(f *Feed) {
+	r.add(authorEmail(f.Author) != "", "Feed.Author.Email", LossDropped, "itunes:author carries the name only")
+}
+
+func (r *lossReporter) pspItem(path string, it *Item) {
+	r.unsupported(it.Source != nil && strings.TrimSpace(it.Source.Href) != "", path+".Source")
+	r.unsupported(it.Author != nil && (authorName(it.Author) != "" || authorEmail(it.Author) != ""), path+".Author")
+	r.unsupported(strings.TrimSpace(it.Language) != "", path+".Language")
+	r.unsupported(it.SourceFeed != nil, path+".SourceFeed")
+	r.comments(path, it)
+}
+
+func (r *lossReporter) jsonFeed(f *Feed) {
+	r.unsupported(strings.TrimSpace(f.ID) != "", "Feed.ID")
+	r.unsupported(!f.Created.IsZero(), "Feed.Created")
+	r.unsupported(!f.Updated.IsZero(), "Feed.Updated")
+	r.unsupported(strings.TrimSpace(f.Copyright) != "", "Feed.Copyright")
+	r.unsupported(len(f.Categories) > 0, "Feed.Categories")
+	r.add(authorEmail(f.Author) != "", "Feed.Author.Email", LossDropped, "JSON Feed authors carry name only")
+}
+
+func (r *lossReporter) jsonItem(path string, it *Item) {
+	r.unsupported(strings.TrimSpace(it.IsPermaLink) != "", path+".IsPermaLink")
+	r.unsupported(it.SourceFeed != nil, path+".SourceFeed")
+	r.comments(path, it)
+	r.add(authorEmail(it.Author) != "", path+".Author.Email", LossDropped, "JSON Feed authors carry name only")
+}
+
+// extensions reports internal markers meant for other formats, namespace prefixes the XML
+// output does not declare and, for JSON Feed, XML structure that flattening cannot keep.
+func (r *lossReporter) extensions(scope string, exts []ExtensionNode) {
+	for _, n := range exts {
+		name := strings.TrimSpace(n.Name)
+		if name == "" {
+			continue
+		}
+		path := fmt.Sprintf("%s.Extensions[%s]", scope, name)
+		if IsInternalExtensionName(name) {
+			r.add(!r.consumesMarker(name), path, LossDropped, "marker for another format")
+			continue
+		}
+		if r.declared != nil {
+			if p := r.undeclaredPrefix(n, nil); p != "" {
+				r.add(true, path, LossUndeclared, fmt.Sprintf("%s does not declare the %q namespace prefix", profileNames[r.profile], p))
+			}
+		}
+		if r.profile != ProfileJSON || jsonMapsExtension(name) {
+			continue
+		}
+		switch {
+		case strings.TrimSpace(n.Text) == "":
+			r.add(true, path, LossDropped, "JSON Feed flattening keeps text only and this node has none")
+		case len(n.Attrs) > 0 || len(n.Children) > 0:
+			r.add(true, path, LossDowngraded, "JSON Feed flattening drops attributes and children")
+		}
+	}
+}
+
+func (r *lossReporter) consumesMarker(name string) bool {
+	s := strings.ToLower(name)
+	for _, p := range markerPrefixesByProfile[r.profile] {
+		if strings.HasPrefix(s, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonMapsExtension reports whether the JSON writer maps a non-internal node to a typed field.
+func jsonMapsExtension(name string) bool {
+	return strings.EqualFold(name, "georss:point")
+}
+
+// unsupportedFields is the capability matrix: the generic Feed and Item fields each renderer
+// never emits. Fields that are only partly emitted (e.g. RSS keeps the first of
+// Feed.Categories) are left to LossReport.
+var unsupportedFields = map[Profile][]string{
+	ProfileRSS:  {"Feed.ID", "Feed.FeedURL", "Item.DurationSeconds"},
+	ProfileAtom: {"Feed.FeedURL", "Item.DurationSeconds", "Item.IsPermaLink"},
+	ProfilePSP: {"Item.Source", "Item.Author", "Item.Language", "Item.SourceFeed",
+		"Item.CommentsURL", "Item.CommentsFeedURL", "Item.CommentCount"},
+	ProfileJSON: {"Feed.ID", "Feed.Created", "Feed.Updated", "Feed.Copyright", "Feed.Categories",
+		"Item.IsPermaLink", "Item.SourceFeed", "Item.CommentsURL", "Item.CommentsFeedURL", "Item.CommentCount"},
+}
+
+// UnsupportedFields returns the generic fields the renderer for profile never emits, as
+// "Feed.<Field>" or "Item.<Field>". RSS and Atom still use Feed.FeedURL for WebSub hub links.
+func UnsupportedFields(profile Profile) []string {
+	return append([]string(nil), unsupportedFields[profile]...)
+}
+
+// CheckCapabilities reports, as warnings, the data in the feed that the renderers for
+// profiles would silently drop or downgrade (see LossReport). The message names the format.
+func CheckCapabilities(profiles ...Profile) Check {
+	return func(feed *Feed) []Issue {
+		var out []Issue
+		for _, p := range profiles {
+			for _, e := range LossReport(feed, p) {
+				out = append(out, Issue{
+					Path:     e.Path,
+					Severity: SeverityWarning,
+					Message:  fmt.Sprintf("%s in %s: %s", e.Kind, profileNames[p], e.Reason),
+				})
+			}
+		}
+		return out
+	}
+}
@@ -0,0 +1,169 @@
+package gofeedx_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func lossPaths(entries []gofeedx.LossEntry) map[string]gofeedx.LossKind {
+	out := map[string]gofeedx.LossKind{}
+	for _, e := range entries {
+		out[e.Path] = e.Kind
+	}
+	return out
+}
+
+func newLossFeed() *gofeedx.Feed {
+	now := time.Now().UTC()
+	return &gofeedx.Feed{
+		Title:       "Loss",
+		Link:        &gofeedx.Link{Href: "https://example.org/"},
+		Description: "D",
+		ID:          "urn:uuid:1",
+		Created:     now,
+		Language:    "en",
+		Copyright:   "(c) Example",
+		Categories:  []*gofeedx.Category{{Text: "Tech"}, {Text: "News"}},
+		Extensions: []gofeedx.ExtensionNode{
+			{Name: "_json:hub", Attrs: map[string]string{"type": "WebSub", "url": "https://hub.example/"}},
+			{Name: "podcast:funding", Attrs: map[string]string{"url": "https://example.org/fund"}, Text: "Support"},
+		},
+		Items: []*gofeedx.Item{{
+			Title:           "Episode",
+			ID:              "e1",
+			IsPermaLink:     "false",
+			Created:         now,
+			DurationSeconds: 90,
+			Enclosure:       &gofeedx.Enclosure{Url: "https://example.org/e1.mp3", Type: "audio/mpeg", Length: 1234},
+			Extensions: []gofeedx.ExtensionNode{
+				{Name: "podcast:transcript", Attrs: map[string]string{"url": "https://example.org/t.vtt", "type": "text/vtt"}},
+				{Name: "_rss:comments", Text: "https://example.org/c"},
+			},
+		}},
+	}
+}
+
+func TestLossReport_PerProfile(t *testing.T) {
+	f := newLossFeed()
+
+	rss := lossPaths(gofeedx.LossReport(f, gofeedx.ProfileRSS))
+	for path, kind := range map[string]gofeedx.LossKind{
+		"Feed.ID":                    gofeedx.LossDropped,
+		"Feed.Categories":            gofeedx.LossDowngraded,
+		"Feed.Extensions[_json:hub]": gofeedx.LossDropped,
+		"Items[0].DurationSeconds":   gofeedx.LossDropped,
+	} {
+		if rss[path] != kind {
+			t.Errorf("RSS: expected %s %s, report=%v", path, kind, rss)
+		}
+	}
+	if _, ok := rss["Items[0].Extensions[_rss:comments]"]; ok {
+		t.Errorf("RSS consumes _rss:comments and must not report it")
+	}
+
+	atom := lossPaths(gofeedx.LossReport(f, gofeedx.ProfileAtom))
+	for _, path := range []string{"Feed.Extensions[_json:hub]", "Items[0].Enclosure.Length", "Items[0].IsPermaLink", "Items[0].Extensions[_rss:comments]"} {
+		if _, ok := atom[path]; !ok {
+			t.Errorf("Atom: expected %s in report %v", path, atom)
+		}
+	}
+
+	js := lossPaths(gofeedx.LossReport(f, gofeedx.ProfileJSON))
+	for path, kind := range map[string]gofeedx.LossKind{
+		"Feed.ID":                                 gofeedx.LossDropped,
+		"Feed.Copyright":                          gofeedx.LossDropped,
+		"Feed.Extensions[podcast:funding]":        gofeedx.LossDowngraded,
+		"Items[0].Extensions[podcast:transcript]": gofeedx.LossDropped,
+		"Items[0].IsPermaLink":                    gofeedx.LossDropped,
+	} {
+		if js[path] != kind {
+			t.Errorf("JSON: expected %s %s, report=%v", path, kind, js)
+		}
+	}
+	if _, ok := js["Feed.Extensions[_json:hub]"]; ok {
+		t.Errorf("JSON consumes _json:hub and must not report it")
+	}
+}
+
+func TestLossReport_EmptyWhenNothingLost(t *testing.T) {
+	f := newRSSBaseFeed()
+	f.Items = append(f.Items, newRSSBaseItem())
+	if got := gofeedx.LossReport(f, gofeedx.ProfileRSS); len(got) != 0 {
+		t.Errorf("expected no losses for plain RSS feed, got %v", got)
+	}
+	if got := gofeedx.LossReport(nil, gofeedx.ProfileRSS); got != nil {
+		t.Errorf("expected nil report for nil feed")
+	}
+}
+
+func TestCheckCapabilities(t *testing.T) {
+	f := newLossFeed()
+	issues := gofeedx.Lint(f, gofeedx.CheckCapabilities(gofeedx.ProfileAtom, gofeedx.ProfileRSS))
+	found := map[string]string{}
+	for _, i := range issues {
+		if i.Severity != gofeedx.SeverityWarning {
+			t.Errorf("expected warnings only, got %v", i)
+		}
+		found[i.Path+" "+i.Message] = ""
+	}
+	for _, want := range []string{
+		"Items[0].DurationSeconds dropped in Atom: not supported by Atom",
+		"Items[0].DurationSeconds dropped in RSS: not supported by RSS",
+		"Feed.Categories downgraded in RSS: only the first category is emitted",
+	} {
+		if _, ok := found[want]; !ok {
+			t.Errorf("expected %q in %v", want, issues)
+		}
+	}
+}
+
+func TestUnsupportedFields_MatchLossReport(t *testing.T) {
+	f := newLossFeed()
+	for _, p := range []gofeedx.Profile{gofeedx.ProfileRSS, gofeedx.ProfileAtom, gofeedx.ProfilePSP, gofeedx.ProfileJSON} {
+		matrix := map[string]bool{}
+		for _, field := range gofeedx.UnsupportedFields(p) {
+			matrix[field] = true
+		}
+		for _, e := range gofeedx.LossReport(f, p) {
+			if !strings.HasPrefix(e.Reason, "not supported") {
+				continue
+			}
+			field := strings.Replace(e.Path, "Items[0].", "Item.", 1)
+			if !matrix[field] {
+				t.Errorf("%v: %s reported unsupported but missing from UnsupportedFields", p, field)
+			}
+		}
+	}
+	if !slices.Contains(gofeedx.UnsupportedFields(gofeedx.ProfileAtom), "Item.DurationSeconds") {
+		t.Errorf("Atom should list Item.DurationSeconds")
+	}
+}
+
+func TestLossReport_UndeclaredNamespacePrefix(t *testing.T) {
+	f := newRSSBaseFeed()
+	it := newRSSBaseItem()
+	it.Extensions = []gofeedx.ExtensionNode{
+		{Name: "podcast:transcript", Attrs: map[string]string{"url": "https://example.org/t.vtt", "type": "text/vtt"}},
+		{Name: "x:rating", Attrs: map[string]string{"xmlns:x": "https://example.org/ns"}, Text: "5"},
+		{Name: "georss:point", Text: "45.256 -71.92"},
+	}
+	f.Items = append(f.Items, it)
+
+	rss := lossPaths(gofeedx.LossReport(f, gofeedx.ProfileRSS))
+	if rss["Items[0].Extensions[podcast:transcript]"] != gofeedx.LossUndeclared {
+		t.Errorf("RSS: expected podcast:transcript to be reported as undeclared, report=%v", rss)
+	}
+	for _, path := range []string{"Items[0].Extensions[x:rating]", "Items[0].Extensions[georss:point]"} {
+		if _, ok := rss[path]; ok {
+			t.Errorf("RSS: %s is declared and must not be reported", path)
+		}
+	}
+	psp := lossPaths(gofeedx.LossReport(f, gofeedx.ProfilePSP))
+	if _, ok := psp["Items[0].Extensions[podcast:transcript]"]; ok {
+		t.Errorf("PSP declares the podcast namespace and must not report podcast:transcript")
+	}
+}
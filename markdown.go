@@ -0,0 +1,198 @@
+package gofeedx
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// WithContentMarkdown renders md to HTML for the item content (content:encoded, Atom content,
+// JSON content_html) and keeps md itself as JSON content_text. See MarkdownToHTML for the
+// supported syntax.
+func (b *ItemBuilder) WithContentMarkdown(md string) *ItemBuilder {
+	if strings.TrimSpace(md) == "" {
+		return b
+	}
+	b.item.Content = MarkdownToHTML(md)
+	return b.WithJSONContentText(md)
+}
+
+var (
+	mdHeadingPattern  = regexp.MustCompile(`^(#{1,6})[ \t]+(.*?)[ \t#]*$`)
+	mdBulletPattern   = regexp.MustCompile(`^[ \t]{0,3}[-*+][ \t]+(.*)$`)
+	mdOrderedPattern  = regexp.MustCompile(`^[ \t]{0,3}(\d{1,9})[.)][ \t]+(.*)$`)
+	mdQuotePattern    = regexp.MustCompile(`^[ \t]{0,3}>[ \t]?(.*)$`)
+	mdFencePattern    = regexp.MustCompile("^[ \\t]{0,3}(```|~~~)[ \\t]*([^`\\s]*)")
+	mdCodeSpanPattern = regexp.MustCompile("`+([^`]|[^`][\\s\\S]*?[^`])`+")
+	mdImagePattern    = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)(?:\s+&#34;([^)]*?)&#34;)?\)`)
+	mdLinkPattern     = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)(?:\s+&#34;([^)]*?)&#34;)?\)`)
+	mdAutoLinkPattern = regexp.MustCompile(`&lt;(https?://[^\s&]+)&gt;`)
+	mdStrongPattern   = regexp.MustCompile(`\*\*(\S(?:.*?\S)?)\*\*|__(\S(?:.*?\S)?)__`)
+	mdEmPattern       = regexp.MustCompile(`\*(\S(?:.*?\S)?)\*|(?:^|\b)_(\S(?:.*?\S)?)_(?:\b|$)`)
+	mdUnsafeURL       = regexp.MustCompile(`(?i)^\s*(javascript|vbscript|data):`)
+)
+
+// MarkdownToHTML renders a commonly used Markdown subset to HTML: ATX headings, paragraphs,
+// hard line breaks (two trailing spaces), blockquotes, flat bullet and ordered lists, fenced
+// code blocks, horizontal rules, code spans, emphasis, links, autolinks and images. Raw HTML
+// in the input is escaped, and links with javascript:, vbscript: or data: URLs render as text.
+func MarkdownToHTML(md string) string {
+	lines := strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n")
+	return strings.TrimSuffix(renderMarkdownBlocks(lines), "\n")
+}
+
+func renderMarkdownBlocks(lines []string) string {
+	var sb strings.Builder
+	var para []string
+	flush := func() {
+		if len(para) > 0 {
+			sb.WriteString("<p>" + renderMarkdownLines(para) + "</p>\n")
+			para = nil
+		}
+	}
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "":
+			flush()
+		case mdFencePattern.MatchString(line):
+			flush()
+			m := mdFencePattern.FindStringSubmatch(line)
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), m[1]); i++ {
+				code = append(code, lines[i])
+			}
+			class := ""
+			if m[2] != "" {
+				class = ` class="language-` + html.EscapeString(m[2]) + `"`
+			}
+			sb.WriteString("<pre><code" + class + ">" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+		case mdHeadingPattern.MatchString(line):
+			flush()
+			m := mdHeadingPattern.FindStringSubmatch(line)
+			n := strconv.Itoa(len(m[1]))
+			sb.WriteString("<h" + n + ">" + renderMarkdownInline(m[2]) + "</h" + n + ">\n")
+		case isMarkdownRule(line):
+			flush()
+			sb.WriteString("<hr>\n")
+		case mdQuotePattern.MatchString(line):
+			flush()
+			var quoted []string
+			for ; i < len(lines) && mdQuotePattern.MatchString(lines[i]); i++ {
+				quoted = append(quoted, mdQuotePattern.FindStringSubmatch(lines[i])[1])
+			}
+			i--
+			sb.WriteString("<blockquote>\n" + renderMarkdownBlocks(quoted) + "</blockquote>\n")
+		case mdBulletPattern.MatchString(line), mdOrderedPattern.MatchString(line):
+			flush()
+			i = renderMarkdownList(&sb, lines, i) - 1
+		default:
+			para = append(para, line)
+		}
+	}
+	flush()
+	return sb.String()
+}
+
+// renderMarkdownList writes the list starting at lines[start] and returns the index after it.
+// Indented lines continue the previous item.
+func renderMarkdownList(sb *strings.Builder, lines []string, start int) int {
+	ordered := mdOrderedPattern.MatchString(lines[start])
+	tag, open := "ul", "<ul>"
+	if ordered {
+		tag, open = "ol", "<ol>"
+		if n := mdOrderedPattern.FindStringSubmatch(lines[start])[1]; strings.TrimLeft(n, "0") != "1" {
+			open = fmt.Sprintf(`<ol start="%s">`, strings.TrimLeft(n, "0"))
+		}
+	}
+	var items [][]string
+	i := start
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if m := mdBulletPattern.FindStringSubmatch(line); m != nil && !ordered {
+			items = append(items, []string{m[1]})
+		} else if m := mdOrderedPattern.FindStringSubmatch(line); m != nil && ordered {
+			items = append(items, []string{m[2]})
+		} else if strings.TrimSpace(line) != "" && (line[0] == ' ' || line[0] == '\t') {
+			items[len(items)-1] = append(items[len(items)-1], line)
+		} else {
+			break
+		}
+	}
+	sb.WriteString(open + "\n")
+	for _, it := range items {
+		sb.WriteString("<li>" + renderMarkdownLines(it) + "</li>\n")
+	}
+	sb.WriteString("</" + tag + ">\n")
+	return i
+}
+
+func isMarkdownRule(line string) bool {
+	s := strings.Join(strings.Fields(line), "")
+	if len(s) < 3 || !strings.ContainsRune("-*_", rune(s[0])) {
+		return false
+	}
+	return strings.Count(s, s[:1]) == len(s)
+}
+
+// renderMarkdownLines renders the lines of one block, turning two trailing spaces into <br>.
+func renderMarkdownLines(lines []string) string {
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		br := i < len(lines)-1 && strings.HasSuffix(l, "  ")
+		out[i] = renderMarkdownInline(strings.TrimSpace(l))
+		if br {
+			out[i] += "<br>"
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+func renderMarkdownInline(s string) string {
+	// Code spans are rendered first and kept out of the other rules via placeholders
+	var spans []string
+	s = mdCodeSpanPattern.ReplaceAllStringFunc(s, func(m string) string {
+		code := strings.TrimSpace(mdCodeSpanPattern.FindStringSubmatch(m)[1])
+		spans = append(spans, "<code>"+html.EscapeString(code)+"</code>")
+		return fmt.Sprintf("\x00%d\x00", len(spans)-1)
+	})
+	s = html.EscapeString(s)
+	s = mdImagePattern.ReplaceAllStringFunc(s, func(m string) string {
+		g := mdImagePattern.FindStringSubmatch(m)
+		if mdUnsafeURL.MatchString(html.UnescapeString(g[2])) {
+			return g[1]
+		}
+		return `<img src="` + g[2] + `" alt="` + g[1] + `"` + mdTitleAttr(g[3]) + `>`
+	})
+	s = mdLinkPattern.ReplaceAllStringFunc(s, func(m string) string {
+		g := mdLinkPattern.FindStringSubmatch(m)
+		if mdUnsafeURL.MatchString(html.UnescapeString(g[2])) {
+			return g[1]
+		}
+		return `<a href="` + g[2] + `"` + mdTitleAttr(g[3]) + `>` + g[1] + `</a>`
+	})
+	s = mdAutoLinkPattern.ReplaceAllString(s, `<a href="$1">$1</a>`)
+	s = mdStrongPattern.ReplaceAllString(s, "<strong>$1$2</strong>")
+	s = mdEmPattern.ReplaceAllStringFunc(s, func(m string) string {
+		g := mdEmPattern.FindStringSubmatch(m)
+		if g[1] != "" {
+			return "<em>" + g[1] + "</em>"
+		}
+		// keep the word boundary characters matched around _emphasis_
+		pre, post := m[:strings.Index(m, "_")], m[strings.LastIndex(m, "_")+1:]
+		return pre + "<em>" + g[2] + "</em>" + post
+	})
+	for i, span := range spans {
+		s = strings.Replace(s, fmt.Sprintf("\x00%d\x00", i), span, 1)
+	}
+	return s
+}
+
+func mdTitleAttr(title string) string {
+	if title == "" {
+		return ""
+	}
+	return ` title="` + title + `"`
+}
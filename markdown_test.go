@@ -0,0 +1,51 @@
+package gofeedx_test
+
+import (
+	"testing"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestMarkdownToHTML(t *testing.T) {
+	cases := map[string]string{
+		"# Title":                                "<h1>Title</h1>",
+		"### Small ###":                          "<h3>Small</h3>",
+		"Hello **bold** and *em* and _u_":        "<p>Hello <strong>bold</strong> and <em>em</em> and <em>u</em></p>",
+		"snake_case_name stays":                  "<p>snake_case_name stays</p>",
+		"a `x < y` b":                            "<p>a <code>x &lt; y</code> b</p>",
+		"See [site](https://e.x/?a=1&b=2 \"T\")": `<p>See <a href="https://e.x/?a=1&amp;b=2" title="T">site</a></p>`,
+		"![logo](https://e.x/l.png)":             `<p><img src="https://e.x/l.png" alt="logo"></p>`,
+		"[x](javascript:void)":                   "<p>x</p>",
+		"<https://e.x/>":                         `<p><a href="https://e.x/">https://e.x/</a></p>`,
+		"<script>x</script>":                     "<p>&lt;script&gt;x&lt;/script&gt;</p>",
+		"line one  \nline two":                   "<p>line one<br>\nline two</p>",
+		"---":                                    "<hr>",
+		"- a\n- b\n  more":                       "<ul>\n<li>a</li>\n<li>b\nmore</li>\n</ul>",
+		"3. c\n4. d":                             "<ol start=\"3\">\n<li>c</li>\n<li>d</li>\n</ol>",
+		"> quoted\n> text":                       "<blockquote>\n<p>quoted\ntext</p>\n</blockquote>",
+		"```go\nif a < b {}\n```":                "<pre><code class=\"language-go\">if a &lt; b {}</code></pre>",
+		"p1\n\np2":                               "<p>p1</p>\n<p>p2</p>",
+	}
+	for in, want := range cases {
+		if got := gofeedx.MarkdownToHTML(in); got != want {
+			t.Errorf("MarkdownToHTML(%q)\n got %q\nwant %q", in, got, want)
+		}
+	}
+}
+
+func TestItemWithContentMarkdown(t *testing.T) {
+	md := "Hello *world*"
+	b := gofeedx.NewFeed("T").WithLink("https://example.org/")
+	b.AddItem(gofeedx.NewItem("md").WithID("1").WithContentMarkdown(md))
+	f, err := b.Build()
+	mustNoErr(t, err, "Build failed")
+	if f.Items[0].Content != "<p>Hello <em>world</em></p>" {
+		t.Fatalf("unexpected content: %q", f.Items[0].Content)
+	}
+	out, err := gofeedx.ToJSON(f)
+	mustNoErr(t, err, "ToJSON failed")
+	mustContain(t, out, `"content_text": "Hello *world*"`, "expected markdown source as content_text")
+	rss, err := gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS failed")
+	mustContain(t, rss, "<p>Hello <em>world</em></p>", "expected rendered HTML in content:encoded")
+}
@@ -0,0 +1,20 @@
+package gofeedx
+
+import "encoding/xml"
+
+// xmlnsMedia is the Media RSS namespace used for per-item media:thumbnail.
+const xmlnsMedia = "http://search.yahoo.com/mrss/"
+
+// MediaThumbnail emits <media:thumbnail url="..."/> for Item.ImageURL in RSS and Atom.
+type MediaThumbnail struct {
+	XMLName xml.Name `xml:"media:thumbnail"`
+	Url     string   `xml:"url,attr"`
+}
+
+// newMediaThumbnail returns the thumbnail for url, or nil when url is empty.
+func newMediaThumbnail(url string) *MediaThumbnail {
+	if url == "" {
+		return nil
+	}
+	return &MediaThumbnail{Url: url}
+}
@@ -0,0 +1,191 @@
+// Package mediascan turns a directory of audio files into podcast items: it reads ID3 (MP3)
+// and iTunes-style MP4 (M4A) tags for title, comment, duration and embedded artwork, and
+// builds ItemBuilders whose enclosures carry the public URL, file size and MIME type.
+package mediascan
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+// mediaTypes maps the supported file extensions to enclosure MIME types.
+var mediaTypes = map[string]string{
+	".mp3": "audio/mpeg",
+	".m4a": "audio/x-m4a",
+}
+
+// File describes one audio file and the metadata read from its tags.
+type File struct {
+	Path     string // slash-separated path relative to the scanned directory
+	Size     int64  // bytes, used as the enclosure length
+	ModTime  time.Time
+	MIMEType string // enclosure type derived from the extension
+
+	Title           string // empty when the file has no title tag
+	Artist          string
+	Album           string
+	Comment         string // comment or description tag
+	DurationSeconds int    // from the tags or, for MP3, the audio stream; 0 when unknown
+
+	Artwork     []byte // embedded cover image, if any
+	ArtworkType string // MIME type of Artwork, e.g. "image/jpeg"
+}
+
+// Options controls how files become items.
+type Options struct {
+	// BaseURL is the absolute http(s) URL the scanned directory is published under; the
+	// escaped File.Path is appended to it to form enclosure URLs.
+	BaseURL string
+	// ArtworkURL, when set, returns the public URL of f.Artwork (e.g. after uploading it). It
+	// is only called for files with embedded artwork; an empty result sets no item image.
+	ArtworkURL func(f *File) string
+}
+
+// Scan walks dir recursively in lexical order and reads every .mp3 and .m4a file. Hidden
+// files and directories (names starting with ".") are skipped.
+func Scan(dir string) ([]*File, error) {
+	var files []*File
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p != dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || mediaTypes[strings.ToLower(filepath.Ext(p))] == "" {
+			return nil
+		}
+		f, err := ReadFile(p)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		f.Path = filepath.ToSlash(rel)
+		files = append(files, f)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("mediascan: %w", err)
+	}
+	return files, nil
+}
+
+// ReadFile reads the tags of the .mp3 or .m4a file at p. File.Path is p in slash form.
+func ReadFile(p string) (*File, error) {
+	mime := mediaTypes[strings.ToLower(filepath.Ext(p))]
+	if mime == "" {
+		return nil, fmt.Errorf("mediascan: %s: unsupported file type", p)
+	}
+	fh, err := os.Open(p)
+	if err != nil {
+		return nil, fmt.Errorf("mediascan: %w", err)
+	}
+	defer fh.Close()
+	info, err := fh.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("mediascan: %w", err)
+	}
+	f := &File{Path: filepath.ToSlash(p), Size: info.Size(), ModTime: info.ModTime(), MIMEType: mime}
+	if mime == "audio/mpeg" {
+		err = readMP3(fh, f)
+	} else {
+		err = readMP4(fh, f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("mediascan: %s: %w", p, err)
+	}
+	return f, nil
+}
+
+// ItemBuilders scans dir (see Scan) and returns an ItemBuilder per file (see File.ItemBuilder).
+func ItemBuilders(dir string, opts Options) ([]*gofeedx.ItemBuilder, error) {
+	if err := checkBaseURL(opts.BaseURL); err != nil {
+		return nil, err
+	}
+	files, err := Scan(dir)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*gofeedx.ItemBuilder, 0, len(files))
+	for _, f := range files {
+		out = append(out, f.ItemBuilder(opts))
+	}
+	return out, nil
+}
+
+// ItemBuilder returns an item for f: the title tag (or the file name without extension), the
+// enclosure URL (also used as the ID), size and type, the duration, the comment as
+// description, the artist as itunes:author (a display name, not the email address RSS
+// author requires), the modification time as publication date and, with
+// Options.ArtworkURL, the artwork as item image. Callers can refine the builder further.
+func (f *File) ItemBuilder(opts Options) *gofeedx.ItemBuilder {
+	title := f.Title
+	if strings.TrimSpace(title) == "" {
+		base := path.Base(f.Path)
+		title = strings.TrimSuffix(base, path.Ext(base))
+	}
+	link := enclosureURL(opts.BaseURL, f.Path)
+	ib := gofeedx.NewItem(title).
+		WithID(link).
+		WithCreated(f.ModTime).
+		WithEnclosure(link, f.Size, f.MIMEType)
+	if f.DurationSeconds > 0 {
+		ib.WithDurationSeconds(f.DurationSeconds)
+	}
+	if strings.TrimSpace(f.Comment) != "" {
+		ib.WithDescription(f.Comment)
+	}
+	if strings.TrimSpace(f.Artist) != "" {
+		ib.WithPSPAuthor(f.Artist)
+	}
+	if opts.ArtworkURL != nil && len(f.Artwork) > 0 {
+		if u := opts.ArtworkURL(f); u != "" {
+			ib.WithImageURL(u)
+		}
+	}
+	return ib
+}
+
+func checkBaseURL(base string) error {
+	u, err := url.Parse(strings.TrimSpace(base))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("mediascan: BaseURL must be an absolute http(s) URL")
+	}
+	return nil
+}
+
+// enclosureURL appends the escaped segments of the slash path rel to base.
+func enclosureURL(base, rel string) string {
+	segs := strings.Split(rel, "/")
+	for i, s := range segs {
+		segs[i] = url.PathEscape(s)
+	}
+	return strings.TrimRight(strings.TrimSpace(base), "/") + "/" + strings.Join(segs, "/")
+}
+
+// imageType sniffs the MIME type of embedded artwork.
+func imageType(b []byte) string {
+	switch {
+	case len(b) >= 3 && b[0] == 0xFF && b[1] == 0xD8 && b[2] == 0xFF:
+		return "image/jpeg"
+	case len(b) >= 8 && string(b[:8]) == "\x89PNG\r\n\x1a\n":
+		return "image/png"
+	default:
+		return ""
+	}
+}
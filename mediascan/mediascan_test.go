@@ -0,0 +1,176 @@
+package mediascan_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+	"github.com/jo-hoe/gofeedx/mediascan"
+)
+
+var png = []byte("\x89PNG\r\n\x1a\nfake")
+
+// id3Frame returns an ID3v2.3 frame.
+func id3Frame(id string, data []byte) []byte {
+	var b bytes.Buffer
+	b.WriteString(id)
+	binary.Write(&b, binary.BigEndian, uint32(len(data)))
+	b.Write([]byte{0, 0})
+	b.Write(data)
+	return b.Bytes()
+}
+
+// id3Tag returns an ID3v2.3 tag holding frames.
+func id3Tag(frames ...[]byte) []byte {
+	body := bytes.Join(frames, nil)
+	n := len(body)
+	return append([]byte{'I', 'D', '3', 3, 0, 0, byte(n >> 21 & 0x7F), byte(n >> 14 & 0x7F), byte(n >> 7 & 0x7F), byte(n & 0x7F)}, body...)
+}
+
+// mpegFrame returns an MPEG-1 Layer III 128 kbit/s 44.1 kHz stereo frame header padded to
+// size, with a Xing header announcing frames when frames > 0.
+func mpegFrame(size int, frames uint32) []byte {
+	b := make([]byte, size)
+	copy(b, []byte{0xFF, 0xFB, 0x90, 0x00})
+	if frames > 0 {
+		copy(b[36:], "Xing")
+		binary.BigEndian.PutUint32(b[40:], 1)
+		binary.BigEndian.PutUint32(b[44:], frames)
+	}
+	return b
+}
+
+// atom returns an MP4 atom.
+func atom(typ string, parts ...[]byte) []byte {
+	body := bytes.Join(parts, nil)
+	b := binary.BigEndian.AppendUint32(nil, uint32(8+len(body)))
+	return append(append(b, typ...), body...)
+}
+
+func dataAtom(kind uint32, payload []byte) []byte {
+	head := binary.BigEndian.AppendUint32(nil, kind)
+	return atom("data", head, make([]byte, 4), payload)
+}
+
+func m4a(title string, seconds uint32) []byte {
+	mvhd := make([]byte, 20)
+	binary.BigEndian.PutUint32(mvhd[12:], 1000)
+	binary.BigEndian.PutUint32(mvhd[16:], seconds*1000)
+	ilst := atom("ilst",
+		atom("\xa9nam", dataAtom(1, []byte(title))),
+		atom("desc", dataAtom(1, []byte("Episode notes"))),
+		atom("covr", dataAtom(14, png)))
+	meta := atom("meta", make([]byte, 4), atom("hdlr", make([]byte, 25)), ilst)
+	return bytes.Join([][]byte{
+		atom("ftyp", []byte("M4A \x00\x00\x00\x00")),
+		atom("moov", atom("mvhd", mvhd), atom("udta", meta)),
+		atom("mdat", make([]byte, 100)),
+	}, nil)
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScan(t *testing.T) {
+	dir := t.TempDir()
+	tagged := append(id3Tag(
+		id3Frame("TIT2", []byte("\x03Pilot")),
+		id3Frame("TPE1", []byte("\x01\xff\xfeH\x00o\x00s\x00t\x00\x00\x00")),
+		id3Frame("COMM", []byte("\x00eng\x00Show notes")),
+		id3Frame("APIC", append([]byte("\x00image/png\x00\x03cover\x00"), png...)),
+	), mpegFrame(417, 100)...)
+	writeFile(t, filepath.Join(dir, "01 Pilot.mp3"), tagged)
+	writeFile(t, filepath.Join(dir, "02-untagged.mp3"), mpegFrame(32000, 0))
+	writeFile(t, filepath.Join(dir, "season 2", "e1.m4a"), m4a("Second season", 61))
+	writeFile(t, filepath.Join(dir, "notes.txt"), []byte("not audio"))
+	writeFile(t, filepath.Join(dir, ".hidden", "x.mp3"), mpegFrame(417, 0))
+
+	files, err := mediascan.Scan(dir)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("expected 3 media files, got %d", len(files))
+	}
+	mp3, untagged, m := files[0], files[1], files[2]
+	if mp3.Path != "01 Pilot.mp3" || mp3.Title != "Pilot" || mp3.Artist != "Host" || mp3.Comment != "Show notes" {
+		t.Errorf("unexpected MP3 tags: %+v", mp3)
+	}
+	if mp3.DurationSeconds != 3 { // 100 frames * 1152 samples / 44100 Hz
+		t.Errorf("Xing duration = %d, want 3", mp3.DurationSeconds)
+	}
+	if !bytes.Equal(mp3.Artwork, png) || mp3.ArtworkType != "image/png" {
+		t.Errorf("unexpected MP3 artwork %q (%s)", mp3.Artwork, mp3.ArtworkType)
+	}
+	if untagged.Title != "" || untagged.DurationSeconds != 2 { // 32000 bytes at 128 kbit/s
+		t.Errorf("unexpected untagged MP3: %+v", untagged)
+	}
+	if m.Path != "season 2/e1.m4a" || m.Title != "Second season" || m.Comment != "Episode notes" ||
+		m.DurationSeconds != 61 || m.ArtworkType != "image/png" || m.MIMEType != "audio/x-m4a" {
+		t.Errorf("unexpected M4A metadata: %+v", m)
+	}
+}
+
+func TestItemBuilders(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "My Episode.mp3"), mpegFrame(16000, 0))
+	opts := mediascan.Options{
+		BaseURL:    "https://cdn.example.com/audio/",
+		ArtworkURL: func(f *mediascan.File) string { return "https://cdn.example.com/art.png" },
+	}
+	items, err := mediascan.ItemBuilders(dir, opts)
+	if err != nil {
+		t.Fatalf("ItemBuilders failed: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("expected 1 item, got %d", len(items))
+	}
+	it, err := items[0].WithDescription("d").Build()
+	if err != nil {
+		t.Fatalf("item Build failed: %v", err)
+	}
+	if it.Title != "My Episode" || it.ID != "https://cdn.example.com/audio/My%20Episode.mp3" {
+		t.Errorf("unexpected title/id: %q %q", it.Title, it.ID)
+	}
+	if it.Enclosure == nil || it.Enclosure.Length != 16000 || it.Enclosure.Type != "audio/mpeg" || it.DurationSeconds != 1 {
+		t.Errorf("unexpected enclosure/duration: %+v %d", it.Enclosure, it.DurationSeconds)
+	}
+	if it.ImageURL != "" {
+		t.Errorf("ArtworkURL must only be used for files with artwork")
+	}
+
+	if _, err := mediascan.ItemBuilders(dir, mediascan.Options{BaseURL: "/audio"}); err == nil || !strings.Contains(err.Error(), "BaseURL") {
+		t.Errorf("expected BaseURL error, got %v", err)
+	}
+}
+
+func TestItemBuilder_ArtistValidatesAsRSS(t *testing.T) {
+	f := &mediascan.File{Path: "ep.mp3", Size: 10, MIMEType: "audio/mpeg", Artist: "Host", ModTime: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	feed, err := gofeedx.NewFeed("Show").
+		WithLink("https://example.org/").
+		WithDescription("d").
+		AddItem(f.ItemBuilder(mediascan.Options{BaseURL: "https://cdn.example.com/"}).WithDescription("d")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+	if err := gofeedx.ValidateRSS(feed); err != nil {
+		t.Errorf("expected the artist not to break RSS validation: %v", err)
+	}
+	rss, err := gofeedx.ToRSS(feed)
+	if err != nil || !strings.Contains(rss, "<itunes:author>Host</itunes:author>") {
+		t.Errorf("expected the artist as itunes:author, got %v:\n%s", err, rss)
+	}
+}
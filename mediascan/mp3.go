@@ -0,0 +1,306 @@
+package mediascan
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// maxTagSize bounds the ID3 tag and MP4 metadata read into memory.
+const maxTagSize = 64 << 20
+
+// readMP3 reads the ID3v2 tag of an MP3 file and, when it has no length frame, derives the
+// duration from the first MPEG audio frame (Xing/Info/VBRI frame count, or the bitrate).
+func readMP3(r io.ReaderAt, f *File) error {
+	audioStart, err := readID3v2(r, f)
+	if err != nil {
+		return err
+	}
+	if f.DurationSeconds == 0 {
+		end := f.Size
+		var v1 [3]byte
+		if f.Size-128 >= audioStart {
+			if _, err := r.ReadAt(v1[:], f.Size-128); err == nil && string(v1[:]) == "TAG" {
+				end -= 128 // ID3v1 tag
+			}
+		}
+		f.DurationSeconds = mpegDuration(r, audioStart, end)
+	}
+	return nil
+}
+
+// readID3v2 parses an ID3v2.2-2.4 tag at the start of r into f and returns its length
+// (0 for an untagged file).
+func readID3v2(r io.ReaderAt, f *File) (int64, error) {
+	var h [10]byte
+	if _, err := r.ReadAt(h[:], 0); err != nil || string(h[:3]) != "ID3" {
+		return 0, nil
+	}
+	major, flags := h[3], h[5]
+	if major < 2 || major > 4 {
+		return 0, errors.New("unsupported ID3v2 version")
+	}
+	size := int64(synchsafe(h[6:10]))
+	if size > maxTagSize {
+		return 0, errors.New("ID3 tag too large")
+	}
+	total := 10 + size
+	if major == 4 && flags&0x10 != 0 {
+		total += 10 // footer
+	}
+	body := make([]byte, size)
+	if _, err := r.ReadAt(body, 10); err != nil {
+		return 0, errors.New("truncated ID3 tag")
+	}
+	if flags&0x80 != 0 && major < 4 {
+		body = unsynchronize(body)
+	}
+	if flags&0x40 != 0 && len(body) >= 4 { // extended header
+		ext := int(binary.BigEndian.Uint32(body)) + 4
+		if major == 4 {
+			ext = int(synchsafe(body[:4]))
+		}
+		if ext > len(body) {
+			return 0, errors.New("malformed ID3 extended header")
+		}
+		body = body[ext:]
+	}
+
+	idLen, headLen := 4, 10
+	if major == 2 {
+		idLen, headLen = 3, 6
+	}
+	var cover, firstPicture []byte
+	for len(body) >= headLen && body[0] != 0 { // a zero byte starts the padding
+		id := string(body[:idLen])
+		var n int
+		switch major {
+		case 2:
+			n = int(body[3])<<16 | int(body[4])<<8 | int(body[5])
+		case 3:
+			n = int(binary.BigEndian.Uint32(body[4:8]))
+		default:
+			n = int(synchsafe(body[4:8]))
+		}
+		if n < 0 || n > len(body)-headLen {
+			break
+		}
+		var frameFlags byte
+		if major > 2 {
+			frameFlags = body[9]
+		}
+		data, ok := id3FrameData(major, frameFlags, body[headLen:headLen+n])
+		body = body[headLen+n:]
+		if !ok || len(data) == 0 {
+			continue
+		}
+		switch id {
+		case "TIT2", "TT2":
+			f.Title = id3Text(data)
+		case "TPE1", "TP1":
+			f.Artist = id3Text(data)
+		case "TALB", "TAL":
+			f.Album = id3Text(data)
+		case "TLEN", "TLE":
+			if ms, err := strconv.Atoi(strings.TrimSpace(id3Text(data))); err == nil && ms > 0 {
+				f.DurationSeconds = int(math.Round(float64(ms) / 1000))
+			}
+		case "COMM", "COM":
+			// encoding, language (3 bytes), short description, text
+			if f.Comment == "" && len(data) > 4 {
+				_, text := splitID3String(data[0], data[4:])
+				f.Comment = decodeID3String(data[0], text)
+			}
+		case "APIC", "PIC":
+			pic, picType := id3Picture(major, data)
+			if len(pic) > 0 && firstPicture == nil {
+				firstPicture = pic
+			}
+			if len(pic) > 0 && picType == 3 && cover == nil { // front cover
+				cover = pic
+			}
+		}
+	}
+	if cover == nil {
+		cover = firstPicture
+	}
+	if cover != nil {
+		f.Artwork, f.ArtworkType = cover, imageType(cover)
+	}
+	return total, nil
+}
+
+// id3FrameData strips the per-frame additions announced by the format flags; ok is false for
+// compressed or encrypted frames, which are skipped.
+func id3FrameData(major, flags byte, data []byte) ([]byte, bool) {
+	switch major {
+	case 3:
+		if flags&0xC0 != 0 {
+			return nil, false
+		}
+		if flags&0x20 != 0 && len(data) > 0 { // grouping identity
+			data = data[1:]
+		}
+	case 4:
+		if flags&0x0C != 0 {
+			return nil, false
+		}
+		if flags&0x40 != 0 && len(data) > 0 { // grouping identity
+			data = data[1:]
+		}
+		if flags&0x01 != 0 { // data length indicator
+			if len(data) < 4 {
+				return nil, false
+			}
+			data = data[4:]
+		}
+		if flags&0x02 != 0 {
+			data = unsynchronize(data)
+		}
+	}
+	return data, true
+}
+
+// id3Picture returns the image data and picture type of an APIC (v2.3/2.4) or PIC (v2.2) frame.
+func id3Picture(major byte, data []byte) ([]byte, byte) {
+	enc, rest := data[0], data[1:]
+	if major == 2 {
+		if len(rest) < 4 {
+			return nil, 0
+		}
+		rest = rest[3:] // image format, e.g. "JPG"
+	} else {
+		i := bytes.IndexByte(rest, 0)
+		if i < 0 {
+			return nil, 0
+		}
+		rest = rest[i+1:] // MIME type
+	}
+	if len(rest) < 1 {
+		return nil, 0
+	}
+	picType := rest[0]
+	_, img := splitID3String(enc, rest[1:]) // description
+	return img, picType
+}
+
+// id3Text decodes a text frame, keeping the first of several null-separated values.
+func id3Text(data []byte) string {
+	s, _ := splitID3String(data[0], data[1:])
+	return strings.TrimSpace(decodeID3String(data[0], s))
+}
+
+// splitID3String splits b at the string terminator of encoding enc (two zero bytes at an even
+// offset for UTF-16, one zero byte otherwise).
+func splitID3String(enc byte, b []byte) (s, rest []byte) {
+	if enc == 1 || enc == 2 {
+		for i := 0; i+1 < len(b); i += 2 {
+			if b[i] == 0 && b[i+1] == 0 {
+				return b[:i], b[i+2:]
+			}
+		}
+		return b, nil
+	}
+	if i := bytes.IndexByte(b, 0); i >= 0 {
+		return b[:i], b[i+1:]
+	}
+	return b, nil
+}
+
+// decodeID3String decodes b in ID3 text encoding enc: ISO-8859-1, UTF-16 with BOM, UTF-16BE
+// or UTF-8.
+func decodeID3String(enc byte, b []byte) string {
+	switch enc {
+	case 0:
+		r := make([]rune, len(b))
+		for i, c := range b {
+			r[i] = rune(c)
+		}
+		return string(r)
+	case 1, 2:
+		order := binary.ByteOrder(binary.BigEndian)
+		if enc == 1 && len(b) >= 2 {
+			if b[0] == 0xFF && b[1] == 0xFE {
+				order = binary.LittleEndian
+			}
+			if (b[0] == 0xFF && b[1] == 0xFE) || (b[0] == 0xFE && b[1] == 0xFF) {
+				b = b[2:]
+			}
+		}
+		u := make([]uint16, len(b)/2)
+		for i := range u {
+			u[i] = order.Uint16(b[2*i:])
+		}
+		return string(utf16.Decode(u))
+	default:
+		return string(b)
+	}
+}
+
+// synchsafe decodes a 28-bit synchsafe integer (7 bits per byte).
+func synchsafe(b []byte) uint32 {
+	return uint32(b[0]&0x7F)<<21 | uint32(b[1]&0x7F)<<14 | uint32(b[2]&0x7F)<<7 | uint32(b[3]&0x7F)
+}
+
+// unsynchronize reverses ID3 unsynchronisation (0xFF 0x00 becomes 0xFF).
+func unsynchronize(b []byte) []byte {
+	return bytes.ReplaceAll(b, []byte{0xFF, 0x00}, []byte{0xFF})
+}
+
+var (
+	mpegBitratesV1 = [16]int{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0}
+	mpegBitratesV2 = [16]int{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0}
+	mpegRates      = map[byte][3]int{3: {44100, 48000, 32000}, 2: {22050, 24000, 16000}, 0: {11025, 12000, 8000}}
+)
+
+// mpegDuration finds the first MPEG Layer III frame header in [start, end) and returns the
+// stream duration in seconds from its Xing/Info/VBRI frame count or, for constant bitrate
+// streams, from the bitrate; it returns 0 when no frame header is found.
+func mpegDuration(r io.ReaderAt, start, end int64) int {
+	buf := make([]byte, 64<<10)
+	n, _ := r.ReadAt(buf, start)
+	buf = buf[:n]
+	for i := 0; i+4 <= len(buf); i++ {
+		if buf[i] != 0xFF || buf[i+1]&0xE0 != 0xE0 {
+			continue
+		}
+		version, layer := (buf[i+1]>>3)&3, (buf[i+1]>>1)&3
+		bitrateIdx, rateIdx := buf[i+2]>>4, (buf[i+2]>>2)&3
+		if version == 1 || layer != 1 || bitrateIdx == 0 || bitrateIdx == 15 || rateIdx == 3 {
+			continue
+		}
+		rate := mpegRates[version][rateIdx]
+		mono := buf[i+3]>>6 == 3
+		bitrate, samples, xing := mpegBitratesV1[bitrateIdx], 1152, 36
+		switch {
+		case version != 3:
+			bitrate, samples, xing = mpegBitratesV2[bitrateIdx], 576, 21
+			if mono {
+				xing = 13
+			}
+		case mono:
+			xing = 21
+		}
+		frame := buf[i:]
+		var frames uint32
+		switch {
+		case len(frame) >= xing+12 && (string(frame[xing:xing+4]) == "Xing" || string(frame[xing:xing+4]) == "Info"):
+			if binary.BigEndian.Uint32(frame[xing+4:])&1 != 0 {
+				frames = binary.BigEndian.Uint32(frame[xing+8:])
+			}
+		case len(frame) >= 36+18 && string(frame[36:40]) == "VBRI":
+			frames = binary.BigEndian.Uint32(frame[36+14:])
+		}
+		if frames > 0 {
+			return int(math.Round(float64(frames) * float64(samples) / float64(rate)))
+		}
+		audio := end - start - int64(i)
+		return int(math.Round(float64(audio) * 8 / float64(bitrate*1000)))
+	}
+	return 0
+}
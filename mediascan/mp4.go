@@ -0,0 +1,152 @@
+package mediascan
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+)
+
+// readMP4 reads the movie header duration and the iTunes metadata list (moov/udta/meta/ilst)
+// of an MP4 audio file.
+func readMP4(r io.ReaderAt, f *File) error {
+	moov, err := findMP4Atom(r, f.Size, "moov")
+	if err != nil {
+		return err
+	}
+	var description string
+	mp4Atoms(moov, func(typ string, body []byte) {
+		switch typ {
+		case "mvhd":
+			f.DurationSeconds = mvhdDuration(body)
+		case "udta":
+			mp4Atoms(body, func(typ string, body []byte) {
+				if typ != "meta" {
+					return
+				}
+				// meta is a full box (version and flags) except in some QuickTime files.
+				if len(body) >= 8 && string(body[4:8]) != "hdlr" {
+					body = body[4:]
+				}
+				mp4Atoms(body, func(typ string, body []byte) {
+					if typ != "ilst" {
+						return
+					}
+					mp4Atoms(body, func(key string, item []byte) {
+						value, kind := mp4Data(item)
+						switch key {
+						case "\xa9nam":
+							f.Title = string(value)
+						case "\xa9ART":
+							f.Artist = string(value)
+						case "\xa9alb":
+							f.Album = string(value)
+						case "\xa9cmt":
+							f.Comment = string(value)
+						case "desc":
+							description = string(value)
+						case "covr":
+							if len(value) > 0 && f.Artwork == nil {
+								f.Artwork = value
+								switch kind {
+								case 13:
+									f.ArtworkType = "image/jpeg"
+								case 14:
+									f.ArtworkType = "image/png"
+								default:
+									f.ArtworkType = imageType(value)
+								}
+							}
+						}
+					})
+				})
+			})
+		}
+	})
+	if description != "" {
+		f.Comment = description
+	}
+	return nil
+}
+
+// findMP4Atom walks the top-level atoms of r and returns the body of the first atom of typ.
+func findMP4Atom(r io.ReaderAt, size int64, typ string) ([]byte, error) {
+	var h [16]byte
+	for off := int64(0); off+8 <= size; {
+		if _, err := r.ReadAt(h[:8], off); err != nil {
+			return nil, errors.New("truncated MP4 atom")
+		}
+		n, head := int64(binary.BigEndian.Uint32(h[:4])), int64(8)
+		switch n {
+		case 0: // extends to the end of the file
+			n = size - off
+		case 1: // 64-bit size follows the type
+			if _, err := r.ReadAt(h[8:16], off+8); err != nil {
+				return nil, errors.New("truncated MP4 atom")
+			}
+			n, head = int64(binary.BigEndian.Uint64(h[8:16])), 16
+		}
+		if n < head || off+n > size {
+			return nil, errors.New("malformed MP4 atom")
+		}
+		if string(h[4:8]) == typ {
+			if n-head > maxTagSize {
+				return nil, errors.New("MP4 " + typ + " atom too large")
+			}
+			body := make([]byte, n-head)
+			if _, err := r.ReadAt(body, off+head); err != nil {
+				return nil, errors.New("truncated MP4 atom")
+			}
+			return body, nil
+		}
+		off += n
+	}
+	return nil, errors.New("no MP4 " + typ + " atom")
+}
+
+// mp4Atoms calls fn for each well-formed atom in b, stopping at the first malformed one.
+func mp4Atoms(b []byte, fn func(typ string, body []byte)) {
+	for len(b) >= 8 {
+		n, head := uint64(binary.BigEndian.Uint32(b)), uint64(8)
+		switch n {
+		case 0:
+			n = uint64(len(b))
+		case 1:
+			if len(b) < 16 {
+				return
+			}
+			n, head = binary.BigEndian.Uint64(b[8:]), 16
+		}
+		if n < head || n > uint64(len(b)) {
+			return
+		}
+		fn(string(b[4:8]), b[head:n])
+		b = b[n:]
+	}
+}
+
+// mp4Data returns the payload and well-known type of the data atom inside an ilst item.
+func mp4Data(item []byte) (value []byte, kind uint32) {
+	mp4Atoms(item, func(typ string, body []byte) {
+		if typ == "data" && value == nil && len(body) >= 8 {
+			// 1 byte version, 3 bytes type, 4 bytes locale
+			kind, value = binary.BigEndian.Uint32(body)&0xFFFFFF, body[8:]
+		}
+	})
+	return value, kind
+}
+
+// mvhdDuration returns the movie duration in seconds from an mvhd body.
+func mvhdDuration(b []byte) int {
+	var scale, duration uint64
+	switch {
+	case len(b) >= 20 && b[0] == 0:
+		scale, duration = uint64(binary.BigEndian.Uint32(b[12:])), uint64(binary.BigEndian.Uint32(b[16:]))
+	case len(b) >= 32 && b[0] == 1:
+		scale, duration = uint64(binary.BigEndian.Uint32(b[20:])), binary.BigEndian.Uint64(b[24:])
+	}
+	if scale == 0 {
+		return 0
+	}
+	return int(math.Round(float64(duration) / float64(scale)))
+}
@@ -0,0 +1,145 @@
+package mediascan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+// probeBlockSize is the size of the first range request, which usually covers the ID3 tag
+// and the first MPEG frame.
+const probeBlockSize = 64 << 10
+
+// HTTPProber is a gofeedx.DurationProber that reads the duration of remote MP3 and M4A
+// enclosures with HTTP range requests, fetching only the tag and header bytes it needs. It
+// requires servers to honour Range headers. The file type is taken from the enclosure type
+// or, failing that, the URL extension.
+type HTTPProber struct {
+	Client *http.Client // nil uses http.DefaultClient
+}
+
+var _ gofeedx.DurationProber = HTTPProber{}
+
+// ProbeDuration returns the duration of the item's enclosure in seconds.
+func (p HTTPProber) ProbeDuration(ctx context.Context, it *gofeedx.Item) (int, error) {
+	if it == nil || it.Enclosure == nil {
+		return 0, errors.New("mediascan: item has no enclosure")
+	}
+	mime := probeType(it.Enclosure)
+	if mime == "" {
+		return 0, fmt.Errorf("mediascan: %s: unsupported media type", it.Enclosure.Url)
+	}
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	r, err := newRangeReader(ctx, client, strings.TrimSpace(it.Enclosure.Url))
+	if err != nil {
+		return 0, fmt.Errorf("mediascan: %w", err)
+	}
+	f := &File{Size: r.size, MIMEType: mime}
+	if mime == "audio/mpeg" {
+		err = readMP3(r, f)
+	} else {
+		err = readMP4(r, f)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("mediascan: %s: %w", r.url, err)
+	}
+	if f.DurationSeconds <= 0 {
+		return 0, fmt.Errorf("mediascan: %s: no duration found", r.url)
+	}
+	return f.DurationSeconds, nil
+}
+
+// probeType maps an enclosure to the MIME type of a supported file type, or "".
+func probeType(e *gofeedx.Enclosure) string {
+	typ, _, _ := strings.Cut(strings.ToLower(e.Type), ";")
+	switch strings.TrimSpace(typ) {
+	case "audio/mpeg", "audio/mp3":
+		return "audio/mpeg"
+	case "audio/mp4", "audio/x-m4a", "audio/m4a":
+		return "audio/x-m4a"
+	}
+	ext := ""
+	if u, err := url.Parse(strings.TrimSpace(e.Url)); err == nil {
+		ext = strings.ToLower(path.Ext(u.Path))
+	}
+	return mediaTypes[ext]
+}
+
+// rangeReader is an io.ReaderAt over a remote file, backed by HTTP range requests. The first
+// block is fetched up front (it also yields the file size) and served from memory.
+type rangeReader struct {
+	ctx    context.Context
+	client *http.Client
+	url    string
+	size   int64
+	head   []byte
+}
+
+func newRangeReader(ctx context.Context, client *http.Client, u string) (*rangeReader, error) {
+	r := &rangeReader{ctx: ctx, client: client, url: u}
+	head, size, err := r.get(0, probeBlockSize)
+	if err != nil {
+		return nil, err
+	}
+	r.head, r.size = head, size
+	return r, nil
+}
+
+// ReadAt implements io.ReaderAt.
+func (r *rangeReader) ReadAt(p []byte, off int64) (int, error) {
+	if off >= r.size {
+		return 0, io.EOF
+	}
+	if off+int64(len(p)) <= int64(len(r.head)) {
+		return copy(p, r.head[off:]), nil
+	}
+	body, _, err := r.get(off, int64(len(p)))
+	if err != nil {
+		return 0, err
+	}
+	n := copy(p, body)
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// get fetches n bytes from off and returns them with the total file size.
+func (r *rangeReader) get(off, n int64) ([]byte, int64, error) {
+	req, err := http.NewRequestWithContext(r.ctx, http.MethodGet, r.url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", off, off+n-1))
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusPartialContent {
+		return nil, 0, fmt.Errorf("GET %s: range request not honoured (status %s)", r.url, resp.Status)
+	}
+	size := int64(-1)
+	if _, total, ok := strings.Cut(resp.Header.Get("Content-Range"), "/"); ok {
+		size, _ = strconv.ParseInt(total, 10, 64)
+	}
+	if size < 0 {
+		return nil, 0, fmt.Errorf("GET %s: no file size in Content-Range", r.url)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, n))
+	if err != nil {
+		return nil, 0, err
+	}
+	return body, size, nil
+}
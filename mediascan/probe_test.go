@@ -0,0 +1,52 @@
+package mediascan_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+	"github.com/jo-hoe/gofeedx/mediascan"
+)
+
+func TestHTTPProber(t *testing.T) {
+	mp3 := append(id3Tag(id3Frame("TIT2", []byte("\x03Pilot"))), mpegFrame(200000, 0)...)
+	files := map[string][]byte{
+		"/ep.mp3":  mp3,
+		"/ep.m4a":  m4a("Remote", 95),
+		"/ep.ogg":  []byte("OggS"),
+		"/nothing": make([]byte, 10),
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeContent(w, r, r.URL.Path, time.Time{}, bytes.NewReader(data))
+	}))
+	defer srv.Close()
+
+	p := mediascan.HTTPProber{Client: srv.Client()}
+	probe := func(path, typ string) (int, error) {
+		return p.ProbeDuration(context.Background(), &gofeedx.Item{Enclosure: &gofeedx.Enclosure{Url: srv.URL + path, Type: typ}})
+	}
+	if sec, err := probe("/ep.mp3", "audio/mpeg"); err != nil || sec != 13 { // ~200 KB at 128 kbit/s
+		t.Errorf("mp3: got %d, %v; want 13", sec, err)
+	}
+	if sec, err := probe("/ep.m4a", ""); err != nil || sec != 95 {
+		t.Errorf("m4a: got %d, %v; want 95", sec, err)
+	}
+	if _, err := probe("/ep.ogg", "audio/ogg"); err == nil {
+		t.Errorf("expected error for unsupported type")
+	}
+	if _, err := probe("/nothing", "audio/mpeg"); err == nil {
+		t.Errorf("expected error when no duration can be found")
+	}
+	if _, err := probe("/missing.mp3", "audio/mpeg"); err == nil {
+		t.Errorf("expected error for a missing file")
+	}
+}
@@ -0,0 +1,164 @@
+package gofeedx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NormalizeChange records one automatic fix applied by Normalize.
+type NormalizeChange struct {
+	Path   string // e.g. "Feed.Title", "Items[2].ID"
+	Action string // e.g. "trimmed whitespace", "generated id"
+}
+
+func (c NormalizeChange) String() string {
+	return fmt.Sprintf("%s: %s", c.Path, c.Action)
+}
+
+// NormalizeOptions configures NormalizeWithOptions.
+type NormalizeOptions struct {
+	// TruncateDescriptions cuts descriptions over PSPDescriptionMaxBytes when PSP is targeted.
+	// Truncation never splits a UTF-8 sequence, a tag or a character reference.
+	TruncateDescriptions bool
+}
+
+// Normalize applies safe automatic fixes to f before validation for profiles (all profiles
+// when none are given) and returns what it changed; see NormalizeWithOptions.
+func (f *Feed) Normalize(profiles ...Profile) []NormalizeChange {
+	return f.NormalizeWithOptions(NormalizeOptions{}, profiles...)
+}
+
+// NormalizeWithOptions trims surrounding whitespace from titles, ids, urls, languages and
+// author fields, derives a missing Feed.Updated from the newest item, generates missing item
+// ids for Atom, JSON Feed and PSP, fills IsPermaLink ("true" for URL ids, else "false") and,
+// when opts.TruncateDescriptions is set, shortens descriptions over the PSP limit. Content and
+// descriptions are otherwise left untouched.
+func (f *Feed) NormalizeWithOptions(opts NormalizeOptions, profiles ...Profile) []NormalizeChange {
+	if f == nil {
+		return nil
+	}
+	n := &normalizer{}
+	n.feed(f)
+	all := len(profiles) == 0
+	if all || containsAnyProfile(profiles, ProfileAtom, ProfileJSON, ProfilePSP) {
+		n.ids(f.Items)
+	}
+	n.permaLinks(f.Items)
+	if opts.TruncateDescriptions && (all || containsProfile(profiles, ProfilePSP)) {
+		n.truncate(f)
+	}
+	return n.changes
+}
+
+type normalizer struct {
+	changes []NormalizeChange
+}
+
+func (n *normalizer) record(path, action string) {
+	n.changes = append(n.changes, NormalizeChange{Path: path, Action: action})
+}
+
+// trim trims *s and records the change.
+func (n *normalizer) trim(path string, s *string) {
+	if t := strings.TrimSpace(*s); t != *s {
+		*s = t
+		n.record(path, "trimmed whitespace")
+	}
+}
+
+func (n *normalizer) link(path string, l *Link) {
+	if l != nil {
+		n.trim(path+".Href", &l.Href)
+	}
+}
+
+func (n *normalizer) author(path string, a *Author) {
+	if a != nil {
+		n.trim(path+".Name", &a.Name)
+		n.trim(path+".Email", &a.Email)
+	}
+}
+
+func (n *normalizer) feed(f *Feed) {
+	n.trim("Feed.Title", &f.Title)
+	n.trim("Feed.ID", &f.ID)
+	n.trim("Feed.Language", &f.Language)
+	n.trim("Feed.FeedURL", &f.FeedURL)
+	n.link("Feed.Link", f.Link)
+	n.author("Feed.Author", f.Author)
+	if f.Image != nil {
+		n.trim("Feed.Image.Url", &f.Image.Url)
+	}
+	for i, c := range f.Categories {
+		if c != nil {
+			n.trim(fmt.Sprintf("Feed.Categories[%d].Text", i), &c.Text)
+		}
+	}
+	for i, it := range f.Items {
+		if it != nil {
+			n.item(fmt.Sprintf("Items[%d]", i), it)
+		}
+	}
+	if f.Updated.IsZero() {
+		var items []*Item
+		for _, it := range f.Items {
+			if it != nil {
+				items = append(items, it)
+			}
+		}
+		if t := maxTime(collectItemTimes(items)...); !t.IsZero() {
+			f.Updated = t
+			n.record("Feed.Updated", "derived from newest item")
+		}
+	}
+}
+
+func (n *normalizer) item(path string, it *Item) {
+	n.trim(path+".Title", &it.Title)
+	n.trim(path+".ID", &it.ID)
+	n.trim(path+".Language", &it.Language)
+	n.trim(path+".ImageURL", &it.ImageURL)
+	n.link(path+".Link", it.Link)
+	n.author(path+".Author", it.Author)
+	if it.Enclosure != nil {
+		n.trim(path+".Enclosure.Url", &it.Enclosure.Url)
+		n.trim(path+".Enclosure.Type", &it.Enclosure.Type)
+	}
+}
+
+func (n *normalizer) ids(items []*Item) {
+	for i, it := range items {
+		if it != nil && it.ID == "" {
+			it.ID = fallbackItemGuid(it)
+			n.record(fmt.Sprintf("Items[%d].ID", i), "generated id")
+		}
+	}
+}
+
+func (n *normalizer) permaLinks(items []*Item) {
+	for i, it := range items {
+		if it == nil || it.ID == "" || it.IsPermaLink != "" {
+			continue
+		}
+		it.IsPermaLink = "false"
+		if isAbsoluteURL(it.ID) {
+			it.IsPermaLink = "true"
+		}
+		n.record(fmt.Sprintf("Items[%d].IsPermaLink", i), "set to "+it.IsPermaLink)
+	}
+}
+
+func (n *normalizer) truncate(f *Feed) {
+	cut := func(path string, s *string) {
+		if len(*s) > PSPDescriptionMaxBytes {
+			*s = truncateHTMLBytes(*s, PSPDescriptionMaxBytes)
+			n.record(path, fmt.Sprintf("truncated to %d bytes", PSPDescriptionMaxBytes))
+		}
+	}
+	cut("Feed.Description", &f.Description)
+	for i, it := range f.Items {
+		if it != nil {
+			cut(fmt.Sprintf("Items[%d].Description", i), &it.Description)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package gofeedx_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestNormalize(t *testing.T) {
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	f := &gofeedx.Feed{
+		Title:       "  Show ",
+		Link:        &gofeedx.Link{Href: " https://example.com/ "},
+		Description: strings.Repeat("a", gofeedx.PSPDescriptionMaxBytes+10),
+		Items: []*gofeedx.Item{
+			{Title: "E1 ", ID: "https://example.com/e1", Created: created},
+			{Title: "E2", Link: &gofeedx.Link{Href: "https://example.com/e2"}, Created: created.Add(-time.Hour)},
+		},
+	}
+	changes := f.NormalizeWithOptions(gofeedx.NormalizeOptions{TruncateDescriptions: true}, gofeedx.ProfilePSP)
+	var got []string
+	for _, c := range changes {
+		got = append(got, c.String())
+	}
+	report := strings.Join(got, "\n")
+
+	if f.Title != "Show" || f.Link.Href != "https://example.com/" || f.Items[0].Title != "E1" {
+		t.Errorf("expected trimmed fields, got %q %q %q", f.Title, f.Link.Href, f.Items[0].Title)
+	}
+	if !f.Updated.Equal(created) {
+		t.Errorf("expected Updated derived from newest item, got %v", f.Updated)
+	}
+	if f.Items[1].ID == "" || f.Items[1].IsPermaLink != "false" {
+		t.Errorf("expected generated id with isPermaLink=false, got %q %q", f.Items[1].ID, f.Items[1].IsPermaLink)
+	}
+	if f.Items[0].IsPermaLink != "true" {
+		t.Errorf("expected URL id to be a permalink, got %q", f.Items[0].IsPermaLink)
+	}
+	if len(f.Description) != gofeedx.PSPDescriptionMaxBytes {
+		t.Errorf("expected truncated description, got %d bytes", len(f.Description))
+	}
+	mustContain(t, report, "Feed.Title: trimmed whitespace", "expected title change in report")
+	mustContain(t, report, "Feed.Updated: derived from newest item", "expected updated change in report")
+	mustContain(t, report, "Items[1].ID: generated id", "expected id change in report")
+	mustContain(t, report, "Feed.Description: truncated to 4000 bytes", "expected truncation in report")
+
+	if again := f.Normalize(gofeedx.ProfilePSP); len(again) != 0 {
+		t.Errorf("expected normalized feed to be stable, got %v", again)
+	}
+}
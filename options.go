@@ -0,0 +1,99 @@
+package gofeedx
+
+import "encoding/json"
+
+// PSPOptions is the typed view of the channel-level PSP options recorded on a feed by the
+// builder helpers (WithPSPExplicit, WithPSPItunesType, WithPSPFunding, WithPSPDurationHHMMSS, ...).
+// Options the writer would reject as invalid are left at their zero value.
+type PSPOptions struct {
+	Explicit      *bool  // itunes:explicit
+	Type          string // itunes:type: "episodic" or "serial"
+	Complete      bool   // itunes:complete
+	ImageHref     string // itunes:image override
+	Locked        *bool  // podcast:locked
+	TXT           []*PodcastTXT
+	Funding       []*PodcastFunding
+	ClockDuration bool // itunes:duration rendered as HH:MM:SS
+}
+
+// PSPOptions decodes the PSP options recorded on f, as the PSP writer would apply them.
+func (f *Feed) PSPOptions() PSPOptions {
+	ch := &PSPChannel{}
+	mapChannelExtensions(f.allExtensions(), ch)
+	return PSPOptions{
+		Explicit:      ch.ItunesExplicit,
+		Type:          ch.ItunesType,
+		Complete:      ch.ItunesComplete,
+		ImageHref:     ch.ItunesImageHref,
+		Locked:        ch.PodcastLocked,
+		TXT:           ch.PodcastTXT,
+		Funding:       ch.PodcastFunding,
+		ClockDuration: usePSPClockDuration(f.allExtensions()),
+	}
+}
+
+// JSONOptions is the typed view of the feed-level JSON Feed options recorded on a feed by the
+// builder helpers (WithJSONUserComment, WithJSONHub, WithJSONExtension, ...).
+type JSONOptions struct {
+	UserComment       string
+	NextURL           string
+	Expired           *bool
+	Hubs              []*JSONHub // includes WebSub hubs
+	Icon              string     // icon override
+	Favicon           string     // favicon override
+	DeriveContentText bool
+	Extensions        map[string]json.RawMessage // custom "_"-prefixed objects
+}
+
+// JSONOptions decodes the JSON Feed options recorded on f, as the JSON writer would apply them.
+func (f *Feed) JSONOptions() JSONOptions {
+	jf := &JSONFeed{}
+	mapFeedExtensionsToJSON(jf, f.allExtensions())
+	return JSONOptions{
+		UserComment:       jf.UserComment,
+		NextURL:           jf.NextUrl,
+		Expired:           jf.Expired,
+		Hubs:              jf.Hubs,
+		Icon:              jf.Icon,
+		Favicon:           jf.Favicon,
+		DeriveContentText: hasJSONDeriveContentText(f.allExtensions()),
+		Extensions:        jf.Custom,
+	}
+}
+
+// Builder helpers record their format options (the "_rss:", "_atom:", "_json:", "_psp:",
+// "_xml:" and "_websub:" markers) in the unexported options of Feed and Item instead of in
+// Extensions, so Extensions holds only what the caller added and cannot collide with them.
+// Writers, validators and inspectors read both through allExtensions.
+
+// withOption records builder options at feed scope.
+func (b *FeedBuilder) withOption(nodes ...ExtensionNode) *FeedBuilder {
+	b.feed.options = append(b.feed.options, nodes...)
+	return b
+}
+
+// withOption records builder options at item scope.
+func (b *ItemBuilder) withOption(nodes ...ExtensionNode) *ItemBuilder {
+	b.item.options = append(b.item.options, nodes...)
+	return b
+}
+
+// allExtensions returns the builder options followed by f.Extensions, so an extension added
+// explicitly is applied after, and for last-wins settings overrides, the builder option.
+func (f *Feed) allExtensions() []ExtensionNode {
+	return mergeOptions(f.options, f.Extensions)
+}
+
+// allExtensions is Feed.allExtensions for item scope.
+func (it *Item) allExtensions() []ExtensionNode {
+	return mergeOptions(it.options, it.Extensions)
+}
+
+func mergeOptions(options, exts []ExtensionNode) []ExtensionNode {
+	if len(options) == 0 {
+		return exts
+	}
+	out := make([]ExtensionNode, 0, len(options)+len(exts))
+	out = append(out, options...)
+	return append(out, exts...)
+}
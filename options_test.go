@@ -0,0 +1,105 @@
+package gofeedx_test
+
+import (
+	"testing"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestFeedOptions_PSP(t *testing.T) {
+	f, err := gofeedx.NewFeed("Show").
+		WithPSPExplicit(true).
+		WithPSPItunesType("serial").
+		WithPSPLocked(false).
+		WithPSPFunding("https://example.com/donate", "Support").
+		WithPSPTXT("verify-me", "verify").
+		WithPSPDurationHHMMSS(true).
+		Build()
+	mustNoErr(t, err, "Build failed")
+
+	o := f.PSPOptions()
+	if o.Explicit == nil || !*o.Explicit {
+		t.Errorf("expected Explicit=true, got %v", o.Explicit)
+	}
+	if o.Type != "serial" {
+		t.Errorf("expected Type=serial, got %q", o.Type)
+	}
+	if o.Locked == nil || *o.Locked {
+		t.Errorf("expected Locked=false, got %v", o.Locked)
+	}
+	if len(o.Funding) != 1 || o.Funding[0].Url != "https://example.com/donate" {
+		t.Errorf("unexpected Funding: %+v", o.Funding)
+	}
+	if len(o.TXT) != 1 || o.TXT[0].Purpose != "verify" {
+		t.Errorf("unexpected TXT: %+v", o.TXT)
+	}
+	if !o.ClockDuration {
+		t.Error("expected ClockDuration")
+	}
+}
+
+func TestFeedOptions_JSON(t *testing.T) {
+	f, err := gofeedx.NewFeed("Blog").
+		WithJSONUserComment("hello").
+		WithJSONExpired(true).
+		WithJSONHub("rssCloud", "https://hub.example.com/").
+		WithJSONFavicon("https://example.com/favicon.png").
+		WithJSONDerivedContentText().
+		WithJSONExtension("_blue_shed", map[string]string{"about": "https://blueshed-podcasts.com/json-feed-extension-docs"}).
+		Build()
+	mustNoErr(t, err, "Build failed")
+
+	o := f.JSONOptions()
+	if o.UserComment != "hello" || o.Favicon != "https://example.com/favicon.png" {
+		t.Errorf("unexpected options: %+v", o)
+	}
+	if o.Expired == nil || !*o.Expired {
+		t.Errorf("expected Expired=true, got %v", o.Expired)
+	}
+	if len(o.Hubs) != 1 || o.Hubs[0].Type != "rssCloud" {
+		t.Errorf("unexpected Hubs: %+v", o.Hubs)
+	}
+	if !o.DeriveContentText {
+		t.Error("expected DeriveContentText")
+	}
+	if _, ok := o.Extensions["_blue_shed"]; !ok {
+		t.Errorf("expected _blue_shed extension, got %v", o.Extensions)
+	}
+
+	plain, err := gofeedx.NewFeed("x").Build()
+	mustNoErr(t, err, "Build failed")
+	if o := plain.PSPOptions(); o.Explicit != nil || o.Type != "" {
+		t.Errorf("expected zero PSP options, got %+v", o)
+	}
+}
+
+func TestBuilderOptions_StayOutOfExtensions(t *testing.T) {
+	custom := gofeedx.ExtensionNode{Name: "custom:flag", Text: "on"}
+	b := gofeedx.NewFeed("Blog").
+		WithLink("https://example.com/").
+		WithDescription("d").
+		WithRSSTTL(30).
+		WithAtomIcon("https://example.com/icon.png").
+		WithJSONUserComment("hello").
+		WithExtensions(custom)
+	b.AddItem(gofeedx.NewItem("Post").WithID("1").WithJSONTag("go").WithRSSComments("https://example.com/c"))
+	f, err := b.Build()
+	mustNoErr(t, err, "Build failed")
+
+	if len(f.Extensions) != 1 || f.Extensions[0].Name != "custom:flag" {
+		t.Fatalf("expected only the caller's extension, got %+v", f.Extensions)
+	}
+	if len(f.Items[0].Extensions) != 0 {
+		t.Fatalf("expected no item extensions, got %+v", f.Items[0].Extensions)
+	}
+
+	c := f.Clone()
+	rss, err := gofeedx.ToRSS(c)
+	mustNoErr(t, err, "ToRSS failed")
+	mustContain(t, rss, "<ttl>30</ttl>", "expected builder option to be applied")
+	mustContain(t, rss, "https://example.com/c</comments>", "expected item builder option to be applied")
+	js, err := gofeedx.ToJSON(c)
+	mustNoErr(t, err, "ToJSON failed")
+	mustContain(t, js, `"user_comment": "hello"`, "expected JSON option to be applied")
+	mustContain(t, js, `"go"`, "expected JSON item tag")
+}
@@ -0,0 +1,71 @@
+package gofeedx
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// PageNumberPlaceholder marks where Paginate inserts the 1-based page number in a URL template.
+const PageNumberPlaceholder = "{page}"
+
+// Paginate splits feed into pages of at most pageSize items, in the existing item order.
+// urlTemplate is the URL of a page with PageNumberPlaceholder standing for its number,
+// e.g. "https://example.org/feed/{page}.json". Each page is wired to its neighbours:
+//   - JSON Feed: next_url on every page but the last, and feed_url for pages after the first
+//   - Atom: rel="next" and rel="previous" links (RFC 5005 paged feeds)
+//
+// The first page keeps feed.FeedURL. Pages share Item pointers with feed; the feed itself is not modified.
+func Paginate(feed *Feed, pageSize int, urlTemplate string) ([]*Feed, error) {
+	if feed == nil {
+		return nil, errors.New("paginate: nil feed")
+	}
+	if pageSize <= 0 {
+		return nil, errors.New("paginate: page size must be positive")
+	}
+	if !strings.Contains(urlTemplate, PageNumberPlaceholder) {
+		return nil, errors.New("paginate: url template must contain " + PageNumberPlaceholder)
+	}
+	pageURL := func(n int) string {
+		return strings.ReplaceAll(urlTemplate, PageNumberPlaceholder, strconv.Itoa(n))
+	}
+	count := (len(feed.Items) + pageSize - 1) / pageSize
+	if count == 0 {
+		count = 1
+	}
+	pages := make([]*Feed, 0, count)
+	for i := 0; i < count; i++ {
+		lo, hi := i*pageSize, (i+1)*pageSize
+		if hi > len(feed.Items) {
+			hi = len(feed.Items)
+		}
+		page := *feed
+		page.Items = append([]*Item(nil), feed.Items[lo:hi]...)
+		// next_url is owned by the paginator
+		page.Extensions = withoutNextURL(feed.Extensions)
+		page.options = withoutNextURL(feed.options)
+		num := i + 1
+		if num > 1 {
+			page.FeedURL = pageURL(num)
+			page.options = append(page.options, ExtensionNode{Name: "_atom:link", Attrs: map[string]string{"href": pageURL(num - 1), "rel": "previous"}})
+		}
+		if num < count {
+			page.options = append(page.options,
+				ExtensionNode{Name: "_json:next_url", Text: pageURL(num + 1)},
+				ExtensionNode{Name: "_atom:link", Attrs: map[string]string{"href": pageURL(num + 1), "rel": "next"}},
+			)
+		}
+		pages = append(pages, &page)
+	}
+	return pages, nil
+}
+
+func withoutNextURL(exts []ExtensionNode) []ExtensionNode {
+	var out []ExtensionNode
+	for _, n := range exts {
+		if !strings.EqualFold(strings.TrimSpace(n.Name), "_json:next_url") {
+			out = append(out, n)
+		}
+	}
+	return out
+}
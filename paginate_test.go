@@ -0,0 +1,75 @@
+package gofeedx_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func newPagedFeed(n int) *gofeedx.Feed {
+	f := &gofeedx.Feed{
+		Title:   "Archive",
+		Link:    &gofeedx.Link{Href: "https://example.org/"},
+		FeedURL: "https://example.org/feed.json",
+		Author:  &gofeedx.Author{Name: "A"},
+		Created: time.Now().UTC(),
+		Extensions: []gofeedx.ExtensionNode{
+			{Name: "_json:next_url", Text: "https://stale.example/"},
+		},
+	}
+	for i := 0; i < n; i++ {
+		f.Items = append(f.Items, &gofeedx.Item{Title: fmt.Sprintf("Post %d", i), ID: fmt.Sprintf("p%d", i), Created: f.Created})
+	}
+	return f
+}
+
+func TestPaginate_SplitsAndWiresLinks(t *testing.T) {
+	f := newPagedFeed(5)
+	pages, err := gofeedx.Paginate(f, 2, "https://example.org/feed/{page}.json")
+	mustNoErr(t, err, "Paginate failed")
+	if len(pages) != 3 {
+		t.Fatalf("expected 3 pages, got %d", len(pages))
+	}
+	if len(pages[0].Items) != 2 || len(pages[2].Items) != 1 || pages[2].Items[0].ID != "p4" {
+		t.Fatalf("unexpected page item distribution")
+	}
+	if len(f.Items) != 5 || len(f.Extensions) != 1 {
+		t.Fatalf("original feed must not be modified")
+	}
+
+	js1, err := gofeedx.ToJSON(pages[0])
+	mustNoErr(t, err, "ToJSON page 1 failed")
+	mustContain(t, js1, `"next_url": "https://example.org/feed/2.json"`, "expected next_url on first page")
+	mustContain(t, js1, `"feed_url": "https://example.org/feed.json"`, "expected first page to keep FeedURL")
+	mustNotContain(t, js1, "stale.example", "did not expect pre-existing next_url")
+
+	js3, err := gofeedx.ToJSON(pages[2])
+	mustNoErr(t, err, "ToJSON last page failed")
+	mustNotContain(t, js3, `"next_url"`, "did not expect next_url on last page")
+	mustContain(t, js3, `"feed_url": "https://example.org/feed/3.json"`, "expected page URL as feed_url")
+
+	atom2, err := gofeedx.ToAtom(pages[1])
+	mustNoErr(t, err, "ToAtom page 2 failed")
+	mustContain(t, atom2, `href="https://example.org/feed/1.json" rel="previous"`, "expected previous link")
+	mustContain(t, atom2, `href="https://example.org/feed/3.json" rel="next"`, "expected next link")
+	mustContain(t, atom2, `href="https://example.org/" rel="alternate"`, "expected alternate link to remain")
+}
+
+func TestPaginate_Errors(t *testing.T) {
+	if _, err := gofeedx.Paginate(nil, 1, "{page}"); err == nil {
+		t.Errorf("expected error for nil feed")
+	}
+	if _, err := gofeedx.Paginate(newPagedFeed(1), 0, "{page}"); err == nil {
+		t.Errorf("expected error for non-positive page size")
+	}
+	if _, err := gofeedx.Paginate(newPagedFeed(1), 1, "https://example.org/feed.json"); err == nil {
+		t.Errorf("expected error for template without placeholder")
+	}
+	pages, err := gofeedx.Paginate(newPagedFeed(0), 10, "{page}")
+	mustNoErr(t, err, "Paginate empty feed failed")
+	if len(pages) != 1 {
+		t.Errorf("expected a single page for empty feed, got %d", len(pages))
+	}
+}
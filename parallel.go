@@ -0,0 +1,170 @@
+package gofeedx
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/xml"
+	"errors"
+	"io"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// itemEncoder encodes one item or entry of a prebuilt XML document.
+type itemEncoder func(e *xml.Encoder) error
+
+// xmlItemEncoders returns the item/entry encoders of the document value x together with
+// their nesting depth, and detach, which replaces the items by a placeholder comment.
+// ok is false for values that have no items to split off.
+func xmlItemEncoders(x interface{}) (items []itemEncoder, depth int, detach func(placeholder string), ok bool) {
+	switch v := x.(type) {
+	case *RssFeedXml:
+		ch := v.Channel
+		if ch == nil {
+			return nil, 0, nil, false
+		}
+		chUse := UseCDATAFromExtensions(ch.Extra)
+		for _, it := range ch.Items {
+			if it == nil {
+				continue
+			}
+			use := CDATAUseForItem(chUse, it.Extra)
+			items = append(items, func(e *xml.Encoder) error { return it.marshalXML(e, xml.StartElement{}, use) })
+		}
+		return items, 2, func(p string) { ch.Items, ch.itemsPlaceholder = nil, p }, true
+	case *AtomFeed:
+		use := UseCDATAFromExtensions(v.Extra)
+		for _, en := range v.Entries {
+			if en == nil {
+				continue
+			}
+			entryUse := CDATAUseForItem(use, en.Extra)
+			items = append(items, func(e *xml.Encoder) error { return en.marshalXML(e, xml.StartElement{}, entryUse) })
+		}
+		return items, 1, func(p string) { v.Entries, v.entriesPlaceholder = nil, p }, true
+	case *PSPRSSRoot:
+		ch := v.Channel
+		if ch == nil {
+			return nil, 0, nil, false
+		}
+		for _, it := range ch.Items {
+			if it == nil {
+				continue
+			}
+			items = append(items, func(e *xml.Encoder) error { return e.Encode(it) })
+		}
+		return items, 2, func(p string) { ch.Items, ch.itemsPlaceholder = nil, p }, true
+	}
+	return nil, 0, nil, false
+}
+
+// writeXMLParallel renders like writeXMLDocument, but pre-serializes the items (or entries)
+// in up to workers goroutines and stitches the chunks into the document in their original
+// order. The output is byte-identical to the sequential path.
+func writeXMLParallel(src *Feed, build func() interface{}, w io.Writer, opts RenderOptions, workers int) error {
+	if workers < 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	x := build()
+	items, depth, detach, ok := xmlItemEncoders(x)
+	if cs, _ := opts.charset(); !ok || workers < 2 || len(items) < 2 || cs != charsetUTF8 {
+		return writeXMLDocument(src, func() interface{} { return x }, w, opts)
+	}
+	crlf, err := opts.crlf()
+	if err != nil {
+		return err
+	}
+	indent, err := opts.indent()
+	if err != nil {
+		return err
+	}
+
+	chunks, err := encodeItemsParallel(items, strings.Repeat(indent, depth), indent, workers)
+	if err != nil {
+		return err
+	}
+
+	// Render the document with a unique placeholder where the items go, then splice them in.
+	placeholder := "gofeedx:items:" + rand.Text()
+	detach(placeholder)
+	skeleton := opts
+	skeleton.Newline = ""
+	var doc bytes.Buffer
+	if err := writeXMLDocument(src, func() interface{} { return x }, &doc, skeleton); err != nil {
+		return err
+	}
+	// encoding/xml writes comments without indentation, so every chunk (which carries its own
+	// indentation) gets the line break the encoder would have written before the element.
+	token := []byte("<!--" + placeholder + "-->")
+	i := bytes.Index(doc.Bytes(), token)
+	if i < 0 {
+		return errors.New("render: parallel item placeholder not found")
+	}
+	sep := []byte("\n")
+	if indent == "" {
+		sep = nil
+	}
+	// the document and every chunk start between markup, so line breaks convert piecewise
+	fix := func(b []byte) []byte { return b }
+	if crlf {
+		fix = crlfMarkup
+		sep = crlfMarkup(sep)
+	}
+	if _, err := w.Write(fix(doc.Bytes()[:i])); err != nil {
+		return err
+	}
+	for _, chunk := range chunks {
+		if len(sep) > 0 {
+			if _, err := w.Write(sep); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(fix(chunk)); err != nil {
+			return err
+		}
+	}
+	_, err = w.Write(fix(doc.Bytes()[i+len(token):]))
+	return err
+}
+
+// encodeItemsParallel encodes items into separate chunks, each indented as if it were written
+// at the given prefix, using up to workers goroutines.
+func encodeItemsParallel(items []itemEncoder, prefix, indent string, workers int) ([][]byte, error) {
+	if workers > len(items) {
+		workers = len(items)
+	}
+	chunks := make([][]byte, len(items))
+	errs := make([]error, len(items))
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				j := int(next.Add(1)) - 1
+				if j >= len(items) {
+					return
+				}
+				var buf bytes.Buffer
+				e := xml.NewEncoder(&buf)
+				e.Indent(prefix, indent)
+				if err := items[j](e); err != nil {
+					errs[j] = err
+					continue
+				}
+				errs[j] = e.Flush()
+				chunks[j] = buf.Bytes()
+			}
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return chunks, nil
+}
@@ -0,0 +1,29 @@
+package gofeedx
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// htmlBreakPattern matches tags that end a line or block of text.
+var htmlBreakPattern = regexp.MustCompile(`(?i)<br\s*/?>|</?(p|div|li|ul|ol|h[1-6]|blockquote|pre|tr|table|section|article)\b[^>]*>`)
+
+// HTMLToText derives plain text from HTML: script and style elements and comments are
+// removed, block-level tags and <br> become line breaks, other tags are dropped and entities
+// are unescaped. Runs of spaces collapse and blank lines are dropped.
+func HTMLToText(s string) string {
+	if strings.TrimSpace(s) == "" {
+		return ""
+	}
+	s = htmlScriptPattern.ReplaceAllString(s, "")
+	s = htmlBreakPattern.ReplaceAllString(s, "\n")
+	s = html.UnescapeString(htmlMarkupPattern.ReplaceAllString(s, ""))
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
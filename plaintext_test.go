@@ -0,0 +1,31 @@
+package gofeedx_test
+
+import (
+	"testing"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestHTMLToText(t *testing.T) {
+	in := "<h1>Title</h1>\n<p>Fish &amp; <b>chips</b><br>today</p><script>x()</script><!-- note --><ul><li>one</li><li>two</li></ul>"
+	want := "Title\nFish & chips\ntoday\none\ntwo"
+	if got := gofeedx.HTMLToText(in); got != want {
+		t.Errorf("HTMLToText = %q, want %q", got, want)
+	}
+}
+
+func TestJSONDerivedContentText(t *testing.T) {
+	b := gofeedx.NewFeed("T").WithLink("https://example.org/").WithJSONDerivedContentText()
+	b.AddItem(gofeedx.NewItem("html").WithID("1").WithContentHTML("<p>Hello <i>world</i></p>"))
+	b.AddItem(gofeedx.NewItem("summary").WithID("2").WithDescription("It&#39;s plain"))
+	b.AddItem(gofeedx.NewItem("explicit").WithID("3").WithContentHTML("<p>x</p>").WithJSONContentText("Mine"))
+	f, err := b.Build()
+	mustNoErr(t, err, "Build failed")
+
+	out, err := gofeedx.ToJSON(f)
+	mustNoErr(t, err, "ToJSON failed")
+	mustContain(t, out, `"content_text": "Hello world"`, "expected text derived from content_html")
+	mustContain(t, out, `"content_text": "It's plain"`, "expected text derived from summary")
+	mustContain(t, out, `"content_text": "Mine"`, "explicit content_text must win")
+	mustNotContain(t, out, "derive_content_text", "marker must not leak")
+}
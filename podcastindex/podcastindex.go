@@ -0,0 +1,169 @@
+// Package podcastindex is an optional client for the Podcast Index API
+// (https://podcastindex-org.github.io/docs-api/). It checks that the podcast:guid gofeedx
+// computes for a feed matches the one registered in the index, catching GUID drift after
+// a feed URL change.
+package podcastindex
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+// DefaultBaseURL is the Podcast Index API root.
+const DefaultBaseURL = "https://api.podcastindex.org/api/1.0"
+
+// Client queries the Podcast Index API. APIKey and APISecret are required; the other
+// fields are optional.
+type Client struct {
+	APIKey     string
+	APISecret  string
+	UserAgent  string           // defaults to "gofeedx"
+	BaseURL    string           // defaults to DefaultBaseURL
+	HTTPClient *http.Client     // defaults to http.DefaultClient
+	Now        func() time.Time // defaults to time.Now; used for the X-Auth-Date header
+}
+
+// Podcast is the subset of a Podcast Index feed record needed for GUID checks.
+type Podcast struct {
+	ID          int64  `json:"id"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	PodcastGUID string `json:"podcastGuid"`
+}
+
+// GUIDCheck is the result of comparing a feed's podcast:guid with the index.
+type GUIDCheck struct {
+	Computed string   // podcast:guid gofeedx emits for the feed
+	Podcast  *Podcast // index record for the feed URL, nil when not registered
+	Warnings []string // empty when the index agrees with Computed
+}
+
+// PodcastByFeedURL looks up the index record for feedURL. It returns nil and no error
+// when the feed is not in the index.
+func (c *Client) PodcastByFeedURL(ctx context.Context, feedURL string) (*Podcast, error) {
+	return c.lookup(ctx, "/podcasts/byfeedurl", url.Values{"url": {feedURL}})
+}
+
+// PodcastByGUID looks up the index record for a podcast:guid. It returns nil and no error
+// when the GUID is not in the index.
+func (c *Client) PodcastByGUID(ctx context.Context, guid string) (*Podcast, error) {
+	return c.lookup(ctx, "/podcasts/byguid", url.Values{"guid": {guid}})
+}
+
+// VerifyGUID compares gofeedx.PodcastGUID(feed) with the index record for feed.FeedURL
+// and the record registered under that GUID, and returns warnings on any mismatch.
+func (c *Client) VerifyGUID(ctx context.Context, feed *gofeedx.Feed) (*GUIDCheck, error) {
+	if feed == nil || strings.TrimSpace(feed.FeedURL) == "" {
+		return nil, errors.New("podcastindex: feed url required")
+	}
+	check := &GUIDCheck{Computed: gofeedx.PodcastGUID(feed)}
+	byURL, err := c.PodcastByFeedURL(ctx, strings.TrimSpace(feed.FeedURL))
+	if err != nil {
+		return nil, err
+	}
+	check.Podcast = byURL
+	switch {
+	case byURL == nil:
+		check.Warnings = append(check.Warnings, fmt.Sprintf("feed url %q is not registered in the Podcast Index", feed.FeedURL))
+	case byURL.PodcastGUID == "":
+		check.Warnings = append(check.Warnings, fmt.Sprintf("index record %d has no podcast guid", byURL.ID))
+	case !strings.EqualFold(byURL.PodcastGUID, check.Computed):
+		check.Warnings = append(check.Warnings, fmt.Sprintf("podcast guid %q differs from index guid %q", check.Computed, byURL.PodcastGUID))
+	}
+	if check.Computed == "" || (byURL != nil && strings.EqualFold(byURL.PodcastGUID, check.Computed)) {
+		return check, nil
+	}
+	byGUID, err := c.PodcastByGUID(ctx, check.Computed)
+	if err != nil {
+		return nil, err
+	}
+	if byGUID != nil && (byURL == nil || byGUID.ID != byURL.ID) {
+		check.Warnings = append(check.Warnings, fmt.Sprintf("podcast guid %q is registered to index record %d (%s)", check.Computed, byGUID.ID, byGUID.URL))
+	}
+	return check, nil
+}
+
+type lookupResponse struct {
+	Status      json.RawMessage `json:"status"`
+	Description string          `json:"description"`
+	Feed        json.RawMessage `json:"feed"`
+}
+
+func (c *Client) lookup(ctx context.Context, path string, query url.Values) (*Podcast, error) {
+	if strings.TrimSpace(c.APIKey) == "" || strings.TrimSpace(c.APISecret) == "" {
+		return nil, errors.New("podcastindex: api key and secret required")
+	}
+	base := strings.TrimRight(strings.TrimSpace(c.BaseURL), "/")
+	if base == "" {
+		base = DefaultBaseURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	c.authorize(req)
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("podcastindex: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("podcastindex: read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, errors.New("podcastindex: api responded " + resp.Status)
+	}
+	var r lookupResponse
+	if err := json.Unmarshal(body, &r); err != nil {
+		return nil, fmt.Errorf("podcastindex: decode response: %w", err)
+	}
+	if s := strings.Trim(string(r.Status), `"`); s != "true" {
+		return nil, fmt.Errorf("podcastindex: lookup failed: %s", r.Description)
+	}
+	// The API answers "feed": [] (or omits it) when nothing matches.
+	if f := bytes.TrimSpace(r.Feed); len(f) == 0 || f[0] != '{' {
+		return nil, nil
+	}
+	var p Podcast
+	if err := json.Unmarshal(r.Feed, &p); err != nil {
+		return nil, fmt.Errorf("podcastindex: decode feed: %w", err)
+	}
+	return &p, nil
+}
+
+// authorize sets the Podcast Index auth headers: the key, the request time, and
+// sha1(key+secret+time) as Authorization.
+func (c *Client) authorize(req *http.Request) {
+	now := time.Now
+	if c.Now != nil {
+		now = c.Now
+	}
+	date := strconv.FormatInt(now().Unix(), 10)
+	sum := sha1.Sum([]byte(c.APIKey + c.APISecret + date))
+	ua := strings.TrimSpace(c.UserAgent)
+	if ua == "" {
+		ua = "gofeedx"
+	}
+	req.Header.Set("User-Agent", ua)
+	req.Header.Set("X-Auth-Key", c.APIKey)
+	req.Header.Set("X-Auth-Date", date)
+	req.Header.Set("Authorization", hex.EncodeToString(sum[:]))
+}
@@ -0,0 +1,95 @@
+package podcastindex_test
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+	"github.com/jo-hoe/gofeedx/podcastindex"
+)
+
+func newIndexServer(t *testing.T, byURL, byGUID string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sum := sha1.Sum([]byte("key" + "secret" + "1700000000"))
+		if r.Header.Get("X-Auth-Key") != "key" || r.Header.Get("X-Auth-Date") != "1700000000" || r.Header.Get("Authorization") != hex.EncodeToString(sum[:]) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/podcasts/byfeedurl"):
+			_, _ = fmt.Fprintf(w, `{"status":"true","feed":%s}`, byURL)
+		case strings.HasSuffix(r.URL.Path, "/podcasts/byguid"):
+			_, _ = fmt.Fprintf(w, `{"status":"true","feed":%s}`, byGUID)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func newClient(baseURL string) *podcastindex.Client {
+	return &podcastindex.Client{
+		APIKey:    "key",
+		APISecret: "secret",
+		BaseURL:   baseURL,
+		Now:       func() time.Time { return time.Unix(1700000000, 0) },
+	}
+}
+
+func TestVerifyGUID_Match(t *testing.T) {
+	feed := &gofeedx.Feed{FeedURL: "https://example.org/podcast.xml"}
+	guid := gofeedx.PodcastGUID(feed)
+	srv := newIndexServer(t, fmt.Sprintf(`{"id":7,"url":"https://example.org/podcast.xml","podcastGuid":%q}`, guid), `[]`)
+	defer srv.Close()
+
+	check, err := newClient(srv.URL).VerifyGUID(context.Background(), feed)
+	if err != nil {
+		t.Fatalf("VerifyGUID failed: %v", err)
+	}
+	if len(check.Warnings) != 0 || check.Podcast == nil || check.Podcast.ID != 7 {
+		t.Fatalf("expected clean match, got %+v", check)
+	}
+}
+
+func TestVerifyGUID_Mismatch(t *testing.T) {
+	feed := &gofeedx.Feed{FeedURL: "https://example.org/new.xml"}
+	srv := newIndexServer(t,
+		`{"id":7,"url":"https://example.org/new.xml","podcastGuid":"917393e3-1b1e-5cef-ace4-edaa54e1f810"}`,
+		`{"id":9,"url":"https://example.org/old.xml","podcastGuid":"x"}`)
+	defer srv.Close()
+
+	check, err := newClient(srv.URL).VerifyGUID(context.Background(), feed)
+	if err != nil {
+		t.Fatalf("VerifyGUID failed: %v", err)
+	}
+	if len(check.Warnings) != 2 {
+		t.Fatalf("expected guid mismatch and foreign registration warnings, got %v", check.Warnings)
+	}
+}
+
+func TestVerifyGUID_NotRegistered(t *testing.T) {
+	srv := newIndexServer(t, `[]`, `[]`)
+	defer srv.Close()
+
+	check, err := newClient(srv.URL).VerifyGUID(context.Background(), &gofeedx.Feed{FeedURL: "https://example.org/p.xml"})
+	if err != nil {
+		t.Fatalf("VerifyGUID failed: %v", err)
+	}
+	if check.Podcast != nil || len(check.Warnings) != 1 || !strings.Contains(check.Warnings[0], "not registered") {
+		t.Fatalf("expected not-registered warning, got %+v", check)
+	}
+}
+
+func TestClient_RequiresCredentials(t *testing.T) {
+	c := &podcastindex.Client{}
+	if _, err := c.PodcastByGUID(context.Background(), "g"); err == nil {
+		t.Fatalf("expected error without credentials")
+	}
+}
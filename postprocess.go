@@ -0,0 +1,26 @@
+package gofeedx
+
+// PostProcessor transforms rendered output for a given profile, e.g. to append a comment or
+// rewrite hosts in RSS/Atom documents. It receives the complete document an Encoder produced
+// (see EncodeOptions.PostProcessors) and returns the bytes to use instead.
+type PostProcessor func(profile Profile, data []byte) ([]byte, error)
+
+// applyPostProcessors runs procs over rendered data in order. data is returned as is when
+// procs is empty; otherwise the chain runs on a copy, so processors never see (or retain) a
+// caller's reusable buffer.
+func applyPostProcessors(procs []PostProcessor, profile Profile, data []byte) ([]byte, error) {
+	if len(procs) == 0 {
+		return data, nil
+	}
+	data = append([]byte(nil), data...)
+	var err error
+	for _, p := range procs {
+		if p == nil {
+			continue
+		}
+		if data, err = p(profile, data); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
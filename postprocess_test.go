@@ -0,0 +1,72 @@
+package gofeedx_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestPostProcessor_InjectsStylesheetForXMLProfiles(t *testing.T) {
+	pi := []byte(`<?xml-stylesheet type="text/xsl" href="/feed.xsl"?>`)
+	var seen []gofeedx.Profile
+	enc := gofeedx.NewEncoderWithOptions(gofeedx.EncodeOptions{PostProcessors: []gofeedx.PostProcessor{
+		func(p gofeedx.Profile, data []byte) ([]byte, error) {
+			seen = append(seen, p)
+			if p == gofeedx.ProfileJSON {
+				return data, nil
+			}
+			i := bytes.Index(data, []byte("?>"))
+			out := append([]byte{}, data[:i+2]...)
+			out = append(out, pi...)
+			return append(out, data[i+2:]...), nil
+		},
+	}})
+
+	f := newRSSBaseFeed()
+	f.Items = append(f.Items, newRSSBaseItem())
+
+	var rss, atom, js bytes.Buffer
+	mustNoErr(t, enc.EncodeRSS(f, &rss), "EncodeRSS failed")
+	mustContain(t, rss.String(), "?>"+string(pi)+"<rss", "expected stylesheet PI after the XML declaration")
+	mustNoErr(t, enc.EncodeAtom(f, &atom), "EncodeAtom failed")
+	mustContain(t, atom.String(), string(pi), "expected stylesheet PI in Atom output")
+	mustNoErr(t, enc.EncodeJSON(f, &js), "EncodeJSON failed")
+	mustNotContain(t, js.String(), "xml-stylesheet", "did not expect stylesheet PI in JSON output")
+
+	want := []gofeedx.Profile{gofeedx.ProfileRSS, gofeedx.ProfileAtom, gofeedx.ProfileJSON}
+	if len(seen) != len(want) {
+		t.Fatalf("post-processor calls = %v, want %v", seen, want)
+	}
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Errorf("call %d profile = %v, want %v", i, seen[i], want[i])
+		}
+	}
+
+	plain, err := gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS failed")
+	mustNotContain(t, plain, "xml-stylesheet", "post-processors of one Encoder must not affect other renders")
+}
+
+func TestPostProcessor_Error(t *testing.T) {
+	f := newRSSBaseFeed()
+	f.Items = append(f.Items, newRSSBaseItem())
+
+	boom := errors.New("boom")
+	enc := gofeedx.NewEncoderWithOptions(gofeedx.EncodeOptions{PostProcessors: []gofeedx.PostProcessor{
+		func(gofeedx.Profile, []byte) ([]byte, error) { return nil, boom },
+	}})
+	var buf bytes.Buffer
+	if err := enc.EncodeRSS(f, &buf); !errors.Is(err, boom) {
+		t.Fatalf("expected post-processor error, got %v", err)
+	}
+
+	buf.Reset()
+	mustNoErr(t, gofeedx.NewEncoder().EncodeRSS(f, &buf), "EncodeRSS without post-processors failed")
+	if !strings.HasPrefix(buf.String(), "<?xml") {
+		t.Errorf("expected unmodified output without post-processors")
+	}
+}
@@ -0,0 +1,81 @@
+package gofeedx
+
+import "strings"
+
+// AuthorPrivacy controls how author email addresses appear in RSS output, where
+// managingEditor and item author are email-based. Atom and JSON Feed keep author names
+// either way.
+type AuthorPrivacy int
+
+const (
+	// AuthorPrivacyOff writes email addresses as given (the default).
+	AuthorPrivacyOff AuthorPrivacy = iota
+	// AuthorPrivacyOmit drops email addresses: managingEditor is left out and item authors
+	// are written by name as dc:creator.
+	AuthorPrivacyOmit
+	// AuthorPrivacyMask keeps the address shape but hides the local part, e.g.
+	// "j***@example.com (Jane)".
+	AuthorPrivacyMask
+)
+
+const authorPrivacyMarker = "_rss:authorPrivacy"
+
+// WithAuthorPrivacy omits or masks author email addresses in RSS managingEditor and item
+// author output. Unknown modes are ignored.
+func (b *FeedBuilder) WithAuthorPrivacy(p AuthorPrivacy) *FeedBuilder {
+	switch p {
+	case AuthorPrivacyOmit:
+		return b.withOption(ExtensionNode{Name: authorPrivacyMarker, Text: "omit"})
+	case AuthorPrivacyMask:
+		return b.withOption(ExtensionNode{Name: authorPrivacyMarker, Text: "mask"})
+	}
+	return b
+}
+
+func handleRSSAuthorPrivacy(out *rssChannelExtras, n ExtensionNode) {
+	switch textLowerTrim(n.Text) {
+	case "omit":
+		out.authorPrivacy = AuthorPrivacyOmit
+	case "mask":
+		out.authorPrivacy = AuthorPrivacyMask
+	}
+}
+
+// rssAuthor returns the managingEditor/author text for a under p.
+func (p AuthorPrivacy) rssAuthor(a *Author) string {
+	switch p {
+	case AuthorPrivacyOmit:
+		return ""
+	case AuthorPrivacyMask:
+		if a == nil || strings.TrimSpace(a.Email) == "" {
+			return ""
+		}
+		masked := *a
+		masked.Email = maskEmail(a.Email)
+		return rssAuthorString(&masked)
+	}
+	return rssAuthorString(a)
+}
+
+// applyToRSSItem rewrites the item author written by newRssItem according to p.
+func (p AuthorPrivacy) applyToRSSItem(item *RssItem, a *Author) {
+	if p == AuthorPrivacyOff || a == nil {
+		return
+	}
+	item.Author = CData(p.rssAuthor(a))
+	if p == AuthorPrivacyOmit {
+		item.Creator = strings.TrimSpace(a.Name)
+	}
+}
+
+// maskEmail keeps the first character of the local part and the domain:
+// "jane@example.com" becomes "j***@example.com".
+func maskEmail(email string) string {
+	email = strings.TrimSpace(email)
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return "***"
+	}
+	first := []rune(email[:at])[0]
+	return string(first) + "***" + email[at:]
+}
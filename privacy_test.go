@@ -0,0 +1,45 @@
+package gofeedx_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func buildPrivacyFeed(t *testing.T, p gofeedx.AuthorPrivacy) *gofeedx.Feed {
+	t.Helper()
+	b := gofeedx.NewFeed("T").
+		WithLink("https://example.com/").
+		WithDescription("d").
+		WithAuthor("Jane", "jane@example.com").
+		WithAuthorPrivacy(p)
+	b.AddItem(gofeedx.NewItem("e1").WithID("1").WithCreated(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)).
+		WithAuthor("Joe", "joe@example.com"))
+	f, err := b.Build()
+	mustNoErr(t, err, "Build")
+	return f
+}
+
+func TestWithAuthorPrivacy_Omit(t *testing.T) {
+	f := buildPrivacyFeed(t, gofeedx.AuthorPrivacyOmit)
+	rss, err := gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS")
+	mustNotContain(t, rss, "@example.com", "emails must be omitted from RSS")
+	mustNotContain(t, rss, "managingEditor", "managingEditor must be omitted")
+	mustContain(t, rss, "<dc:creator>Joe</dc:creator>", "expected item author name as dc:creator")
+	mustContain(t, rss, `xmlns:dc=`, "expected Dublin Core namespace")
+
+	js, err := gofeedx.ToJSON(f)
+	mustNoErr(t, err, "ToJSON")
+	mustContain(t, js, `"name": "Jane"`, "JSON must keep author names")
+}
+
+func TestWithAuthorPrivacy_Mask(t *testing.T) {
+	f := buildPrivacyFeed(t, gofeedx.AuthorPrivacyMask)
+	rss, err := gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS")
+	mustNotContain(t, rss, "jane@example.com", "emails must be masked")
+	mustContain(t, rss, "j***@example.com (Jane)", "expected masked managingEditor")
+	mustContain(t, rss, "j***@example.com (Joe)", "expected masked item author")
+}
@@ -10,7 +10,10 @@ import (
 	"encoding/xml"
 	"errors"
 	"fmt"
+	"math"
 	"net/url"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -74,6 +77,7 @@ type PSPChannel struct {
 
 	// Recommended and optional standard RSS fields
 	Copyright string `xml:"copyright,omitempty"`
+	Generator string `xml:"generator,omitempty"`
 
 	// atom:link rel="self"
 	AtomSelf *PSPAtomLink `xml:"atom:link,omitempty"`
@@ -81,22 +85,21 @@ type PSPChannel struct {
 	ItunesExplicit  *bool
 	ItunesType      string // "episodic" | "serial"
 	ItunesComplete  bool   // emits "yes" when true
-	ItunesImageHref string // overrides or supplements image href from Feed.Image.Url
+	ItunesImageHref string // overrides or supplements image href from Feed.Images.Artwork / Feed.Image.Url
 
 	// podcast namespace
-	PodcastLocked  *bool // emits "yes"/"no"
-	PodcastTXT     *PodcastTXT
-	PodcastFunding *PodcastFunding
+	PodcastLocked  *bool             // emits "yes"/"no"
+	PodcastTXT     []*PodcastTXT     // one per purpose, e.g. applepodcastsverify and a Spotify token
+	PodcastFunding []*PodcastFunding // one per support link (Patreon, PayPal, memberships, ...)
 
 	Extra []ExtensionNode `xml:",any"`
+
+	itemsPlaceholder string // set by parallel encoding in place of Items (see writeXMLParallel)
 }
 
 // ToPSP renders the feed to a PSP-1 compliant RSS string after validating ProfilePSP.
 func ToPSP(feed *Feed) (string, error) {
-	if feed == nil {
-		return "", errors.New("nil feed")
-	}
-	return ToXML(&PSP{feed})
+	return ToPSPWithOptions(feed, RenderOptions{})
 }
 
 // MarshalXML customizes channel XML to avoid emitting untagged struct fields and to include extension nodes.
@@ -140,7 +143,7 @@ func (ch *PSPChannel) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	if err := e.EncodeToken(start.End()); err != nil {
 		return err
 	}
-	return e.Flush()
+	return nil
 }
 
 // Internal helpers to reduce cyclomatic complexity of MarshalXML.
@@ -170,7 +173,13 @@ func (ch *PSPChannel) encodeCoreText(e *xml.Encoder, use bool) error {
 	if err := ch.encodeTextIfSet(e, "link", ch.Link, use); err != nil {
 		return err
 	}
-	return ch.encodeTextIfSet(e, "description", ch.Description, use)
+	if err := ch.encodeTextIfSet(e, "description", ch.Description, use); err != nil {
+		return err
+	}
+	if err := ch.encodeTextIfSet(e, "copyright", ch.Copyright, use); err != nil {
+		return err
+	}
+	return ch.encodeTextIfSet(e, "generator", ch.Generator, use)
 }
 
 func (ch *PSPChannel) encodeDates(e *xml.Encoder, use bool) error {
@@ -201,20 +210,35 @@ func (ch *PSPChannel) encodePodcastLocked(e *xml.Encoder) error {
 }
 
 func (ch *PSPChannel) encodePodcastTXT(e *xml.Encoder) error {
-	if ch.PodcastTXT != nil {
-		return e.Encode(ch.PodcastTXT)
+	for _, t := range ch.PodcastTXT {
+		if t == nil {
+			continue
+		}
+		if err := e.Encode(t); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 func (ch *PSPChannel) encodePodcastFunding(e *xml.Encoder) error {
-	if ch.PodcastFunding != nil {
-		return e.Encode(ch.PodcastFunding)
+	for _, f := range ch.PodcastFunding {
+		if f == nil {
+			continue
+		}
+		if err := e.Encode(f); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
 func (ch *PSPChannel) encodeItems(e *xml.Encoder) error {
+	if ch.itemsPlaceholder != "" {
+		if err := e.EncodeToken(xml.Comment(ch.itemsPlaceholder)); err != nil {
+			return err
+		}
+	}
 	for _, it := range ch.Items {
 		if it == nil {
 			continue
@@ -253,7 +277,7 @@ func (ch *PSPChannel) encodeExtensions(e *xml.Encoder) error {
 		if IsInternalExtensionName(n.Name) {
 			continue
 		}
-		if err := e.Encode(n); err != nil {
+		if err := n.MarshalXML(e, xml.StartElement{}); err != nil {
 			return err
 		}
 	}
@@ -306,10 +330,6 @@ func encodePositiveIntIfSet(e *xml.Encoder, name string, v int) error {
 	return nil
 }
 
-func isYes(s string) bool {
-	return strings.EqualFold(strings.TrimSpace(s), "yes")
-}
-
 func processExtensions(exts []ExtensionNode, handlers map[string]func(ExtensionNode) bool) (extras []ExtensionNode) {
 	for _, n := range exts {
 		name := strings.TrimSpace(strings.ToLower(n.Name))
@@ -323,6 +343,12 @@ func processExtensions(exts []ExtensionNode, handlers map[string]func(ExtensionN
 	return extras
 }
 
+// isAbsoluteURL reports whether s parses as a URL with a scheme and host.
+func isAbsoluteURL(s string) bool {
+	u, err := url.Parse(strings.TrimSpace(s))
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
 func hasHTML(s string) bool {
 	// Heuristic: contains any angle brackets
 	return strings.Contains(s, "<") && strings.Contains(s, ">")
@@ -378,6 +404,20 @@ type PSPTranscript struct {
 	Rel      string   `xml:"rel,attr,omitempty"`
 }
 
+// PSPPodcastSeason emits podcast:season with an optional name attr
+type PSPPodcastSeason struct {
+	XMLName xml.Name `xml:"podcast:season"`
+	Name    string   `xml:"name,attr,omitempty"` // <= 128 characters
+	Number  int      `xml:",chardata"`           // > 0
+}
+
+// PSPPodcastEpisode emits podcast:episode with an optional display attr
+type PSPPodcastEpisode struct {
+	XMLName xml.Name `xml:"podcast:episode"`
+	Display string   `xml:"display,attr,omitempty"` // <= 32 characters
+	Number  string   `xml:",chardata"`              // positive decimal, e.g. "204" or "204.5"
+}
+
 /*
 PSPItem extends RSS <item> with PSP/iTunes item fields.
 
@@ -403,7 +443,10 @@ OPTIONAL (supported when relevant):
 - <itunes:episode>                   (ItunesEpisode) — non-zero integer; REQUIRED for serial podcasts
 - <itunes:season>                    (ItunesSeason) — non-zero integer
 - <itunes:episodeType>               (ItunesEpisodeType) — "full" (default), "trailer", or "bonus"
+- <itunes:author>                    (ItunesAuthor) — episode author name, overrides the channel author
 - <itunes:block>                     (ItunesBlock) — "yes"
+- <podcast:season [name="..."]>      (PodcastSeason) — non-zero integer
+- <podcast:episode [display="..."]>  (PodcastEpisode) — positive decimal
 */
 type PSPItem struct {
 	Title             CData            `xml:"title"`                        // required
@@ -418,8 +461,11 @@ type PSPItem struct {
 	ItunesEpisode     int              `xml:"itunes:episode,omitempty"`     // > 0
 	ItunesSeason      int              `xml:"itunes:season,omitempty"`      // > 0
 	ItunesEpisodeType string           `xml:"itunes:episodeType,omitempty"` // "full" | "trailer" | "bonus"
+	ItunesAuthor      string           `xml:"itunes:author,omitempty"`      // episode author name
 	ItunesBlock       string           `xml:"itunes:block,omitempty"`       // "yes"
 	Transcripts       []*PSPTranscript `xml:"podcast:transcript,omitempty"` // multiple allowed
+	PodcastSeason     *PSPPodcastSeason
+	PodcastEpisode    *PSPPodcastEpisode
 
 	XMLName xml.Name    `xml:"item"`
 	Content *RssContent `xml:"content:encoded,omitempty"` // optional HTML content in CDATA (content namespace)
@@ -451,8 +497,10 @@ func (it *PSPItem) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		func(enc *xml.Encoder, use bool) error { return it.encodeItunesEpisode(enc) },
 		func(enc *xml.Encoder, use bool) error { return it.encodeItunesSeason(enc) },
 		func(enc *xml.Encoder, use bool) error { return it.encodeItunesEpisodeType(enc) },
+		func(enc *xml.Encoder, use bool) error { return it.encodeItunesAuthor(enc) },
 		func(enc *xml.Encoder, use bool) error { return it.encodeItunesBlock(enc) },
 		func(enc *xml.Encoder, use bool) error { return it.encodeTranscripts(enc) },
+		func(enc *xml.Encoder, use bool) error { return it.encodePodcastSeasonEpisode(enc) },
 		func(enc *xml.Encoder, use bool) error { return it.encodeExtras(enc) },
 	}
 	for _, step := range steps {
@@ -464,7 +512,7 @@ func (it *PSPItem) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	if err := e.EncodeToken(start.End()); err != nil {
 		return err
 	}
-	return e.Flush()
+	return nil
 }
 
 func (it *PSPItem) encodeTitle(e *xml.Encoder, use bool) error {
@@ -531,6 +579,10 @@ func (it *PSPItem) encodeItunesEpisodeType(e *xml.Encoder) error {
 	return encodeStringIfSet(e, "itunes:episodeType", it.ItunesEpisodeType)
 }
 
+func (it *PSPItem) encodeItunesAuthor(e *xml.Encoder) error {
+	return encodeStringIfSet(e, "itunes:author", it.ItunesAuthor)
+}
+
 func (it *PSPItem) encodeItunesBlock(e *xml.Encoder) error {
 	return encodeStringIfSet(e, "itunes:block", it.ItunesBlock)
 }
@@ -547,12 +599,24 @@ func (it *PSPItem) encodeTranscripts(e *xml.Encoder) error {
 	return nil
 }
 
+func (it *PSPItem) encodePodcastSeasonEpisode(e *xml.Encoder) error {
+	if it.PodcastSeason != nil {
+		if err := e.Encode(it.PodcastSeason); err != nil {
+			return err
+		}
+	}
+	if it.PodcastEpisode != nil {
+		return e.Encode(it.PodcastEpisode)
+	}
+	return nil
+}
+
 func (it *PSPItem) encodeExtras(e *xml.Encoder) error {
 	for _, n := range it.Extra {
 		if IsInternalExtensionName(n.Name) {
 			continue
 		}
-		if err := e.Encode(n); err != nil {
+		if err := n.MarshalXML(e, xml.StartElement{}); err != nil {
 			return err
 		}
 	}
@@ -576,31 +640,37 @@ func ValidatePSP(f *Feed) error {
 	if err := validatePSPChannel(f); err != nil {
 		return err
 	}
-	return validatePSPItems(f)
+	if err := validatePSPItems(f); err != nil {
+		return err
+	}
+	return validatePSPExtensions(f)
 }
 
 func validatePSPChannel(f *Feed) error {
 	if strings.TrimSpace(f.Title) == "" {
-		return errors.New("psp: channel title required")
+		return feedError(ProfilePSP, "Title", ErrMissingTitle, "psp: channel title required")
 	}
 	if strings.TrimSpace(f.Description) == "" {
-		return errors.New("psp: channel description required")
+		return feedError(ProfilePSP, "Description", ErrMissingDescription, "psp: channel description required")
 	}
 	// PSP-1: channel description maximum 4000 bytes
 	if len([]byte(f.Description)) > 4000 {
-		return errors.New("psp: channel description must be <= 4000 bytes")
+		return feedError(ProfilePSP, "Description", ErrTooLong, "psp: channel description must be <= 4000 bytes")
 	}
 	if f.Link == nil || strings.TrimSpace(f.Link.Href) == "" {
-		return errors.New("psp: channel link required")
+		return feedError(ProfilePSP, "Link", ErrMissingLink, "psp: channel link required").at("Link.Href")
 	}
 	if strings.TrimSpace(f.Language) == "" {
-		return errors.New("psp: channel language required")
+		return feedError(ProfilePSP, "Language", ErrMissingLanguage, "psp: channel language required")
+	}
+	if err := validateLanguageIfSet(ProfilePSP, -1, f.Language); err != nil {
+		return err
 	}
 	if len(f.Categories) == 0 {
-		return errors.New("psp: at least one category required")
+		return feedError(ProfilePSP, "Categories", ErrMissingCategory, "psp: at least one category required")
 	}
 	if strings.TrimSpace(f.FeedURL) == "" {
-		return errors.New("psp: atom:link rel=self required")
+		return feedError(ProfilePSP, "FeedURL", ErrMissingFeedURL, "psp: atom:link rel=self required")
 	}
 	return nil
 }
@@ -608,23 +678,282 @@ func validatePSPChannel(f *Feed) error {
 func validatePSPItems(f *Feed) error {
 	for i, it := range f.Items {
 		if strings.TrimSpace(it.Title) == "" {
-			return fmt.Errorf("psp: item[%d] title required", i)
+			return itemError(ProfilePSP, i, "Title", ErrMissingTitle, "psp: item[%d] title required", i)
 		}
 		if it.Enclosure == nil || strings.TrimSpace(it.Enclosure.Url) == "" || strings.TrimSpace(it.Enclosure.Type) == "" || it.Enclosure.Length <= 0 {
-			return fmt.Errorf("psp: item[%d] enclosure url/type/length required", i)
+			return itemError(ProfilePSP, i, "Enclosure", &ErrInvalidEnclosure{Index: i}, "psp: item[%d] enclosure url/type/length required", i).at(enclosurePath(it.Enclosure))
 		}
 		// GUID required (can be guid with isPermaLink=false)
 		if strings.TrimSpace(it.ID) == "" {
-			return fmt.Errorf("psp: item[%d] guid (ID) required", i)
+			return itemError(ProfilePSP, i, "ID", ErrMissingID, "psp: item[%d] guid (ID) required", i)
 		}
 		// PSP-1: item description maximum 4000 bytes (if present)
 		if len(it.Description) > 0 && len([]byte(it.Description)) > 4000 {
-			return fmt.Errorf("psp: item[%d] description must be <= 4000 bytes", i)
+			return itemError(ProfilePSP, i, "Description", ErrTooLong, "psp: item[%d] description must be <= 4000 bytes", i)
 		}
 	}
 	return nil
 }
 
+// validatePSPExtensions runs the PSP extension mapping on channel and item extensions and
+// reports the first recognized node whose value would be dropped by the writer
+// (e.g. itunes:episode "abc", itunes:explicit "maybe", podcast:transcript without a valid url).
+func validatePSPExtensions(f *Feed) error {
+	if n, ok := firstRejectedExtension(f.allExtensions(), channelExtensionHandlers(&PSPChannel{})); ok {
+		return feedError(ProfilePSP, "Extensions", ErrInvalidExtension, "psp: channel extension %s has an invalid value", describeExtensionNode(n)).at(extensionPath(n.Name))
+	}
+	if n, problem := firstInvalidPodcastNode(f.allExtensions()); problem != "" {
+		return feedError(ProfilePSP, "Extensions", ErrInvalidExtension, "psp: channel extension %s %s", describeExtensionNode(n), problem).at(extensionPath(n.Name))
+	}
+	if hasExtensionNamed(f.Extensions, "podcast:transcript") {
+		return feedError(ProfilePSP, "Extensions", ErrInvalidExtension, "psp: podcast:transcript is only allowed at item scope; add trailer transcripts to the trailer's item").at(extensionPath("podcast:transcript"))
+	}
+	for i, it := range f.Items {
+		if n, ok := firstRejectedItemExtension(it.allExtensions()); ok {
+			return itemError(ProfilePSP, i, "Extensions", ErrInvalidExtension, "psp: item[%d] extension %s has an invalid value", i, describeExtensionNode(n)).at(extensionPath(n.Name))
+		}
+		if n, problem := firstInvalidPodcastNode(it.allExtensions()); problem != "" {
+			return itemError(ProfilePSP, i, "Extensions", ErrInvalidExtension, "psp: item[%d] extension %s %s", i, describeExtensionNode(n), problem).at(extensionPath(n.Name))
+		}
+		if n, problem := firstInvalidItemFunding(it.allExtensions()); problem != "" {
+			return itemError(ProfilePSP, i, "Extensions", ErrInvalidExtension, "psp: item[%d] extension %s %s", i, describeExtensionNode(n), problem).at(extensionPath(n.Name))
+		}
+	}
+	return nil
+}
+
+// firstInvalidItemFunding checks item-level podcast:funding, which is written as a plain node
+// (the channel-level one is mapped by handleExtPodcastFunding).
+func firstInvalidItemFunding(exts []ExtensionNode) (ExtensionNode, string) {
+	for _, n := range exts {
+		if textLowerTrim(n.Name) == "podcast:funding" {
+			if problem := checkAbsoluteURLAttr(n); problem != "" {
+				return n, problem
+			}
+		}
+	}
+	return ExtensionNode{}, ""
+}
+
+// podcastNodeChecks validates podcast elements that are written as plain extension nodes,
+// keyed by lowercased name. Each check returns a description of the problem or "".
+var podcastNodeChecks = map[string]func(ExtensionNode) string{
+	"podcast:transcript":     checkAbsoluteURLAttr,
+	"podcast:trailer":        checkAbsoluteURLAttr,
+	"podcast:location":       checkPodcastLocation,
+	"podcast:license":        checkPodcastLicense,
+	"podcast:medium":         checkPodcastMedium,
+	"podcast:socialinteract": checkPodcastSocialInteract,
+}
+
+// firstInvalidPodcastNode returns the first node rejected by podcastNodeChecks and the reason.
+func firstInvalidPodcastNode(exts []ExtensionNode) (ExtensionNode, string) {
+	for _, n := range exts {
+		if check, ok := podcastNodeChecks[textLowerTrim(n.Name)]; ok {
+			if problem := check(n); problem != "" {
+				return n, problem
+			}
+		}
+	}
+	return ExtensionNode{}, ""
+}
+
+var (
+	// geoURIPattern matches an RFC 5870 geo URI: geo:lat,lon[,alt][;param...]
+	geoURIPattern = regexp.MustCompile(`^geo:(-?\d+(?:\.\d+)?),(-?\d+(?:\.\d+)?)(?:,-?\d+(?:\.\d+)?)?(?:;[A-Za-z0-9-]+(?:=[^;]+)?)*$`)
+	// osmPattern matches an OpenStreetMap reference: type (N, W or R), id and optional #revision.
+	osmPattern = regexp.MustCompile(`^[NWR]\d+(?:#\d+)?$`)
+)
+
+// validGeoURI reports whether s is an RFC 5870 geo URI with in-range coordinates.
+func validGeoURI(s string) bool {
+	m := geoURIPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return false
+	}
+	lat, _ := strconv.ParseFloat(m[1], 64)
+	lon, _ := strconv.ParseFloat(m[2], 64)
+	return lat >= -90 && lat <= 90 && lon >= -180 && lon <= 180
+}
+
+func checkPodcastLocation(n ExtensionNode) string {
+	name := strings.TrimSpace(n.Text)
+	switch {
+	case name == "":
+		return "requires a location name"
+	case len([]rune(name)) > 128:
+		return "name must be <= 128 characters"
+	}
+	if geo := attrTrim(n.Attrs, "geo"); geo != "" && !validGeoURI(geo) {
+		return "geo must be a geo: URI such as \"geo:30.2672,97.7431\""
+	}
+	if osm := attrTrim(n.Attrs, "osm"); osm != "" && !osmPattern.MatchString(osm) {
+		return "osm must be an OpenStreetMap reference such as \"R113314\""
+	}
+	return ""
+}
+
+// podcastLocationNode builds a podcast:location node; ok is false when the input is invalid.
+func podcastLocationNode(name, geo, osm string) (ExtensionNode, bool) {
+	n := ExtensionNode{Name: "podcast:location", Text: strings.TrimSpace(name), Attrs: map[string]string{}}
+	if s := strings.TrimSpace(geo); s != "" {
+		n.Attrs["geo"] = s
+	}
+	if s := strings.TrimSpace(osm); s != "" {
+		n.Attrs["osm"] = s
+	}
+	return n, checkPodcastLocation(n) == ""
+}
+
+// podcastMediums lists the podcast:medium values defined by Podcasting 2.0. Each may also take
+// an "L" suffix (e.g. "musicL") for feeds that list other feeds of that medium.
+var podcastMediums = []string{"podcast", "music", "video", "film", "audiobook", "newsletter", "blog", "publisher", "course"}
+
+// normalizePodcastMedium returns the canonical spelling of a podcast:medium value.
+func normalizePodcastMedium(s string) (string, bool) {
+	v := strings.TrimSpace(s)
+	if m, ok := canonicalMedium(v); ok {
+		return m, true
+	}
+	if strings.HasSuffix(v, "L") || strings.HasSuffix(v, "l") {
+		if m, ok := canonicalMedium(v[:len(v)-1]); ok {
+			return m + "L", true
+		}
+	}
+	return "", false
+}
+
+func canonicalMedium(s string) (string, bool) {
+	for _, m := range podcastMediums {
+		if strings.EqualFold(s, m) {
+			return m, true
+		}
+	}
+	return "", false
+}
+
+func checkPodcastMedium(n ExtensionNode) string {
+	if _, ok := normalizePodcastMedium(n.Text); !ok {
+		return "must be one of " + strings.Join(podcastMediums, ", ") + " (optionally with an \"L\" suffix)"
+	}
+	return ""
+}
+
+func checkPodcastSocialInteract(n ExtensionNode) string {
+	protocol := strings.ToLower(attrTrim(n.Attrs, "protocol"))
+	switch {
+	case protocol == "":
+		return "requires a protocol"
+	case protocol != "disabled" && attrTrim(n.Attrs, "uri") == "":
+		return "requires a uri"
+	}
+	if u := attrTrim(n.Attrs, "accountUrl"); u != "" && !isAbsoluteURL(u) {
+		return "accountUrl must be an absolute URL"
+	}
+	if p := attrTrim(n.Attrs, "priority"); p != "" {
+		if _, ok := parsePositiveInt(p); !ok {
+			return "priority must be a positive integer"
+		}
+	}
+	return ""
+}
+
+// spdxLicenseIDs holds the SPDX identifiers commonly used for podcasts and media
+// (Creative Commons, public domain and the main open source licenses). It is not the full
+// SPDX list; licenses outside it can still be declared with a url.
+var spdxLicenseIDs = spdxIndex(
+	"CC0-1.0", "CC-PDDC", "PDDL-1.0", "Unlicense",
+	"CC-BY-1.0", "CC-BY-2.0", "CC-BY-2.5", "CC-BY-3.0", "CC-BY-4.0",
+	"CC-BY-SA-1.0", "CC-BY-SA-2.0", "CC-BY-SA-2.5", "CC-BY-SA-3.0", "CC-BY-SA-4.0",
+	"CC-BY-ND-1.0", "CC-BY-ND-2.0", "CC-BY-ND-2.5", "CC-BY-ND-3.0", "CC-BY-ND-4.0",
+	"CC-BY-NC-1.0", "CC-BY-NC-2.0", "CC-BY-NC-2.5", "CC-BY-NC-3.0", "CC-BY-NC-4.0",
+	"CC-BY-NC-SA-1.0", "CC-BY-NC-SA-2.0", "CC-BY-NC-SA-2.5", "CC-BY-NC-SA-3.0", "CC-BY-NC-SA-4.0",
+	"CC-BY-NC-ND-1.0", "CC-BY-NC-ND-2.0", "CC-BY-NC-ND-2.5", "CC-BY-NC-ND-3.0", "CC-BY-NC-ND-4.0",
+	"MIT", "Apache-2.0", "BSD-2-Clause", "BSD-3-Clause", "ISC", "MPL-2.0",
+	"GPL-2.0-only", "GPL-2.0-or-later", "GPL-3.0-only", "GPL-3.0-or-later",
+	"LGPL-2.1-only", "LGPL-2.1-or-later", "LGPL-3.0-only", "LGPL-3.0-or-later",
+	"AGPL-3.0-only", "AGPL-3.0-or-later", "GFDL-1.3-only", "GFDL-1.3-or-later",
+	"ODbL-1.0", "ODC-By-1.0", "OFL-1.1", "Artistic-2.0", "EUPL-1.2",
+)
+
+// spdxIndex maps lowercased identifiers to their canonical spelling.
+func spdxIndex(ids ...string) map[string]string {
+	m := make(map[string]string, len(ids))
+	for _, id := range ids {
+		m[strings.ToLower(id)] = id
+	}
+	return m
+}
+
+// IsSPDXLicenseID reports whether id is one of the SPDX license identifiers known to gofeedx
+// (case-insensitive). The built-in list covers common media and open source licenses only.
+func IsSPDXLicenseID(id string) bool {
+	_, ok := spdxLicenseIDs[textLowerTrim(id)]
+	return ok
+}
+
+func checkPodcastLicense(n ExtensionNode) string {
+	id := strings.TrimSpace(n.Text)
+	switch {
+	case id == "":
+		return "requires a license identifier"
+	case len([]rune(id)) > 128:
+		return "identifier must be <= 128 characters"
+	}
+	if u := attrTrim(n.Attrs, "url"); u != "" && !isAbsoluteURL(u) {
+		return "url must be an absolute URL"
+	}
+	return ""
+}
+
+// podcastLicenseNode builds a podcast:license node, normalizing known SPDX identifiers to
+// their canonical case; ok is false when the input is invalid.
+func podcastLicenseNode(identifier, url string) (ExtensionNode, bool) {
+	id := strings.TrimSpace(identifier)
+	if canonical, known := spdxLicenseIDs[strings.ToLower(id)]; known {
+		id = canonical
+	}
+	n := ExtensionNode{Name: "podcast:license", Text: id}
+	if s := strings.TrimSpace(url); s != "" {
+		n.Attrs = map[string]string{"url": s}
+	}
+	return n, checkPodcastLicense(n) == ""
+}
+
+func checkAbsoluteURLAttr(n ExtensionNode) string {
+	if !isAbsoluteURL(attrTrim(n.Attrs, "url")) {
+		return "url must be an absolute URL"
+	}
+	return ""
+}
+
+// firstRejectedExtension returns the first node with a registered handler that refuses it.
+func firstRejectedExtension(exts []ExtensionNode, handlers map[string]func(ExtensionNode) bool) (ExtensionNode, bool) {
+	for _, n := range exts {
+		if h, ok := handlers[textLowerTrim(n.Name)]; ok && !h(n) {
+			return n, true
+		}
+	}
+	return ExtensionNode{}, false
+}
+
+// describeExtensionNode renders a node as <name attr="..."> "text" for error messages.
+func describeExtensionNode(n ExtensionNode) string {
+	var sb strings.Builder
+	sb.WriteString("<" + strings.TrimSpace(n.Name))
+	keys := make([]string, 0, len(n.Attrs))
+	for k := range n.Attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&sb, " %s=%q", k, n.Attrs[k])
+	}
+	sb.WriteString(">")
+	fmt.Fprintf(&sb, " %q", n.Text)
+	return sb.String()
+}
+
 func (p *PSP) wrapRoot(ch *PSPChannel) *PSPRSSRoot {
 	needsContent := false
 	// Trigger content namespace if any item has Content or Description includes HTML tags (heuristic)
@@ -652,12 +981,16 @@ func (p *PSP) wrapRoot(ch *PSPChannel) *PSPRSSRoot {
 }
 
 func (p *PSP) buildChannel() *PSPChannel {
+	p = &PSP{p.renderView(ProfilePSP)}
 	ch := deriveBasicChannel(p)
 	addAtomSelf(p, ch)
 	addItunesChannelFields(p, ch)
 	addPodcastGUID(p, ch)
+	addPodcastLicenseFromFeed(p, ch)
 	addItems(p, ch)
-	mapChannelExtensions(p.Extensions, ch)
+	mapChannelExtensions(p.allExtensions(), ch)
+	// WebSub hub links; the channel already has its atom:link rel="self"
+	ch.Extra = append(ch.Extra, websubLinkNodes(p.allExtensions(), "")...)
 	return ch
 }
 
@@ -675,7 +1008,8 @@ func deriveBasicChannel(p *PSP) *PSPChannel {
 		Description:   p.Description,
 		Link:          linkHref,
 		Language:      p.Language,
-		Copyright:     p.Copyright,
+		Copyright:     feedRights(p.Feed),
+		Generator:     p.Generator.String(),
 		PubDate:       pub,
 		LastBuildDate: build,
 	}
@@ -688,8 +1022,8 @@ func addAtomSelf(p *PSP, ch *PSPChannel) {
 }
 
 func addItunesChannelFields(p *PSP, ch *PSPChannel) {
-	if p.Image != nil && strings.TrimSpace(p.Image.Url) != "" {
-		ch.ItunesImage = &ItunesImage{Href: p.Image.Url}
+	if href := feedArtworkURL(p.Feed); href != "" {
+		ch.ItunesImage = &ItunesImage{Href: href}
 	}
 	if p.Author != nil && strings.TrimSpace(p.Author.Name) != "" {
 		ch.ItunesAuthor = p.Author.Name
@@ -698,12 +1032,24 @@ func addItunesChannelFields(p *PSP, ch *PSPChannel) {
 }
 
 func addPodcastGUID(p *PSP, ch *PSPChannel) {
-	if strings.TrimSpace(p.ID) != "" {
-		// Use Feed.ID as podcast GUID when provided
-		ch.Extra = append(ch.Extra, ExtensionNode{Name: "podcast:guid", Text: p.ID})
-	} else if strings.TrimSpace(p.FeedURL) != "" {
-		ch.Extra = append(ch.Extra, ExtensionNode{Name: "podcast:guid", Text: computePodcastGuid(p.FeedURL)})
+	if guid := PodcastGUID(p.Feed); guid != "" {
+		ch.Extra = append(ch.Extra, ExtensionNode{Name: "podcast:guid", Text: guid})
+	}
+}
+
+// PodcastGUID returns the podcast:guid ToPSP emits for feed: Feed.ID when set, otherwise
+// the UUIDv5 of the normalized FeedURL. It returns "" when neither is available.
+func PodcastGUID(feed *Feed) string {
+	if feed == nil {
+		return ""
+	}
+	if strings.TrimSpace(feed.ID) != "" {
+		return feed.ID
 	}
+	if strings.TrimSpace(feed.FeedURL) != "" {
+		return computePodcastGuid(feed.FeedURL)
+	}
+	return ""
 }
 
 func addItems(p *PSP, ch *PSPChannel) {
@@ -716,7 +1062,16 @@ func mapChannelExtensions(exts []ExtensionNode, ch *PSPChannel) {
 	if len(exts) == 0 {
 		return
 	}
-	handlers := map[string]func(ExtensionNode) bool{
+	extras := processExtensions(exts, channelExtensionHandlers(ch))
+	if len(extras) > 0 {
+		ch.Extra = append(ch.Extra, extras...)
+	}
+}
+
+// channelExtensionHandlers returns the channel-level handlers keyed by lowercased node name.
+// Each handler maps a node into ch and reports whether the node was valid and consumed.
+func channelExtensionHandlers(ch *PSPChannel) map[string]func(ExtensionNode) bool {
+	return map[string]func(ExtensionNode) bool{
 		"itunes:explicit": func(n ExtensionNode) bool { return handleExtItunesExplicit(ch, n) },
 		"itunes:type":     func(n ExtensionNode) bool { return handleExtItunesType(ch, n) },
 		"itunes:complete": func(n ExtensionNode) bool { return handleExtItunesComplete(ch, n) },
@@ -725,26 +1080,15 @@ func mapChannelExtensions(exts []ExtensionNode, ch *PSPChannel) {
 		"podcast:txt":     func(n ExtensionNode) bool { return handleExtPodcastTXT(ch, n) },
 		"podcast:funding": func(n ExtensionNode) bool { return handleExtPodcastFunding(ch, n) },
 	}
-	extras := processExtensions(exts, handlers)
-	if len(extras) > 0 {
-		ch.Extra = append(ch.Extra, extras...)
-	}
 }
 
 func handleExtItunesExplicit(ch *PSPChannel, n ExtensionNode) bool {
-	t := textLowerTrim(n.Text)
-	switch t {
-	case "true":
-		v := true
-		ch.ItunesExplicit = &v
-		return true
-	case "false":
-		v := false
-		ch.ItunesExplicit = &v
-		return true
-	default:
+	v, ok := ParseFeedBool(n.Text)
+	if !ok {
 		return false
 	}
+	ch.ItunesExplicit = &v
+	return true
 }
 
 func handleExtItunesType(ch *PSPChannel, n ExtensionNode) bool {
@@ -757,11 +1101,12 @@ func handleExtItunesType(ch *PSPChannel, n ExtensionNode) bool {
 }
 
 func handleExtItunesComplete(ch *PSPChannel, n ExtensionNode) bool {
-	if isYes(n.Text) {
-		ch.ItunesComplete = true
-		return true
+	v, ok := ParseFeedBool(n.Text)
+	if !ok {
+		return false
 	}
-	return false
+	ch.ItunesComplete = v
+	return true
 }
 
 func handleExtItunesImage(ch *PSPChannel, n ExtensionNode) bool {
@@ -774,13 +1119,12 @@ func handleExtItunesImage(ch *PSPChannel, n ExtensionNode) bool {
 }
 
 func handleExtPodcastLocked(ch *PSPChannel, n ExtensionNode) bool {
-	t := textLowerTrim(n.Text)
-	if t == "yes" || t == "no" {
-		v := t == "yes"
-		ch.PodcastLocked = &v
-		return true
+	v, ok := ParseFeedBool(n.Text)
+	if !ok {
+		return false
 	}
-	return false
+	ch.PodcastLocked = &v
+	return true
 }
 
 func handleExtPodcastTXT(ch *PSPChannel, n ExtensionNode) bool {
@@ -792,14 +1136,14 @@ func handleExtPodcastTXT(ch *PSPChannel, n ExtensionNode) bool {
 	if n.Attrs != nil {
 		pt.Purpose = attrTrim(n.Attrs, "purpose")
 	}
-	ch.PodcastTXT = pt
+	ch.PodcastTXT = append(ch.PodcastTXT, pt)
 	return true
 }
 
 func handleExtPodcastFunding(ch *PSPChannel, n ExtensionNode) bool {
 	href := attrTrim(n.Attrs, "url")
 	if href != "" || strings.TrimSpace(n.Text) != "" {
-		ch.PodcastFunding = &PodcastFunding{Url: href, Text: n.Text}
+		ch.PodcastFunding = append(ch.PodcastFunding, &PodcastFunding{Url: href, Text: n.Text})
 		return true
 	}
 	return false
@@ -811,25 +1155,84 @@ func mapItemExtensions(exts []ExtensionNode, it *PSPItem) (extras []ExtensionNod
 	if len(exts) == 0 {
 		return nil
 	}
-	handlers := map[string]func(ExtensionNode) bool{
-		"itunes:explicit":    func(n ExtensionNode) bool { return itemHandleItunesExplicit(it, n) },
-		"itunes:image":       func(n ExtensionNode) bool { return itemHandleItunesImage(it, n) },
-		"itunes:episode":     func(n ExtensionNode) bool { return itemHandleItunesEpisode(it, n) },
-		"itunes:season":      func(n ExtensionNode) bool { return itemHandleItunesSeason(it, n) },
-		"itunes:episodetype": func(n ExtensionNode) bool { return itemHandleItunesEpisodeType(it, n) },
-		"itunes:block":       func(n ExtensionNode) bool { return itemHandleItunesBlock(it, n) },
-		"podcast:transcript": func(n ExtensionNode) bool { return itemHandlePodcastTranscript(it, n) },
+	for _, n := range exts {
+		if h, ok := itemExtensionHandlers[textLowerTrim(n.Name)]; ok && h(it, n) {
+			continue
+		}
+		extras = append(extras, n)
 	}
-	return processExtensions(exts, handlers)
+	return extras
+}
+
+// firstRejectedItemExtension is firstRejectedExtension for item scope.
+func firstRejectedItemExtension(exts []ExtensionNode) (ExtensionNode, bool) {
+	probe := &PSPItem{}
+	for _, n := range exts {
+		if h, ok := itemExtensionHandlers[textLowerTrim(n.Name)]; ok && !h(probe, n) {
+			return n, true
+		}
+	}
+	return ExtensionNode{}, false
+}
+
+// itemExtensionHandlers holds the item-level handlers keyed by lowercased node name.
+// Each handler maps a node into the item and reports whether the node was valid and consumed.
+// The table is shared by all items so rendering large feeds does not rebuild it per item.
+var itemExtensionHandlers = map[string]func(*PSPItem, ExtensionNode) bool{
+	"itunes:explicit":    itemHandleItunesExplicit,
+	"itunes:image":       itemHandleItunesImage,
+	"itunes:episode":     itemHandleItunesEpisode,
+	"itunes:season":      itemHandleItunesSeason,
+	"itunes:episodetype": itemHandleItunesEpisodeType,
+	"itunes:author":      itemHandleItunesAuthor,
+	"itunes:block":       itemHandleItunesBlock,
+	"itunes:duration":    itemHandleItunesDuration,
+	"podcast:transcript": itemHandlePodcastTranscript,
+	"podcast:season":     itemHandlePodcastSeason,
+	"podcast:episode":    itemHandlePodcastEpisode,
+}
+
+func itemHandlePodcastSeason(it *PSPItem, n ExtensionNode) bool {
+	v, ok := parsePositiveInt(n.Text)
+	name := attrTrim(n.Attrs, "name")
+	if !ok || len([]rune(name)) > 128 {
+		return false
+	}
+	it.PodcastSeason = &PSPPodcastSeason{Name: name, Number: v}
+	return true
+}
+
+func itemHandlePodcastEpisode(it *PSPItem, n ExtensionNode) bool {
+	v, ok := parsePositiveDecimal(n.Text)
+	display := attrTrim(n.Attrs, "display")
+	if !ok || len([]rune(display)) > 32 {
+		return false
+	}
+	it.PodcastEpisode = &PSPPodcastEpisode{Display: display, Number: formatDecimal(v)}
+	return true
+}
+
+// parsePositiveDecimal parses a decimal number > 0 such as "12" or "12.5".
+func parsePositiveDecimal(s string) (float64, bool) {
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || v <= 0 || math.IsInf(v, 0) || math.IsNaN(v) {
+		return 0, false
+	}
+	return v, true
+}
+
+// formatDecimal renders v without exponent or trailing zeros.
+func formatDecimal(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
 }
 
 func itemHandleItunesExplicit(it *PSPItem, n ExtensionNode) bool {
-	t := textLowerTrim(n.Text)
-	if t == "true" || t == "false" {
-		it.ItunesExplicit = t
-		return true
+	v, ok := ParseFeedBool(n.Text)
+	if !ok {
+		return false
 	}
-	return false
+	it.ItunesExplicit = strconv.FormatBool(v)
+	return true
 }
 
 func itemHandleItunesImage(it *PSPItem, n ExtensionNode) bool {
@@ -868,12 +1271,33 @@ func itemHandleItunesEpisodeType(it *PSPItem, n ExtensionNode) bool {
 	}
 }
 
+func itemHandleItunesAuthor(it *PSPItem, n ExtensionNode) bool {
+	name := strings.TrimSpace(n.Text)
+	if name == "" {
+		return false
+	}
+	it.ItunesAuthor = name
+	return true
+}
+
 func itemHandleItunesBlock(it *PSPItem, n ExtensionNode) bool {
-	if isYes(n.Text) {
+	v, ok := ParseFeedBool(n.Text)
+	if !ok {
+		return false
+	}
+	it.ItunesBlock = ""
+	if v {
 		it.ItunesBlock = "yes"
-		return true
 	}
-	return false
+	return true
+}
+
+func itemHandleItunesDuration(it *PSPItem, n ExtensionNode) bool {
+	if _, err := ParseItunesDuration(n.Text); err != nil {
+		return false
+	}
+	it.ItunesDuration = strings.TrimSpace(n.Text)
+	return true
 }
 
 func itemHandlePodcastTranscript(it *PSPItem, n ExtensionNode) bool {
@@ -922,7 +1346,14 @@ func (p *PSP) buildItem(it *Item) *PSPItem {
 
 	// iTunes item fields (from generic feed where available)
 	if it.DurationSeconds > 0 {
-		pi.ItunesDuration = fmt.Sprintf("%d", it.DurationSeconds)
+		if usePSPClockDuration(p.allExtensions()) {
+			pi.ItunesDuration = FormatItunesDuration(it.DurationSeconds)
+		} else {
+			pi.ItunesDuration = strconv.Itoa(it.DurationSeconds)
+		}
+	}
+	if s := strings.TrimSpace(it.ImageURL); s != "" {
+		pi.ItunesImage = &ItunesImage{Href: s}
 	}
 	// Optional HTML content via content:encoded (align with RSS behavior)
 	if len(it.Content) > 0 {
@@ -930,8 +1361,8 @@ func (p *PSP) buildItem(it *Item) *PSPItem {
 	}
 
 	// Map PSP/iTunes item-level extensions into typed fields; keep unknown in Extra
-	if len(it.Extensions) > 0 {
-		extras := mapItemExtensions(it.Extensions, pi)
+	if exts := it.allExtensions(); len(exts) > 0 {
+		extras := mapItemExtensions(exts, pi)
 		if len(extras) > 0 {
 			pi.Extra = append(pi.Extra, extras...)
 		}
@@ -939,6 +1370,49 @@ func (p *PSP) buildItem(it *Item) *PSPItem {
 	return pi
 }
 
+// usePSPClockDuration reports whether the last "_psp:durationFormat" marker selects HH:MM:SS output.
+func usePSPClockDuration(exts []ExtensionNode) bool {
+	clock := false
+	for _, n := range exts {
+		if strings.EqualFold(strings.TrimSpace(n.Name), "_psp:durationFormat") {
+			clock = textLowerTrim(n.Text) == "hh:mm:ss"
+		}
+	}
+	return clock
+}
+
+// FormatItunesDuration formats seconds as HH:MM:SS (e.g. 1801 -> "00:30:01").
+// Hours are not wrapped, so long durations render as e.g. "100:00:00".
+func FormatItunesDuration(sec int) string {
+	if sec < 0 {
+		sec = 0
+	}
+	return fmt.Sprintf("%02d:%02d:%02d", sec/3600, (sec%3600)/60, sec%60)
+}
+
+// ParseItunesDuration parses an itunes:duration value given as seconds ("1801"),
+// MM:SS ("30:01") or HH:MM:SS ("00:30:01") and returns the total seconds.
+// Minutes and seconds must be below 60 when a larger unit is present.
+func ParseItunesDuration(s string) (int, error) {
+	t := strings.TrimSpace(s)
+	parts := strings.Split(t, ":")
+	if t == "" || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid itunes:duration %q", s)
+	}
+	total := 0
+	for i, p := range parts {
+		v, err := strconv.Atoi(p)
+		if err != nil || v < 0 || p == "" || strings.ContainsAny(p, "+-") {
+			return 0, fmt.Errorf("invalid itunes:duration %q", s)
+		}
+		if i > 0 && v >= 60 {
+			return 0, fmt.Errorf("invalid itunes:duration %q: minutes and seconds must be < 60", s)
+		}
+		total = total*60 + v
+	}
+	return total, nil
+}
+
 // convertCategories maps generic Categories to iTunes category XML structure (including nested subcategories).
 func convertCategories(cats []*Category) []*ItunesCategory {
 	var out []*ItunesCategory
@@ -955,7 +1429,7 @@ func convertCategories(cats []*Category) []*ItunesCategory {
 // computePodcastGuid generates UUIDv5 from normalized feed URL (scheme-stripped, trailing slashes removed).
 func computePodcastGuid(feedURL string) string {
 	normalized := normalizeFeedURL(feedURL)
-	u := UUIDv5(PodcastNamespaceUUID, []byte(normalized))
+	u := NewUUIDv5(PodcastNamespaceUUID, []byte(normalized))
 	return u.String()
 }
 
@@ -1002,7 +1476,8 @@ func (b *FeedBuilder) WithPSPExplicit(explicit bool) *FeedBuilder {
 	return b.WithExtensions(ExtensionNode{Name: "itunes:explicit", Text: text})
 }
 
-// WithPSPFunding sets podcast:funding at channel scope with url attr and label text.
+// WithPSPFunding adds podcast:funding at channel scope with url attr and label text. Call it
+// once per support link; every entry is emitted.
 func (b *FeedBuilder) WithPSPFunding(url, label string) *FeedBuilder {
 	url = strings.TrimSpace(url)
 	label = strings.TrimSpace(label)
@@ -1025,7 +1500,8 @@ func (b *FeedBuilder) WithPSPLocked(locked bool) *FeedBuilder {
 	return b.WithExtensions(ExtensionNode{Name: "podcast:locked", Text: val})
 }
 
-// WithPSPTXT sets podcast:txt at channel scope with optional purpose attr.
+// WithPSPTXT adds podcast:txt at channel scope with optional purpose attr. Call it once per
+// entry; every entry is emitted (e.g. applepodcastsverify plus a Spotify verification token).
 func (b *FeedBuilder) WithPSPTXT(value, purpose string) *FeedBuilder {
 	value = strings.TrimSpace(value)
 	purpose = strings.TrimSpace(purpose)
@@ -1039,6 +1515,59 @@ func (b *FeedBuilder) WithPSPTXT(value, purpose string) *FeedBuilder {
 	return b.WithExtensions(ExtensionNode{Name: "podcast:txt", Attrs: attrs, Text: value})
 }
 
+// WithPSPTrailer adds podcast:trailer at channel scope. title, url and pubDate are required;
+// zero mime, length and season are omitted. Call it once per trailer.
+func (b *FeedBuilder) WithPSPTrailer(title, url, mime string, length int64, pubDate time.Time, season int) *FeedBuilder {
+	title = strings.TrimSpace(title)
+	url = strings.TrimSpace(url)
+	if title == "" || url == "" || pubDate.IsZero() {
+		return b
+	}
+	attrs := map[string]string{
+		"url":     url,
+		"pubdate": pubDate.Format(time.RFC1123Z),
+	}
+	if s := strings.TrimSpace(mime); s != "" {
+		attrs["type"] = s
+	}
+	if length > 0 {
+		attrs["length"] = strconv.FormatInt(length, 10)
+	}
+	if season > 0 {
+		attrs["season"] = strconv.Itoa(season)
+	}
+	return b.WithExtensions(ExtensionNode{Name: "podcast:trailer", Attrs: attrs, Text: title})
+}
+
+// WithPSPLocation sets podcast:location at channel scope: what the show is about, with an
+// optional RFC 5870 geo URI (e.g. "geo:39.7837304,-100.4458825") and OpenStreetMap reference
+// (e.g. "R113314"). Invalid input is ignored.
+func (b *FeedBuilder) WithPSPLocation(name, geo, osm string) *FeedBuilder {
+	if n, ok := podcastLocationNode(name, geo, osm); ok {
+		return b.WithExtensions(n)
+	}
+	return b
+}
+
+// WithPSPLicense sets podcast:license at channel scope: an SPDX identifier (e.g. "CC-BY-4.0")
+// or custom license name, with an optional url to the license text. Podcasting 2.0 requires
+// the url for licenses that are not on the SPDX list. Invalid input is ignored.
+func (b *FeedBuilder) WithPSPLicense(identifier, url string) *FeedBuilder {
+	if n, ok := podcastLicenseNode(identifier, url); ok {
+		return b.WithExtensions(n)
+	}
+	return b
+}
+
+// WithPSPMedium sets podcast:medium at channel scope, e.g. "music" for music feeds, which
+// apps require to tell them apart from spoken-word podcasts. Unknown values are ignored.
+func (b *FeedBuilder) WithPSPMedium(medium string) *FeedBuilder {
+	if m, ok := normalizePodcastMedium(medium); ok {
+		return b.WithExtensions(ExtensionNode{Name: "podcast:medium", Text: m})
+	}
+	return b
+}
+
 // WithPSPItunesType sets itunes:type ("episodic" or "serial") at channel scope.
 func (b *FeedBuilder) WithPSPItunesType(t string) *FeedBuilder {
 	t = strings.TrimSpace(strings.ToLower(t))
@@ -1068,6 +1597,16 @@ func (b *FeedBuilder) WithPSPImageHref(href string) *FeedBuilder {
 	return b.WithExtensions(ExtensionNode{Name: "itunes:image", Attrs: map[string]string{"href": href}})
 }
 
+// WithPSPDurationHHMMSS selects the itunes:duration output format for items: HH:MM:SS when
+// enabled, raw seconds otherwise (the default). Both forms are allowed by the iTunes spec.
+func (b *FeedBuilder) WithPSPDurationHHMMSS(enabled bool) *FeedBuilder {
+	val := "seconds"
+	if enabled {
+		val = "hh:mm:ss"
+	}
+	return b.withOption(ExtensionNode{Name: "_psp:durationFormat", Text: val})
+}
+
 // Item-level helpers:
 
 // WithPSPExplicit sets itunes:explicit at item scope ("true"/"false").
@@ -1079,6 +1618,16 @@ func (b *ItemBuilder) WithPSPExplicit(explicit bool) *ItemBuilder {
 	return b.WithExtensions(ExtensionNode{Name: "itunes:explicit", Text: text})
 }
 
+// WithPSPFunding adds podcast:funding at item scope, e.g. a sponsor link for one episode.
+// url must be absolute; invalid input is ignored. Call it once per link.
+func (b *ItemBuilder) WithPSPFunding(url, label string) *ItemBuilder {
+	url = strings.TrimSpace(url)
+	if !isAbsoluteURL(url) {
+		return b
+	}
+	return b.WithExtensions(ExtensionNode{Name: "podcast:funding", Attrs: map[string]string{"url": url}, Text: strings.TrimSpace(label)})
+}
+
 // WithPSPTranscript adds a podcast:transcript node at item scope.
 func (b *ItemBuilder) WithPSPTranscript(url, typ, language, rel string) *ItemBuilder {
 	url = strings.TrimSpace(url)
@@ -1124,6 +1673,80 @@ func (b *ItemBuilder) WithPSPSeason(n int) *ItemBuilder {
 	return b.WithExtensions(ExtensionNode{Name: "itunes:season", Text: strconv.Itoa(n)})
 }
 
+// WithPSPPodcastSeason sets itunes:season and podcast:season (with optional name, at most 128
+// characters) from the same number at item scope. n must be > 0.
+func (b *ItemBuilder) WithPSPPodcastSeason(n int, name string) *ItemBuilder {
+	name = strings.TrimSpace(name)
+	if n <= 0 || len([]rune(name)) > 128 {
+		return b
+	}
+	node := ExtensionNode{Name: "podcast:season", Text: strconv.Itoa(n)}
+	if name != "" {
+		node.Attrs = map[string]string{"name": name}
+	}
+	return b.WithPSPSeason(n).WithExtensions(node)
+}
+
+// WithPSPPodcastEpisode sets podcast:episode (with optional display label, at most 32
+// characters) at item scope, plus itunes:episode when n is a whole number. n must be > 0;
+// podcast:episode also allows fractional numbers such as 204.5.
+func (b *ItemBuilder) WithPSPPodcastEpisode(n float64, display string) *ItemBuilder {
+	display = strings.TrimSpace(display)
+	if _, ok := parsePositiveDecimal(formatDecimal(n)); !ok || len([]rune(display)) > 32 {
+		return b
+	}
+	if n == math.Trunc(n) && n <= math.MaxInt32 {
+		b.WithPSPEpisode(int(n))
+	}
+	node := ExtensionNode{Name: "podcast:episode", Text: formatDecimal(n)}
+	if display != "" {
+		node.Attrs = map[string]string{"display": display}
+	}
+	return b.WithExtensions(node)
+}
+
+// WithPSPLocation sets podcast:location at item scope; see FeedBuilder.WithPSPLocation.
+func (b *ItemBuilder) WithPSPLocation(name, geo, osm string) *ItemBuilder {
+	if n, ok := podcastLocationNode(name, geo, osm); ok {
+		return b.WithExtensions(n)
+	}
+	return b
+}
+
+// WithPSPLicense sets podcast:license at item scope; see FeedBuilder.WithPSPLicense.
+func (b *ItemBuilder) WithPSPLicense(identifier, url string) *ItemBuilder {
+	if n, ok := podcastLicenseNode(identifier, url); ok {
+		return b.WithExtensions(n)
+	}
+	return b
+}
+
+// WithPSPSocialInteract adds podcast:socialInteract at item scope, pointing at the episode's
+// comment thread. protocol is e.g. "activitypub", "atproto" or "disabled" (which needs no
+// uri); accountID, accountURL and priority (omitted when 0) are optional. Call it once per
+// thread. Invalid input is ignored.
+func (b *ItemBuilder) WithPSPSocialInteract(uri, protocol, accountID, accountURL string, priority int) *ItemBuilder {
+	if n, ok := podcastSocialInteractNode(uri, protocol, accountID, accountURL, priority); ok {
+		return b.WithExtensions(n)
+	}
+	return b
+}
+
+// podcastSocialInteractNode builds a podcast:socialInteract node; ok is false when the input is invalid.
+func podcastSocialInteractNode(uri, protocol, accountID, accountURL string, priority int) (ExtensionNode, bool) {
+	attrs := map[string]string{"protocol": strings.ToLower(strings.TrimSpace(protocol))}
+	for k, v := range map[string]string{"uri": uri, "accountId": accountID, "accountUrl": accountURL} {
+		if s := strings.TrimSpace(v); s != "" {
+			attrs[k] = s
+		}
+	}
+	if priority != 0 {
+		attrs["priority"] = strconv.Itoa(priority)
+	}
+	n := ExtensionNode{Name: "podcast:socialInteract", Attrs: attrs}
+	return n, checkPodcastSocialInteract(n) == ""
+}
+
 // WithPSPEpisodeType sets itunes:episodeType ("full" | "trailer" | "bonus") at item scope.
 func (b *ItemBuilder) WithPSPEpisodeType(t string) *ItemBuilder {
 	t = strings.TrimSpace(strings.ToLower(t))
@@ -1135,6 +1758,16 @@ func (b *ItemBuilder) WithPSPEpisodeType(t string) *ItemBuilder {
 	}
 }
 
+// WithPSPAuthor sets itunes:author at item scope. Unlike the RSS author, which is
+// an email address, this is a free-form display name. Empty names are ignored.
+func (b *ItemBuilder) WithPSPAuthor(name string) *ItemBuilder {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return b
+	}
+	return b.WithExtensions(ExtensionNode{Name: "itunes:author", Text: name})
+}
+
 // WithPSPBlock sets itunes:block ("yes") at item scope when true.
 func (b *ItemBuilder) WithPSPBlock(block bool) *ItemBuilder {
 	if !block {
@@ -1142,3 +1775,342 @@ func (b *ItemBuilder) WithPSPBlock(block bool) *ItemBuilder {
 	}
 	return b.WithExtensions(ExtensionNode{Name: "itunes:block", Text: "yes"})
 }
+
+// Namespace sub-builders.
+//
+// These provide a typed, fluent alternative to constructing podcast:* and itunes:* extension
+// nodes by hand, e.g.:
+//
+//	b.Podcast().Funding("https://example.com/donate", "Support us").Locked(true).Done()
+//	ib.Itunes().Episode(3).Season(1).Done()
+//
+// Each method validates its input for the namespace. Invalid input is not emitted; instead an
+// error is recorded on the parent builder and returned by Build in strict mode.
+
+// PodcastFeedBuilder sets podcast:* elements at channel scope.
+type PodcastFeedBuilder struct {
+	parent *FeedBuilder
+}
+
+// ItunesFeedBuilder sets itunes:* elements at channel scope.
+type ItunesFeedBuilder struct {
+	parent *FeedBuilder
+}
+
+// PodcastItemBuilder sets podcast:* elements at item scope.
+type PodcastItemBuilder struct {
+	parent *ItemBuilder
+}
+
+// ItunesItemBuilder sets itunes:* elements at item scope.
+type ItunesItemBuilder struct {
+	parent *ItemBuilder
+}
+
+// Podcast returns a sub-builder for channel-level podcast namespace elements.
+func (b *FeedBuilder) Podcast() *PodcastFeedBuilder {
+	return &PodcastFeedBuilder{parent: b}
+}
+
+// Itunes returns a sub-builder for channel-level itunes namespace elements.
+func (b *FeedBuilder) Itunes() *ItunesFeedBuilder {
+	return &ItunesFeedBuilder{parent: b}
+}
+
+// Podcast returns a sub-builder for item-level podcast namespace elements.
+func (b *ItemBuilder) Podcast() *PodcastItemBuilder {
+	return &PodcastItemBuilder{parent: b}
+}
+
+// Itunes returns a sub-builder for item-level itunes namespace elements.
+func (b *ItemBuilder) Itunes() *ItunesItemBuilder {
+	return &ItunesItemBuilder{parent: b}
+}
+
+// Funding adds podcast:funding with an absolute url and optional label; call it once per link.
+func (n *PodcastFeedBuilder) Funding(url, label string) *PodcastFeedBuilder {
+	if !isAbsoluteURL(url) {
+		n.parent.errs = append(n.parent.errs, fmt.Errorf("podcast: funding url %q must be an absolute URL", url))
+		return n
+	}
+	n.parent.WithPSPFunding(url, label)
+	return n
+}
+
+// Locked sets podcast:locked.
+func (n *PodcastFeedBuilder) Locked(locked bool) *PodcastFeedBuilder {
+	n.parent.WithPSPLocked(locked)
+	return n
+}
+
+// TXT adds podcast:txt (call it once per entry); value must be non-empty and at most 4000 characters, purpose at most 128.
+func (n *PodcastFeedBuilder) TXT(value, purpose string) *PodcastFeedBuilder {
+	value = strings.TrimSpace(value)
+	switch {
+	case value == "":
+		n.parent.errs = append(n.parent.errs, errors.New("podcast: txt value required"))
+	case len([]rune(value)) > 4000:
+		n.parent.errs = append(n.parent.errs, errors.New("podcast: txt value must be <= 4000 characters"))
+	case len([]rune(strings.TrimSpace(purpose))) > 128:
+		n.parent.errs = append(n.parent.errs, errors.New("podcast: txt purpose must be <= 128 characters"))
+	default:
+		n.parent.WithPSPTXT(value, purpose)
+	}
+	return n
+}
+
+// Trailer adds podcast:trailer; title is required, url must be absolute and pubDate set.
+func (n *PodcastFeedBuilder) Trailer(title, url, mime string, length int64, pubDate time.Time, season int) *PodcastFeedBuilder {
+	switch {
+	case strings.TrimSpace(title) == "":
+		n.parent.errs = append(n.parent.errs, errors.New("podcast: trailer title required"))
+	case !isAbsoluteURL(url):
+		n.parent.errs = append(n.parent.errs, fmt.Errorf("podcast: trailer url %q must be an absolute URL", url))
+	case pubDate.IsZero():
+		n.parent.errs = append(n.parent.errs, errors.New("podcast: trailer pubdate required"))
+	case length < 0 || season < 0:
+		n.parent.errs = append(n.parent.errs, errors.New("podcast: trailer length and season must not be negative"))
+	default:
+		n.parent.WithPSPTrailer(title, url, mime, length, pubDate, season)
+	}
+	return n
+}
+
+// Location sets podcast:location; name is required, geo must be a geo: URI and osm an
+// OpenStreetMap reference when set.
+func (n *PodcastFeedBuilder) Location(name, geo, osm string) *PodcastFeedBuilder {
+	if node, ok := podcastLocationNode(name, geo, osm); !ok {
+		n.parent.errs = append(n.parent.errs, fmt.Errorf("podcast: location %s", checkPodcastLocation(node)))
+		return n
+	}
+	n.parent.WithPSPLocation(name, geo, osm)
+	return n
+}
+
+// License sets podcast:license. Identifiers not known to IsSPDXLicenseID require an absolute url.
+func (n *PodcastFeedBuilder) License(identifier, url string) *PodcastFeedBuilder {
+	if err := checkLicenseInput(identifier, url); err != nil {
+		n.parent.errs = append(n.parent.errs, err)
+		return n
+	}
+	n.parent.WithPSPLicense(identifier, url)
+	return n
+}
+
+// Medium sets podcast:medium; see WithPSPMedium for the allowed values.
+func (n *PodcastFeedBuilder) Medium(medium string) *PodcastFeedBuilder {
+	if _, ok := normalizePodcastMedium(medium); !ok {
+		n.parent.errs = append(n.parent.errs, fmt.Errorf("podcast: medium %q %s", medium, checkPodcastMedium(ExtensionNode{Text: medium})))
+		return n
+	}
+	n.parent.WithPSPMedium(medium)
+	return n
+}
+
+// Done returns the parent feed builder.
+func (n *PodcastFeedBuilder) Done() *FeedBuilder {
+	return n.parent
+}
+
+// Explicit sets itunes:explicit.
+func (n *ItunesFeedBuilder) Explicit(explicit bool) *ItunesFeedBuilder {
+	n.parent.WithPSPExplicit(explicit)
+	return n
+}
+
+// Type sets itunes:type ("episodic" or "serial").
+func (n *ItunesFeedBuilder) Type(t string) *ItunesFeedBuilder {
+	switch textLowerTrim(t) {
+	case "episodic", "serial":
+		n.parent.WithPSPItunesType(t)
+	default:
+		n.parent.errs = append(n.parent.errs, fmt.Errorf("itunes: type %q must be \"episodic\" or \"serial\"", t))
+	}
+	return n
+}
+
+// Complete sets itunes:complete ("yes") when complete is true.
+func (n *ItunesFeedBuilder) Complete(complete bool) *ItunesFeedBuilder {
+	n.parent.WithPSPItunesComplete(complete)
+	return n
+}
+
+// Image sets itunes:image@href; href must be an absolute URL.
+func (n *ItunesFeedBuilder) Image(href string) *ItunesFeedBuilder {
+	if !isAbsoluteURL(href) {
+		n.parent.errs = append(n.parent.errs, fmt.Errorf("itunes: image href %q must be an absolute URL", href))
+		return n
+	}
+	n.parent.WithPSPImageHref(href)
+	return n
+}
+
+// Done returns the parent feed builder.
+func (n *ItunesFeedBuilder) Done() *FeedBuilder {
+	return n.parent
+}
+
+// Funding adds item-level podcast:funding with an absolute url and optional label.
+func (n *PodcastItemBuilder) Funding(url, label string) *PodcastItemBuilder {
+	if !isAbsoluteURL(url) {
+		n.parent.errs = append(n.parent.errs, fmt.Errorf("podcast: funding url %q must be an absolute URL", url))
+		return n
+	}
+	n.parent.WithPSPFunding(url, label)
+	return n
+}
+
+// Transcript adds podcast:transcript; url must be absolute and typ (MIME type) is required.
+func (n *PodcastItemBuilder) Transcript(url, typ, language, rel string) *PodcastItemBuilder {
+	switch {
+	case !isAbsoluteURL(url):
+		n.parent.errs = append(n.parent.errs, fmt.Errorf("podcast: transcript url %q must be an absolute URL", url))
+	case strings.TrimSpace(typ) == "":
+		n.parent.errs = append(n.parent.errs, errors.New("podcast: transcript type required"))
+	default:
+		n.parent.WithPSPTranscript(url, typ, language, rel)
+	}
+	return n
+}
+
+// Season sets podcast:season and itunes:season; num must be > 0 and name at most 128 characters.
+func (n *PodcastItemBuilder) Season(num int, name string) *PodcastItemBuilder {
+	switch {
+	case num <= 0:
+		n.parent.errs = append(n.parent.errs, fmt.Errorf("podcast: season %d must be > 0", num))
+	case len([]rune(strings.TrimSpace(name))) > 128:
+		n.parent.errs = append(n.parent.errs, errors.New("podcast: season name must be <= 128 characters"))
+	default:
+		n.parent.WithPSPPodcastSeason(num, name)
+	}
+	return n
+}
+
+// Episode sets podcast:episode (and itunes:episode for whole numbers); num must be > 0 and
+// display at most 32 characters.
+func (n *PodcastItemBuilder) Episode(num float64, display string) *PodcastItemBuilder {
+	switch {
+	case !(num > 0) || math.IsInf(num, 0):
+		n.parent.errs = append(n.parent.errs, fmt.Errorf("podcast: episode %v must be > 0", num))
+	case len([]rune(strings.TrimSpace(display))) > 32:
+		n.parent.errs = append(n.parent.errs, errors.New("podcast: episode display must be <= 32 characters"))
+	default:
+		n.parent.WithPSPPodcastEpisode(num, display)
+	}
+	return n
+}
+
+// Location sets podcast:location; see PodcastFeedBuilder.Location.
+func (n *PodcastItemBuilder) Location(name, geo, osm string) *PodcastItemBuilder {
+	if node, ok := podcastLocationNode(name, geo, osm); !ok {
+		n.parent.errs = append(n.parent.errs, fmt.Errorf("podcast: location %s", checkPodcastLocation(node)))
+		return n
+	}
+	n.parent.WithPSPLocation(name, geo, osm)
+	return n
+}
+
+// License sets podcast:license; see PodcastFeedBuilder.License.
+func (n *PodcastItemBuilder) License(identifier, url string) *PodcastItemBuilder {
+	if err := checkLicenseInput(identifier, url); err != nil {
+		n.parent.errs = append(n.parent.errs, err)
+		return n
+	}
+	n.parent.WithPSPLicense(identifier, url)
+	return n
+}
+
+// SocialInteract adds podcast:socialInteract; see ItemBuilder.WithPSPSocialInteract.
+func (n *PodcastItemBuilder) SocialInteract(uri, protocol, accountID, accountURL string, priority int) *PodcastItemBuilder {
+	if node, ok := podcastSocialInteractNode(uri, protocol, accountID, accountURL, priority); !ok {
+		n.parent.errs = append(n.parent.errs, fmt.Errorf("podcast: socialInteract %s", checkPodcastSocialInteract(node)))
+		return n
+	}
+	n.parent.WithPSPSocialInteract(uri, protocol, accountID, accountURL, priority)
+	return n
+}
+
+// checkLicenseInput validates sub-builder license input, requiring a url for non-SPDX identifiers.
+func checkLicenseInput(identifier, url string) error {
+	node, ok := podcastLicenseNode(identifier, url)
+	switch {
+	case !ok:
+		return fmt.Errorf("podcast: license %s", checkPodcastLicense(node))
+	case strings.TrimSpace(url) == "" && !IsSPDXLicenseID(identifier):
+		return fmt.Errorf("podcast: license %q is not a known SPDX identifier and needs a url", strings.TrimSpace(identifier))
+	}
+	return nil
+}
+
+// Done returns the parent item builder.
+func (n *PodcastItemBuilder) Done() *ItemBuilder {
+	return n.parent
+}
+
+// Episode sets itunes:episode; n must be > 0.
+func (n *ItunesItemBuilder) Episode(num int) *ItunesItemBuilder {
+	if num <= 0 {
+		n.parent.errs = append(n.parent.errs, fmt.Errorf("itunes: episode %d must be > 0", num))
+		return n
+	}
+	n.parent.WithPSPEpisode(num)
+	return n
+}
+
+// Season sets itunes:season; n must be > 0.
+func (n *ItunesItemBuilder) Season(num int) *ItunesItemBuilder {
+	if num <= 0 {
+		n.parent.errs = append(n.parent.errs, fmt.Errorf("itunes: season %d must be > 0", num))
+		return n
+	}
+	n.parent.WithPSPSeason(num)
+	return n
+}
+
+// EpisodeType sets itunes:episodeType ("full", "trailer" or "bonus").
+func (n *ItunesItemBuilder) EpisodeType(t string) *ItunesItemBuilder {
+	switch textLowerTrim(t) {
+	case "full", "trailer", "bonus":
+		n.parent.WithPSPEpisodeType(t)
+	default:
+		n.parent.errs = append(n.parent.errs, fmt.Errorf("itunes: episodeType %q must be \"full\", \"trailer\" or \"bonus\"", t))
+	}
+	return n
+}
+
+// Author sets the episode's itunes:author; name must not be empty.
+func (n *ItunesItemBuilder) Author(name string) *ItunesItemBuilder {
+	if strings.TrimSpace(name) == "" {
+		n.parent.errs = append(n.parent.errs, fmt.Errorf("itunes: author must not be empty"))
+		return n
+	}
+	n.parent.WithPSPAuthor(name)
+	return n
+}
+
+// Explicit sets itunes:explicit.
+func (n *ItunesItemBuilder) Explicit(explicit bool) *ItunesItemBuilder {
+	n.parent.WithPSPExplicit(explicit)
+	return n
+}
+
+// Block sets itunes:block ("yes") when block is true.
+func (n *ItunesItemBuilder) Block(block bool) *ItunesItemBuilder {
+	n.parent.WithPSPBlock(block)
+	return n
+}
+
+// Image sets itunes:image@href; href must be an absolute URL.
+func (n *ItunesItemBuilder) Image(href string) *ItunesItemBuilder {
+	if !isAbsoluteURL(href) {
+		n.parent.errs = append(n.parent.errs, fmt.Errorf("itunes: image href %q must be an absolute URL", href))
+		return n
+	}
+	n.parent.WithPSPImageHref(href)
+	return n
+}
+
+// Done returns the parent item builder.
+func (n *ItunesItemBuilder) Done() *ItemBuilder {
+	return n.parent
+}
@@ -1,7 +1,6 @@
 package gofeedx_test
 
 import (
-	"crypto/sha1"
 	"strings"
 	"testing"
 	"time"
@@ -38,67 +37,7 @@ func mustErr(t *testing.T, err error, context string) {
 // uuidV5 computes a UUID v5 from a namespace UUID string and a name per RFC 4122.
 // It is used here to compute expected podcast:guid values without external resources.
 func uuidV5(namespaceUUID, name string) string {
-	ns := mustParseUUID(namespaceUUID)
-	h := sha1.New()
-	h.Write(ns[:])
-	h.Write([]byte(name))
-	sum := h.Sum(nil)
-	var uuid [16]byte
-	copy(uuid[:], sum[:16])
-	// Set version 5
-	uuid[6] = (uuid[6] & 0x0f) | (5 << 4)
-	// Set variant to RFC 4122
-	uuid[8] = (uuid[8] & 0x3f) | 0x80
-	return formatUUID(uuid)
-}
-
-func mustParseUUID(s string) [16]byte {
-	var out [16]byte
-	hex := make([]byte, 0, 32)
-	for i := 0; i < len(s); i++ {
-		c := s[i]
-		if c == '-' || c == '{' || c == '}' {
-			continue
-		}
-		hex = append(hex, c)
-	}
-	if len(hex) != 32 {
-		panic("invalid UUID format")
-	}
-	for i := 0; i < 16; i++ {
-		b := fromHex(hex[i*2])<<4 | fromHex(hex[i*2+1])
-		out[i] = byte(b)
-	}
-	return out
-}
-
-func fromHex(b byte) byte {
-	switch {
-	case '0' <= b && b <= '9':
-		return b - '0'
-	case 'a' <= b && b <= 'f':
-		return b - 'a' + 10
-	case 'A' <= b && b <= 'F':
-		return b - 'A' + 10
-	default:
-		panic("invalid hex")
-	}
-}
-
-func formatUUID(u [16]byte) string {
-	b := make([]byte, 36)
-	hex := "0123456789abcdef"
-	idx := 0
-	for i := 0; i < 16; i++ {
-		if i == 4 || i == 6 || i == 8 || i == 10 {
-			b[idx] = '-'
-			idx++
-		}
-		b[idx] = hex[u[i]>>4]
-		b[idx+1] = hex[u[i]&0x0f]
-		idx += 2
-	}
-	return string(b)
+	return gofeedx.NewUUIDv5(gofeedx.MustParseUUID(namespaceUUID), []byte(name)).String()
 }
 
 // newBaseFeed constructs a minimal base feed as documented in README.
@@ -790,3 +729,474 @@ func TestPSPAtomSelfLinkOmittedWhenFeedURLEmpty(t *testing.T) {
 	mustNoErr(t, err, "ToPSP failed without FeedURL")
 	mustNotContain(t, xml, "<atom:link", "did not expect atom:link when FeedURL is empty")
 }
+
+func TestValidatePSP_ExtensionValues(t *testing.T) {
+	newFeed := func() *gofeedx.Feed {
+		f := newBaseFeed()
+		f.FeedURL = "https://example.com/podcast.rss"
+		f.Categories = []*gofeedx.Category{{Text: "Technology"}}
+		f.Items = []*gofeedx.Item{newBaseEpisode()}
+		return f
+	}
+
+	// Valid extension values pass
+	f := newFeed()
+	f.Extensions = []gofeedx.ExtensionNode{{Name: "itunes:explicit", Text: "false"}}
+	f.Items[0].Extensions = []gofeedx.ExtensionNode{
+		{Name: "itunes:episode", Text: "3"},
+		{Name: "podcast:transcript", Attrs: map[string]string{"url": "https://example.com/ep.vtt", "type": "text/vtt"}},
+	}
+	mustNoErr(t, gofeedx.ValidatePSP(f), "ValidatePSP with valid extensions")
+
+	// Invalid item-level episode number is reported with the offending node
+	f = newFeed()
+	f.Items[0].Extensions = []gofeedx.ExtensionNode{{Name: "itunes:episode", Text: "abc"}}
+	err := gofeedx.ValidatePSP(f)
+	mustErr(t, err, "expected error for non-numeric itunes:episode")
+	mustContain(t, err.Error(), `psp: item[0] extension <itunes:episode> "abc"`, "error should describe offending node: "+err.Error())
+
+	// Invalid channel-level explicit flag
+	f = newFeed()
+	f.Extensions = []gofeedx.ExtensionNode{{Name: "itunes:explicit", Text: "maybe"}}
+	err = gofeedx.ValidatePSP(f)
+	mustErr(t, err, "expected error for invalid channel itunes:explicit")
+	mustContain(t, err.Error(), "psp: channel extension <itunes:explicit>", "error should name channel node: "+err.Error())
+
+	// Transcript with a relative URL
+	f = newFeed()
+	f.Items[0].Extensions = []gofeedx.ExtensionNode{
+		{Name: "podcast:transcript", Attrs: map[string]string{"url": "ep.vtt", "type": "text/vtt"}},
+	}
+	err = gofeedx.ValidatePSP(f)
+	mustErr(t, err, "expected error for relative transcript url")
+	mustContain(t, err.Error(), "url must be an absolute URL", "unexpected transcript error: "+err.Error())
+}
+
+func TestPSPBuilder_ProfileRejectsInvalidExtension(t *testing.T) {
+	_, err := gofeedx.NewFeed("Show").
+		WithLink("https://example.com/show").
+		WithDescription("d").
+		WithLanguage("en-us").
+		WithFeedURL("https://example.com/podcast.rss").
+		WithCategories("Tech").
+		AddItem(gofeedx.NewItem("Ep").
+			WithEnclosure("https://cdn.example.com/ep.mp3", 123, "audio/mpeg").
+			WithExtensions(gofeedx.ExtensionNode{Name: "itunes:season", Text: "-1"})).
+		WithProfiles(gofeedx.ProfilePSP).
+		Build()
+	mustErr(t, err, "expected Build to fail for invalid itunes:season extension")
+	mustContain(t, err.Error(), "itunes:season", "error should mention itunes:season")
+}
+
+func TestPSPExtensions_BooleanTokenVariants(t *testing.T) {
+	feed := newBaseFeed()
+	feed.FeedURL = "https://example.com/podcast.rss"
+	feed.Categories = []*gofeedx.Category{{Text: "Technology"}}
+	feed.Extensions = []gofeedx.ExtensionNode{
+		{Name: "itunes:explicit", Text: "Yes"},
+		{Name: "itunes:complete", Text: "1"},
+		{Name: "podcast:locked", Text: "TRUE"},
+	}
+	ep := newBaseEpisode()
+	ep.Extensions = []gofeedx.ExtensionNode{
+		{Name: "itunes:explicit", Text: "0"},
+		{Name: "itunes:block", Text: "true"},
+	}
+	feed.Items = []*gofeedx.Item{ep}
+	xmlStr, err := gofeedx.ToPSP(feed)
+	mustNoErr(t, err, "ToPSP failed")
+	mustContain(t, xmlStr, "<itunes:explicit>true</itunes:explicit>", "expected channel itunes:explicit=true from Yes")
+	mustContain(t, xmlStr, "<itunes:complete>yes</itunes:complete>", "expected itunes:complete=yes from 1")
+	mustContain(t, xmlStr, "<podcast:locked>yes</podcast:locked>", "expected podcast:locked=yes from TRUE")
+	mustContain(t, xmlStr, "<itunes:explicit>false</itunes:explicit>", "expected item itunes:explicit=false from 0")
+	mustContain(t, xmlStr, "<itunes:block>yes</itunes:block>", "expected itunes:block=yes from true")
+}
+
+func TestNamespaceSubBuilders(t *testing.T) {
+	b := gofeedx.NewFeed("Show").
+		WithLink("https://example.com/show").
+		WithDescription("d").
+		WithLanguage("en-us").
+		WithFeedURL("https://example.com/podcast.rss").
+		WithCategories("Tech").
+		Podcast().Funding("https://example.com/donate", "Support").Locked(true).TXT("verify-me", "verify").Done().
+		Itunes().Type("serial").Explicit(false).Image("https://example.com/art.jpg").Done()
+
+	ib := gofeedx.NewItem("Ep").
+		WithEnclosure("https://cdn.example.com/ep.mp3", 123, "audio/mpeg").
+		Itunes().Episode(3).Season(1).EpisodeType("bonus").Done().
+		Podcast().Transcript("https://example.com/ep.vtt", "text/vtt", "en", "").Done()
+	b.AddItem(ib)
+
+	f, err := b.WithProfiles(gofeedx.ProfilePSP).Build()
+	mustNoErr(t, err, "Build with namespace sub-builders")
+	xml, err := gofeedx.ToPSP(f)
+	mustNoErr(t, err, "ToPSP failed")
+	mustContain(t, xml, `<podcast:funding url="https://example.com/donate">Support</podcast:funding>`, "expected podcast:funding")
+	mustContain(t, xml, "<podcast:locked>yes</podcast:locked>", "expected podcast:locked")
+	mustContain(t, xml, `purpose="verify"`, "expected podcast:txt purpose")
+	mustContain(t, xml, "<itunes:type>serial</itunes:type>", "expected itunes:type")
+	mustContain(t, xml, "<itunes:explicit>false</itunes:explicit>", "expected itunes:explicit")
+	mustContain(t, xml, "<itunes:episode>3</itunes:episode>", "expected itunes:episode")
+	mustContain(t, xml, "<itunes:season>1</itunes:season>", "expected itunes:season")
+	mustContain(t, xml, "<itunes:episodeType>bonus</itunes:episodeType>", "expected itunes:episodeType")
+	mustContain(t, xml, `<podcast:transcript url="https://example.com/ep.vtt" type="text/vtt" language="en">`, "expected podcast:transcript")
+}
+
+func TestNamespaceSubBuilders_InvalidInput(t *testing.T) {
+	_, err := gofeedx.NewFeed("Show").Itunes().Type("weekly").Done().Podcast().Funding("/donate", "x").Done().Build()
+	mustErr(t, err, "expected strict Build to report sub-builder errors")
+	mustContain(t, err.Error(), `itunes: type "weekly"`, "expected itunes:type error: "+err.Error())
+	mustContain(t, err.Error(), `podcast: funding url "/donate"`, "expected funding error: "+err.Error())
+
+	_, err = gofeedx.NewItem("Ep").Itunes().Episode(0).Done().Build()
+	mustErr(t, err, "expected item Build to report invalid episode")
+	mustContain(t, err.Error(), "itunes: episode 0 must be > 0", "unexpected error: "+err.Error())
+
+	// the feed builder reports them too, though the item itself is skipped as before
+	_, err = gofeedx.NewFeed("Show").AddItem(gofeedx.NewItem("Ep").Itunes().Episode(0).Done()).Build()
+	mustErr(t, err, "expected feed Build to report the item's sub-builder errors")
+	mustContain(t, err.Error(), `builder: item "Ep": itunes: episode 0 must be > 0`, "unexpected error: "+err.Error())
+
+	// Lenient builders drop invalid input without failing
+	f, err := gofeedx.NewFeed("Show").WithLenient().Itunes().Type("weekly").Done().Build()
+	mustNoErr(t, err, "lenient Build")
+	if len(f.Extensions) != 0 {
+		t.Errorf("expected no extensions from invalid input, got %d", len(f.Extensions))
+	}
+}
+
+func TestItunesDuration_FormatAndParse(t *testing.T) {
+	if got := gofeedx.FormatItunesDuration(1801); got != "00:30:01" {
+		t.Errorf("FormatItunesDuration(1801) = %q", got)
+	}
+	if got := gofeedx.FormatItunesDuration(360000); got != "100:00:00" {
+		t.Errorf("FormatItunesDuration(360000) = %q", got)
+	}
+	valid := map[string]int{"1801": 1801, "30:01": 1801, "00:30:01": 1801, " 1:00:00 ": 3600}
+	for in, want := range valid {
+		got, err := gofeedx.ParseItunesDuration(in)
+		if err != nil || got != want {
+			t.Errorf("ParseItunesDuration(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "abc", "1:2:3:4", "00:61", "-5", "1::2", "+3"} {
+		if _, err := gofeedx.ParseItunesDuration(in); err == nil {
+			t.Errorf("ParseItunesDuration(%q) expected error", in)
+		}
+	}
+}
+
+func TestPSPDurationHHMMSS(t *testing.T) {
+	build := func(hhmmss bool) *gofeedx.Feed {
+		f, err := gofeedx.NewFeed("Show").
+			WithLink("https://example.com/show").
+			WithDescription("d").
+			WithLanguage("en-us").
+			WithFeedURL("https://example.com/podcast.rss").
+			WithCategories("Tech").
+			WithPSPDurationHHMMSS(hhmmss).
+			AddItem(gofeedx.NewItem("Ep").
+				WithEnclosure("https://cdn.example.com/ep.mp3", 123, "audio/mpeg").
+				WithDurationSeconds(3725)).
+			WithProfiles(gofeedx.ProfilePSP).
+			Build()
+		mustNoErr(t, err, "Build PSP feed")
+		return f
+	}
+	f := build(true)
+	xml, err := gofeedx.ToPSP(f)
+	mustNoErr(t, err, "ToPSP failed")
+	mustContain(t, xml, "<itunes:duration>01:02:05</itunes:duration>", "expected HH:MM:SS duration")
+	mustNotContain(t, xml, "_psp:", "internal marker must not be emitted")
+
+	// Default remains raw seconds
+	f = build(false)
+	xml, err = gofeedx.ToPSP(f)
+	mustNoErr(t, err, "ToPSP failed")
+	mustContain(t, xml, "<itunes:duration>3725</itunes:duration>", "expected seconds duration by default")
+
+	// Invalid itunes:duration extension values are rejected by validation
+	f.Items[0].Extensions = []gofeedx.ExtensionNode{{Name: "itunes:duration", Text: "1h"}}
+	mustErr(t, gofeedx.ValidatePSP(f), "expected invalid itunes:duration extension to fail validation")
+}
+
+func TestPSPTrailer(t *testing.T) {
+	pub := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	b := gofeedx.NewFeed("Show").
+		WithLink("https://example.com/show").
+		WithDescription("d").
+		WithLanguage("en-us").
+		WithFeedURL("https://example.com/podcast.rss").
+		WithCategories("Tech").
+		WithPSPTrailer("Season 2 is coming", "https://cdn.example.com/s2.mp3", "audio/mpeg", 12345, pub, 2).
+		Podcast().Trailer("Teaser", "https://cdn.example.com/t.mp3", "", 0, pub, 0).Done()
+	b.AddItem(gofeedx.NewItem("Ep").WithEnclosure("https://cdn.example.com/ep.mp3", 123, "audio/mpeg"))
+
+	f, err := b.WithProfiles(gofeedx.ProfilePSP).Build()
+	mustNoErr(t, err, "Build with trailers")
+	xml, err := gofeedx.ToPSP(f)
+	mustNoErr(t, err, "ToPSP failed")
+	mustContain(t, xml, `<podcast:trailer length="12345" pubdate="Fri, 01 Mar 2024 08:00:00 +0000" season="2" type="audio/mpeg" url="https://cdn.example.com/s2.mp3">Season 2 is coming</podcast:trailer>`, "expected full podcast:trailer")
+	mustContain(t, xml, `<podcast:trailer pubdate="Fri, 01 Mar 2024 08:00:00 +0000" url="https://cdn.example.com/t.mp3">Teaser</podcast:trailer>`, "expected minimal podcast:trailer")
+
+	_, err = gofeedx.NewFeed("Show").Podcast().Trailer("T", "/t.mp3", "", 0, pub, 0).Done().Build()
+	mustErr(t, err, "expected error for relative trailer url")
+
+	f.Extensions = append(f.Extensions, gofeedx.ExtensionNode{Name: "podcast:trailer", Attrs: map[string]string{"url": "t.mp3"}, Text: "x"})
+	mustErr(t, gofeedx.ValidatePSP(f), "expected ValidatePSP to reject relative trailer url")
+}
+
+func TestPSPPodcastSeasonAndEpisode(t *testing.T) {
+	b := gofeedx.NewFeed("Show").
+		WithLink("https://example.com/show").
+		WithDescription("d").
+		WithLanguage("en-us").
+		WithFeedURL("https://example.com/podcast.rss").
+		WithCategories("Tech")
+	b.AddItem(gofeedx.NewItem("Ep").
+		WithEnclosure("https://cdn.example.com/ep.mp3", 123, "audio/mpeg").
+		WithPSPPodcastSeason(2, "Road Trip").
+		WithPSPPodcastEpisode(7, "Ch. 7"))
+	b.AddItem(gofeedx.NewItem("Bonus").
+		WithEnclosure("https://cdn.example.com/b.mp3", 123, "audio/mpeg").
+		Podcast().Episode(7.5, "").Done())
+
+	f, err := b.WithProfiles(gofeedx.ProfilePSP).Build()
+	mustNoErr(t, err, "Build with podcast season/episode")
+	xml, err := gofeedx.ToPSP(f)
+	mustNoErr(t, err, "ToPSP failed")
+	mustContain(t, xml, "<itunes:season>2</itunes:season>", "expected itunes:season from the same number")
+	mustContain(t, xml, `<podcast:season name="Road Trip">2</podcast:season>`, "expected podcast:season with name")
+	mustContain(t, xml, "<itunes:episode>7</itunes:episode>", "expected itunes:episode for whole number")
+	mustContain(t, xml, `<podcast:episode display="Ch. 7">7</podcast:episode>`, "expected podcast:episode with display")
+	mustContain(t, xml, "<podcast:episode>7.5</podcast:episode>", "expected fractional podcast:episode")
+	if strings.Count(xml, "<itunes:episode>") != 1 {
+		t.Errorf("expected no itunes:episode for fractional episode numbers")
+	}
+
+	_, err = gofeedx.NewItem("Ep").Podcast().Season(0, "x").Episode(-1, "").Done().Build()
+	mustErr(t, err, "expected errors for invalid season/episode")
+
+	f.Items[0].Extensions = append(f.Items[0].Extensions, gofeedx.ExtensionNode{Name: "podcast:episode", Text: "abc"})
+	mustErr(t, gofeedx.ValidatePSP(f), "expected ValidatePSP to reject non-numeric podcast:episode")
+}
+
+func TestPSPLocation(t *testing.T) {
+	b := gofeedx.NewFeed("Show").
+		WithLink("https://example.com/show").
+		WithDescription("d").
+		WithLanguage("en-us").
+		WithFeedURL("https://example.com/podcast.rss").
+		WithCategories("Tech").
+		WithPSPLocation("Austin, TX", "geo:30.2672,-97.7431", "R113314").
+		WithPSPLocation("Nowhere", "geo:200,0", "") // ignored: latitude out of range
+	b.AddItem(gofeedx.NewItem("Ep").
+		WithEnclosure("https://cdn.example.com/ep.mp3", 123, "audio/mpeg").
+		Podcast().Location("Gitksan Territory", "geo:55.5,-127.9;u=350", "").Done())
+
+	f, err := b.WithProfiles(gofeedx.ProfilePSP).Build()
+	mustNoErr(t, err, "Build with podcast:location")
+	xml, err := gofeedx.ToPSP(f)
+	mustNoErr(t, err, "ToPSP failed")
+	mustContain(t, xml, `<podcast:location geo="geo:30.2672,-97.7431" osm="R113314">Austin, TX</podcast:location>`, "expected channel podcast:location")
+	mustContain(t, xml, `<podcast:location geo="geo:55.5,-127.9;u=350">Gitksan Territory</podcast:location>`, "expected item podcast:location")
+	mustNotContain(t, xml, "Nowhere", "expected invalid location to be ignored")
+
+	_, err = gofeedx.NewFeed("Show").Podcast().Location("Somewhere", "30.1,20.2", "").Done().Build()
+	mustErr(t, err, "expected error for geo without geo: scheme")
+	mustContain(t, err.Error(), "podcast: location geo must be", "unexpected error: "+err.Error())
+
+	f.Extensions = append(f.Extensions, gofeedx.ExtensionNode{Name: "podcast:location", Attrs: map[string]string{"osm": "X1"}, Text: "Bad"})
+	mustErr(t, gofeedx.ValidatePSP(f), "expected ValidatePSP to reject invalid osm reference")
+}
+
+func TestPSPLicense(t *testing.T) {
+	b := gofeedx.NewFeed("Show").
+		WithLink("https://example.com/show").
+		WithDescription("d").
+		WithLanguage("en-us").
+		WithFeedURL("https://example.com/podcast.rss").
+		WithCategories("Tech").
+		WithPSPLicense("cc-by-4.0", "")
+	b.AddItem(gofeedx.NewItem("Ep").
+		WithEnclosure("https://cdn.example.com/ep.mp3", 123, "audio/mpeg").
+		Podcast().License("my-podcast-license-v1", "https://example.org/license").Done())
+
+	f, err := b.WithProfiles(gofeedx.ProfilePSP).Build()
+	mustNoErr(t, err, "Build with podcast:license")
+	xml, err := gofeedx.ToPSP(f)
+	mustNoErr(t, err, "ToPSP failed")
+	mustContain(t, xml, "<podcast:license>CC-BY-4.0</podcast:license>", "expected canonical SPDX identifier")
+	mustContain(t, xml, `<podcast:license url="https://example.org/license">my-podcast-license-v1</podcast:license>`, "expected custom license with url")
+
+	if !gofeedx.IsSPDXLicenseID("MIT") || gofeedx.IsSPDXLicenseID("my-license") {
+		t.Errorf("unexpected IsSPDXLicenseID result")
+	}
+
+	_, err = gofeedx.NewFeed("Show").Podcast().License("my-license", "").Done().Build()
+	mustErr(t, err, "expected error for unknown identifier without url")
+	mustContain(t, err.Error(), "needs a url", "unexpected error: "+err.Error())
+}
+
+func TestPSPMedium(t *testing.T) {
+	b := gofeedx.NewFeed("Album").
+		WithLink("https://example.com/album").
+		WithDescription("d").
+		WithLanguage("en-us").
+		WithFeedURL("https://example.com/album.rss").
+		WithCategories("Music").
+		WithPSPMedium("Music")
+	b.AddItem(gofeedx.NewItem("Track 1").WithEnclosure("https://cdn.example.com/t1.mp3", 123, "audio/mpeg"))
+
+	f, err := b.WithProfiles(gofeedx.ProfilePSP).Build()
+	mustNoErr(t, err, "Build with podcast:medium")
+	xml, err := gofeedx.ToPSP(f)
+	mustNoErr(t, err, "ToPSP failed")
+	mustContain(t, xml, "<podcast:medium>music</podcast:medium>", "expected normalized podcast:medium")
+
+	list, err := gofeedx.NewFeed("List").Podcast().Medium("musicl").Done().Build()
+	mustNoErr(t, err, "Build with list medium")
+	if got := list.Extensions[0].Text; got != "musicL" {
+		t.Errorf("expected list medium musicL, got %q", got)
+	}
+
+	_, err = gofeedx.NewFeed("Show").Podcast().Medium("radio").Done().Build()
+	mustErr(t, err, "expected error for unknown medium")
+
+	f.Extensions = append(f.Extensions, gofeedx.ExtensionNode{Name: "podcast:medium", Text: "radio"})
+	mustErr(t, gofeedx.ValidatePSP(f), "expected ValidatePSP to reject unknown medium")
+}
+
+func TestPSPSocialInteract(t *testing.T) {
+	b := gofeedx.NewFeed("Show").
+		WithLink("https://example.com/show").
+		WithDescription("d").
+		WithLanguage("en-us").
+		WithFeedURL("https://example.com/podcast.rss").
+		WithCategories("Tech")
+	b.AddItem(gofeedx.NewItem("Ep").
+		WithEnclosure("https://cdn.example.com/ep.mp3", 123, "audio/mpeg").
+		WithPSPSocialInteract("https://podcastindex.social/web/@dave/108013847520053258", "ActivityPub", "@dave", "https://podcastindex.social/web/@dave", 1).
+		Podcast().SocialInteract("", "disabled", "", "", 0).Done())
+
+	f, err := b.WithProfiles(gofeedx.ProfilePSP).Build()
+	mustNoErr(t, err, "Build with podcast:socialInteract")
+	xml, err := gofeedx.ToPSP(f)
+	mustNoErr(t, err, "ToPSP failed")
+	mustContain(t, xml, `<podcast:socialInteract accountId="@dave" accountUrl="https://podcastindex.social/web/@dave" priority="1" protocol="activitypub" uri="https://podcastindex.social/web/@dave/108013847520053258"></podcast:socialInteract>`, "expected podcast:socialInteract")
+	mustContain(t, xml, `<podcast:socialInteract protocol="disabled"></podcast:socialInteract>`, "expected disabled podcast:socialInteract")
+
+	_, err = gofeedx.NewItem("Ep").Podcast().SocialInteract("", "activitypub", "", "", 0).Done().Build()
+	mustErr(t, err, "expected error for missing uri")
+	mustContain(t, err.Error(), "podcast: socialInteract requires a uri", "unexpected error: "+err.Error())
+
+	f.Items[0].Extensions = append(f.Items[0].Extensions, gofeedx.ExtensionNode{Name: "podcast:socialInteract", Attrs: map[string]string{"uri": "x"}})
+	mustErr(t, gofeedx.ValidatePSP(f), "expected ValidatePSP to reject socialInteract without protocol")
+}
+
+func TestPSP_MultiplePodcastTXT(t *testing.T) {
+	f := newBaseFeed()
+	f.Items = append(f.Items, newBaseEpisode())
+	f.FeedURL = "https://example.com/podcast.rss"
+	f.Image = &gofeedx.Image{Url: "https://example.com/artwork.jpg"}
+	f.Categories = append(f.Categories, &gofeedx.Category{Text: "Technology"})
+	f.Extensions = append(f.Extensions,
+		gofeedx.ExtensionNode{Name: "podcast:txt", Text: "apple-token", Attrs: map[string]string{"purpose": "applepodcastsverify"}},
+		gofeedx.ExtensionNode{Name: "podcast:txt", Text: "spotify-token", Attrs: map[string]string{"purpose": "spotify"}},
+	)
+	out, err := gofeedx.ToPSP(f)
+	mustNoErr(t, err, "ToPSP failed")
+	if strings.Count(out, "<podcast:txt") != 2 {
+		t.Fatalf("expected two podcast:txt elements:\n%s", out)
+	}
+	mustContain(t, out, ">apple-token<", "expected first podcast:txt")
+	mustContain(t, out, ">spotify-token<", "expected second podcast:txt")
+}
+
+func TestPSP_MultiplePodcastFunding(t *testing.T) {
+	b := gofeedx.NewFeed("Show").
+		WithLink("https://example.com/").
+		WithDescription("d").
+		WithLanguage("en").
+		WithFeedURL("https://example.com/podcast.rss").
+		WithImage("https://example.com/artwork.jpg", "", "").
+		WithCategories("Technology").
+		WithAuthor("Host", "").
+		WithPSPFunding("https://patreon.com/show", "Patreon").
+		WithPSPFunding("https://paypal.me/show", "PayPal")
+	b.Podcast().Funding("https://example.com/members", "Members")
+	b.AddItem(gofeedx.NewItem("E1").WithID("1").WithCreated(time.Now()).
+		WithEnclosure("https://example.com/e1.mp3", 1, "audio/mpeg").WithDescription("e"))
+	f, err := b.Build()
+	mustNoErr(t, err, "Build failed")
+	out, err := gofeedx.ToPSP(f)
+	mustNoErr(t, err, "ToPSP failed")
+	if strings.Count(out, "<podcast:funding") != 3 {
+		t.Fatalf("expected three podcast:funding elements:\n%s", out)
+	}
+	mustContain(t, out, ">Patreon<", "expected Patreon funding")
+	mustContain(t, out, ">Members<", "expected Members funding")
+}
+
+func TestPSP_ItemFundingAndChannelTranscript(t *testing.T) {
+	newFeed := func() *gofeedx.FeedBuilder {
+		return gofeedx.NewFeed("Show").
+			WithLink("https://example.com/").
+			WithDescription("d").
+			WithLanguage("en").
+			WithFeedURL("https://example.com/podcast.rss").
+			WithImage("https://example.com/artwork.jpg", "", "").
+			WithCategories("Technology").
+			WithAuthor("Host", "").
+			WithProfiles(gofeedx.ProfilePSP)
+	}
+	newItem := func() *gofeedx.ItemBuilder {
+		return gofeedx.NewItem("E1").WithID("1").WithCreated(time.Now()).
+			WithEnclosure("https://example.com/e1.mp3", 1, "audio/mpeg").WithDescription("e")
+	}
+
+	b := newFeed()
+	b.AddItem(newItem().WithPSPFunding("https://sponsor.example.com/", "Sponsor").
+		Podcast().Funding("https://example.com/tip", "Tip").Done())
+	f, err := b.Build()
+	mustNoErr(t, err, "Build failed")
+	out, err := gofeedx.ToPSP(f)
+	mustNoErr(t, err, "ToPSP failed")
+	mustContain(t, out, `<podcast:funding url="https://sponsor.example.com/">Sponsor</podcast:funding>`, "expected item funding")
+	mustContain(t, out, ">Tip<", "expected second item funding")
+
+	_, err = newItem().Podcast().Funding("/tip", "Tip").Done().Build()
+	mustErr(t, err, "expected error for relative item funding url")
+
+	b = newFeed().WithExtensions(gofeedx.ExtensionNode{Name: "podcast:transcript", Attrs: map[string]string{"url": "https://example.com/t.vtt", "type": "text/vtt"}})
+	b.AddItem(newItem())
+	_, err = b.Build()
+	mustErr(t, err, "expected error for channel-level transcript")
+	if err != nil && !strings.Contains(err.Error(), "only allowed at item scope") {
+		t.Errorf("unexpected error message: %v", err)
+	}
+}
+
+func TestPSP_ItemAuthor(t *testing.T) {
+	b := gofeedx.NewFeed("Show").
+		WithLink("https://example.com/").
+		WithDescription("d").
+		WithLanguage("en").
+		WithFeedURL("https://example.com/podcast.rss").
+		WithImage("https://example.com/artwork.jpg", "", "").
+		WithCategories("Technology").
+		WithAuthor("Host", "host@example.com").
+		WithProfiles(gofeedx.ProfilePSP)
+	b.AddItem(gofeedx.NewItem("E1").WithID("1").WithCreated(time.Now()).
+		WithEnclosure("https://example.com/e1.mp3", 1, "audio/mpeg").WithDescription("e").
+		WithPSPAuthor("  Guest Host  "))
+	f, err := b.Build()
+	mustNoErr(t, err, "Build failed")
+	out, err := gofeedx.ToPSP(f)
+	mustNoErr(t, err, "ToPSP failed")
+	mustContain(t, out, "<itunes:author>Guest Host</itunes:author>", "expected item itunes:author")
+
+	_, err = gofeedx.NewItem("E1").Itunes().Author(" ").Done().Build()
+	mustErr(t, err, "expected error for empty itunes author")
+}
@@ -0,0 +1,100 @@
+package gofeedx
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultWordsPerMinute is the reading speed used by WithReadingTime when none is given.
+const DefaultWordsPerMinute = 200
+
+// JSON Feed custom keys carrying computed reading metadata. They are recorded as
+// _json:extension options, so XML writers skip them.
+const (
+	readingTimeKey = "_reading_time_minutes"
+	wordCountKey   = "_word_count"
+)
+
+var htmlMarkupPattern = regexp.MustCompile(`(?s)<!--.*?-->|<[^>]*>`)
+
+// WordCount returns the number of whitespace-separated words in content after
+// stripping HTML tags and unescaping entities.
+func WordCount(content string) int {
+	if strings.TrimSpace(content) == "" {
+		return 0
+	}
+	text := html.UnescapeString(htmlMarkupPattern.ReplaceAllString(content, " "))
+	return len(strings.Fields(text))
+}
+
+// ReadingTimeMinutes returns the estimated reading time for words at wordsPerMinute,
+// rounded up to whole minutes (at least 1 for non-empty content).
+// A non-positive wordsPerMinute uses DefaultWordsPerMinute.
+func ReadingTimeMinutes(words, wordsPerMinute int) int {
+	if words <= 0 {
+		return 0
+	}
+	if wordsPerMinute <= 0 {
+		wordsPerMinute = DefaultWordsPerMinute
+	}
+	return (words + wordsPerMinute - 1) / wordsPerMinute
+}
+
+// AddReadingTime computes word count and reading time from item.Content and records them as
+// options written as the numeric _word_count and _reading_time_minutes JSON Feed keys; XML
+// output is unaffected. Items without content, or that already have a reading time, are
+// left unchanged.
+func AddReadingTime(item *Item, wordsPerMinute int) {
+	if item == nil {
+		return
+	}
+	if _, minutes := item.ReadingTime(); minutes > 0 {
+		return
+	}
+	words := WordCount(item.Content)
+	if words == 0 {
+		return
+	}
+	item.options = append(item.options,
+		readingNode(wordCountKey, words),
+		readingNode(readingTimeKey, ReadingTimeMinutes(words, wordsPerMinute)),
+	)
+}
+
+// readingNode returns the _json:extension option writing n under key.
+func readingNode(key string, n int) ExtensionNode {
+	return ExtensionNode{Name: "_json:extension", Attrs: map[string]string{"key": key}, Text: strconv.Itoa(n)}
+}
+
+// ReadingTime returns the word count and reading time in minutes recorded by AddReadingTime
+// or WithReadingTime, or zeros when the item has none.
+func (it *Item) ReadingTime() (words, minutes int) {
+	if it == nil {
+		return 0, 0
+	}
+	for _, n := range it.options {
+		if n.Name != "_json:extension" {
+			continue
+		}
+		switch n.Attrs["key"] {
+		case wordCountKey:
+			words, _ = strconv.Atoi(n.Text)
+		case readingTimeKey:
+			minutes, _ = strconv.Atoi(n.Text)
+		}
+	}
+	return words, minutes
+}
+
+// WithReadingTime enables reading-time enrichment on Build: each item with Content gets
+// numeric _word_count and _reading_time_minutes JSON Feed keys (see AddReadingTime).
+// A non-positive wordsPerMinute uses DefaultWordsPerMinute.
+func (b *FeedBuilder) WithReadingTime(wordsPerMinute int) *FeedBuilder {
+	if wordsPerMinute <= 0 {
+		wordsPerMinute = DefaultWordsPerMinute
+	}
+	b.readingTimeWPM = wordsPerMinute
+	return b
+}
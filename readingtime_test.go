@@ -0,0 +1,79 @@
+package gofeedx_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestWordCountAndReadingTime(t *testing.T) {
+	if got := gofeedx.WordCount(`<p>Hello <b>brave</b> new&nbsp;world</p><!-- not counted -->`); got != 4 {
+		t.Errorf("WordCount = %d, want 4", got)
+	}
+	if got := gofeedx.WordCount("   "); got != 0 {
+		t.Errorf("WordCount(blank) = %d, want 0", got)
+	}
+	cases := []struct{ words, wpm, want int }{
+		{0, 200, 0},
+		{1, 200, 1},
+		{200, 200, 1},
+		{201, 200, 2},
+		{450, 0, 3}, // default 200 wpm
+	}
+	for _, c := range cases {
+		if got := gofeedx.ReadingTimeMinutes(c.words, c.wpm); got != c.want {
+			t.Errorf("ReadingTimeMinutes(%d, %d) = %d, want %d", c.words, c.wpm, got, c.want)
+		}
+	}
+}
+
+func TestWithReadingTime_EnrichesJSONOnly(t *testing.T) {
+	body := "<p>" + strings.Repeat("word ", 250) + "</p>"
+	f, err := gofeedx.NewFeed("Blog").
+		WithLink("https://example.org/").
+		WithDescription("Posts").
+		WithReadingTime(0).
+		AddItem(gofeedx.NewItem("Long post").WithID("p1").WithCreated(time.Now().UTC()).WithContentHTML(body)).
+		AddItem(gofeedx.NewItem("No content").WithID("p2").WithCreated(time.Now().UTC())).
+		Build()
+	mustNoErr(t, err, "Build failed")
+
+	js, err := gofeedx.ToJSON(f)
+	mustNoErr(t, err, "ToJSON failed")
+	var doc struct {
+		Items []map[string]any `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(js), &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if doc.Items[0]["_reading_time_minutes"] != float64(2) || doc.Items[0]["_word_count"] != float64(250) {
+		t.Errorf("expected reading metadata on first item, got %v", doc.Items[0])
+	}
+	if _, ok := doc.Items[1]["_reading_time_minutes"]; ok {
+		t.Errorf("did not expect reading time for item without content")
+	}
+
+	rss, err := gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS failed")
+	mustNotContain(t, rss, "_reading_time_minutes", "did not expect reading time in RSS")
+	atom, err := gofeedx.ToAtom(f)
+	mustNoErr(t, err, "ToAtom failed")
+	mustNotContain(t, atom, "_word_count", "did not expect word count in Atom")
+	if len(f.Items[0].Extensions) != 0 {
+		t.Errorf("expected no item extensions, got %+v", f.Items[0].Extensions)
+	}
+
+	// Enrichment is idempotent
+	gofeedx.AddReadingTime(f.Items[0], 100)
+	if words, minutes := f.Items[0].ReadingTime(); words != 250 || minutes != 2 {
+		t.Errorf("ReadingTime() = %d, %d, want 250, 2", words, minutes)
+	}
+	js, err = gofeedx.ToJSON(f)
+	mustNoErr(t, err, "ToJSON failed")
+	if n := strings.Count(js, "_reading_time_minutes"); n != 1 {
+		t.Errorf("expected a single reading time key, got %d", n)
+	}
+}
@@ -0,0 +1,116 @@
+package gofeedx
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Writer renders feeds in a custom output format registered with RegisterProfile.
+// Validate is run by FeedBuilder.Build for the profile like the built-in validators;
+// Encode writes the document.
+type Writer interface {
+	Validate(feed *Feed) error
+	Encode(feed *Feed, w io.Writer) error
+}
+
+// XMLWriter adapts an XmlFeed wrapper to Writer, so custom XML formats are written with the
+// same declaration and indentation as the built-in ones. ValidateFunc may be nil.
+type XMLWriter struct {
+	Wrap         func(feed *Feed) XmlFeed
+	ValidateFunc func(feed *Feed) error
+}
+
+// Validate calls ValidateFunc when set.
+func (x XMLWriter) Validate(feed *Feed) error {
+	if x.ValidateFunc == nil {
+		return nil
+	}
+	return x.ValidateFunc(feed)
+}
+
+// Encode writes Wrap(feed) as an XML document to w.
+func (x XMLWriter) Encode(feed *Feed, w io.Writer) error {
+	return WriteXML(x.Wrap(feed), w)
+}
+
+type customProfile struct {
+	name   string
+	writer Writer
+}
+
+var (
+	customProfilesMu  sync.RWMutex
+	customProfiles    = map[Profile]customProfile{}
+	customProfileIDs  = map[string]Profile{}
+	nextCustomProfile = ProfileJSON + 1
+)
+
+// RegisterProfile registers w as the writer for a custom format called name and returns the
+// Profile that selects it in WithProfiles, Render and Encoder.Encode. Names are case-insensitive;
+// registering a name again replaces its writer and returns the same Profile. The built-in
+// names "rss", "atom", "psp" and "json" cannot be registered.
+func RegisterProfile(name string, w Writer) (Profile, error) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if key == "" {
+		return 0, errors.New("registry: profile name must not be empty")
+	}
+	if w == nil {
+		return 0, fmt.Errorf("registry: nil writer for profile %q", key)
+	}
+	for _, p := range []Profile{ProfileRSS, ProfileAtom, ProfilePSP, ProfileJSON} {
+		if p.String() == key {
+			return 0, fmt.Errorf("registry: profile %q is built in", key)
+		}
+	}
+	customProfilesMu.Lock()
+	defer customProfilesMu.Unlock()
+	p, ok := customProfileIDs[key]
+	if !ok {
+		p = nextCustomProfile
+		nextCustomProfile++
+		customProfileIDs[key] = p
+	}
+	customProfiles[p] = customProfile{name: key, writer: w}
+	return p, nil
+}
+
+// ProfileByName returns the built-in or registered profile called name (case-insensitive).
+func ProfileByName(name string) (Profile, bool) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	for _, p := range []Profile{ProfileRSS, ProfileAtom, ProfilePSP, ProfileJSON} {
+		if p.String() == key {
+			return p, true
+		}
+	}
+	customProfilesMu.RLock()
+	defer customProfilesMu.RUnlock()
+	p, ok := customProfileIDs[key]
+	return p, ok
+}
+
+func lookupCustomProfile(p Profile) (customProfile, bool) {
+	customProfilesMu.RLock()
+	defer customProfilesMu.RUnlock()
+	c, ok := customProfiles[p]
+	return c, ok
+}
+
+// renderCustom renders feed with the writer registered for profile.
+func renderCustom(feed *Feed, profile Profile) ([]byte, error) {
+	c, ok := lookupCustomProfile(profile)
+	if !ok {
+		return nil, fmt.Errorf("render: unknown profile %d", profile)
+	}
+	if feed == nil {
+		return nil, errors.New("nil feed")
+	}
+	var buf bytes.Buffer
+	if err := c.writer.Encode(feed, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,106 @@
+package gofeedx_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+// lineWriter is a toy format: one "title<TAB>link" line per item.
+type lineWriter struct{}
+
+func (lineWriter) Validate(f *gofeedx.Feed) error {
+	if len(f.Items) == 0 {
+		return errors.New("at least one item required")
+	}
+	return nil
+}
+
+func (lineWriter) Encode(f *gofeedx.Feed, w io.Writer) error {
+	for _, it := range f.Items {
+		link := ""
+		if it.Link != nil {
+			link = it.Link.Href
+		}
+		if _, err := fmt.Fprintf(w, "%s\t%s\n", it.Title, link); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestRegisterProfile_CustomWriter(t *testing.T) {
+	p, err := gofeedx.RegisterProfile("Lines", lineWriter{})
+	mustNoErr(t, err, "RegisterProfile")
+	if again, err := gofeedx.RegisterProfile("lines", lineWriter{}); err != nil || again != p {
+		t.Fatalf("re-registering should keep the profile, got %v, %v", again, err)
+	}
+	if p.String() != "lines" {
+		t.Errorf("String() = %q", p.String())
+	}
+	if got, ok := gofeedx.ProfileByName("LINES"); !ok || got != p {
+		t.Errorf("ProfileByName = %v, %v", got, ok)
+	}
+
+	_, err = gofeedx.NewFeed("Custom").WithProfiles(p).Build()
+	mustErr(t, err, "expected custom validation to fail without items")
+	if !strings.HasPrefix(err.Error(), "lines: ") {
+		t.Errorf("expected error prefixed with the profile name, got %v", err)
+	}
+
+	b := gofeedx.NewFeed("Custom").WithProfiles(p)
+	b.AddItem(gofeedx.NewItem("one").WithLink("https://example.org/1"))
+	f, err := b.Build()
+	mustNoErr(t, err, "Build")
+	out, err := gofeedx.Render(f, p)
+	mustNoErr(t, err, "Render")
+	if out != "one\thttps://example.org/1\n" {
+		t.Errorf("Render = %q", out)
+	}
+	var buf bytes.Buffer
+	mustNoErr(t, gofeedx.NewEncoder().Encode(f, p, &buf), "Encode")
+	if buf.String() != out {
+		t.Errorf("Encoder output %q differs from Render %q", buf.String(), out)
+	}
+}
+
+func TestRegisterProfile_Errors(t *testing.T) {
+	for _, name := range []string{"", "rss", " JSON "} {
+		if _, err := gofeedx.RegisterProfile(name, lineWriter{}); err == nil {
+			t.Errorf("expected error registering %q", name)
+		}
+	}
+	if _, err := gofeedx.RegisterProfile("nilwriter", nil); err == nil {
+		t.Errorf("expected error for nil writer")
+	}
+	if _, err := gofeedx.Render(&gofeedx.Feed{}, gofeedx.Profile(999)); err == nil {
+		t.Errorf("expected error for unknown profile")
+	}
+}
+
+type opmlOutline struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Title   string   `xml:"head>title"`
+}
+
+type opmlFeed struct{ f *gofeedx.Feed }
+
+func (o opmlFeed) FeedXml() interface{} {
+	return &opmlOutline{Version: "2.0", Title: o.f.Title}
+}
+
+func TestXMLWriter(t *testing.T) {
+	p, err := gofeedx.RegisterProfile("opml-test", gofeedx.XMLWriter{Wrap: func(f *gofeedx.Feed) gofeedx.XmlFeed { return opmlFeed{f} }})
+	mustNoErr(t, err, "RegisterProfile")
+	out, err := gofeedx.Render(&gofeedx.Feed{Title: "Blogroll"}, p)
+	mustNoErr(t, err, "Render")
+	mustContain(t, out, `<?xml version="1.0" encoding="UTF-8"?>`, "expected XML declaration")
+	mustContain(t, out, "<title>Blogroll</title>", "expected wrapped value")
+}
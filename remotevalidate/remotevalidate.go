@@ -0,0 +1,149 @@
+// Package remotevalidate cross-checks rendered feeds against hosted reference validators.
+// It is optional and kept out of the gofeedx package so the core library never makes
+// network calls on its own; use it from CI or tooling.
+package remotevalidate
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Severity classifies an Issue reported by a remote validator.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Issue is one finding reported by a remote validator.
+type Issue struct {
+	Severity Severity
+	Type     string // validator-specific message type, e.g. "MissingGuid"
+	Element  string
+	Line     int
+	Column   int
+	Message  string
+}
+
+func (i Issue) String() string {
+	if i.Line > 0 {
+		return fmt.Sprintf("%s at %d:%d: %s", i.Severity, i.Line, i.Column, i.Message)
+	}
+	return fmt.Sprintf("%s: %s", i.Severity, i.Message)
+}
+
+// Validator submits a feed document to a remote service and returns its findings.
+type Validator interface {
+	Validate(ctx context.Context, xml string) ([]Issue, error)
+}
+
+// ValidatorFunc adapts a function to the Validator interface, e.g. to plug in services
+// such as Podbase or Cast Feed Validator that do not offer a stable public API.
+type ValidatorFunc func(ctx context.Context, xml string) ([]Issue, error)
+
+// Validate calls f(ctx, xml).
+func (f ValidatorFunc) Validate(ctx context.Context, xml string) ([]Issue, error) {
+	return f(ctx, xml)
+}
+
+// ValidateRemote submits xml to v and returns the reported issues.
+func ValidateRemote(ctx context.Context, xml string, v Validator) ([]Issue, error) {
+	if v == nil {
+		return nil, errors.New("remotevalidate: nil validator")
+	}
+	if strings.TrimSpace(xml) == "" {
+		return nil, errors.New("remotevalidate: empty document")
+	}
+	return v.Validate(ctx, xml)
+}
+
+// HasErrors reports whether any issue has SeverityError.
+func HasErrors(issues []Issue) bool {
+	for _, i := range issues {
+		if i.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultW3CEndpoint is the check endpoint of the public W3C Feed Validation Service.
+const DefaultW3CEndpoint = "https://validator.w3.org/feed/check.cgi"
+
+// W3C validates documents with the W3C Feed Validation Service using its SOAP 1.2 output.
+// The zero value uses DefaultW3CEndpoint and http.DefaultClient.
+type W3C struct {
+	Endpoint string
+	Client   *http.Client
+}
+
+// w3cResponse mirrors the parts of the SOAP 1.2 response the validator returns.
+type w3cResponse struct {
+	Errors   []w3cMessage `xml:"Body>feedvalidationresponse>errors>errorlist>error"`
+	Warnings []w3cMessage `xml:"Body>feedvalidationresponse>warnings>warninglist>warning"`
+	Infos    []w3cMessage `xml:"Body>feedvalidationresponse>informations>infolist>info"`
+}
+
+type w3cMessage struct {
+	Type    string `xml:"type"`
+	Element string `xml:"element"`
+	Line    int    `xml:"line"`
+	Column  int    `xml:"column"`
+	Text    string `xml:"text"`
+}
+
+// Validate implements Validator.
+func (v W3C) Validate(ctx context.Context, doc string) ([]Issue, error) {
+	endpoint := strings.TrimSpace(v.Endpoint)
+	if endpoint == "" {
+		endpoint = DefaultW3CEndpoint
+	}
+	client := v.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	form := url.Values{"rawdata": {doc}, "output": {"soap12"}, "manual": {"1"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("remotevalidate: w3c: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+		return nil, errors.New("remotevalidate: w3c responded " + resp.Status)
+	}
+	var parsed w3cResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("remotevalidate: w3c: decode response: %w", err)
+	}
+	var issues []Issue
+	add := func(sev Severity, msgs []w3cMessage) {
+		for _, m := range msgs {
+			issues = append(issues, Issue{
+				Severity: sev,
+				Type:     strings.TrimSpace(m.Type),
+				Element:  strings.TrimSpace(m.Element),
+				Line:     m.Line,
+				Column:   m.Column,
+				Message:  strings.TrimSpace(m.Text),
+			})
+		}
+	}
+	add(SeverityError, parsed.Errors)
+	add(SeverityWarning, parsed.Warnings)
+	add(SeverityInfo, parsed.Infos)
+	return issues, nil
+}
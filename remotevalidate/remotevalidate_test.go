@@ -0,0 +1,82 @@
+package remotevalidate_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jo-hoe/gofeedx/remotevalidate"
+)
+
+const w3cSOAPResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<env:Envelope xmlns:env="http://www.w3.org/2003/05/soap-envelope">
+<env:Body>
+<m:feedvalidationresponse xmlns:m="http://www.w3.org/2005/10/feed-validator">
+<m:validity>false</m:validity>
+<m:errors><m:errorcount>1</m:errorcount><m:errorlist>
+<error><level>error</level><type>MissingElement</type><line>3</line><column>5</column><text>Missing channel element: description</text><element>description</element></error>
+</m:errorlist></m:errors>
+<m:warnings><m:warningcount>1</m:warningcount><m:warninglist>
+<warning><level>warning</level><type>MissingGuid</type><line>9</line><column>7</column><text>item should contain a guid element</text><element>guid</element></warning>
+</m:warninglist></m:warnings>
+</m:feedvalidationresponse>
+</env:Body>
+</env:Envelope>`
+
+func TestW3C_Validate(t *testing.T) {
+	var gotRaw, gotOutput string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		gotRaw, gotOutput = r.PostForm.Get("rawdata"), r.PostForm.Get("output")
+		_, _ = w.Write([]byte(w3cSOAPResponse))
+	}))
+	defer srv.Close()
+
+	doc := `<rss version="2.0"><channel><title>T</title></channel></rss>`
+	issues, err := remotevalidate.ValidateRemote(context.Background(), doc, remotevalidate.W3C{Endpoint: srv.URL})
+	if err != nil {
+		t.Fatalf("ValidateRemote failed: %v", err)
+	}
+	if gotRaw != doc || gotOutput != "soap12" {
+		t.Fatalf("unexpected request: rawdata=%q output=%q", gotRaw, gotOutput)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %d: %v", len(issues), issues)
+	}
+	if issues[0].Severity != remotevalidate.SeverityError || issues[0].Type != "MissingElement" || issues[0].Line != 3 {
+		t.Fatalf("unexpected first issue: %+v", issues[0])
+	}
+	if issues[1].Severity != remotevalidate.SeverityWarning || issues[1].Element != "guid" {
+		t.Fatalf("unexpected second issue: %+v", issues[1])
+	}
+	if !remotevalidate.HasErrors(issues) {
+		t.Fatalf("expected HasErrors to be true")
+	}
+}
+
+func TestW3C_ValidateHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	_, err := remotevalidate.ValidateRemote(context.Background(), "<rss/>", remotevalidate.W3C{Endpoint: srv.URL})
+	if err == nil || !strings.Contains(err.Error(), "503") {
+		t.Fatalf("expected 503 error, got %v", err)
+	}
+}
+
+func TestValidatorFunc(t *testing.T) {
+	v := remotevalidate.ValidatorFunc(func(ctx context.Context, xml string) ([]remotevalidate.Issue, error) {
+		return []remotevalidate.Issue{{Severity: remotevalidate.SeverityInfo, Message: "ok"}}, nil
+	})
+	issues, err := remotevalidate.ValidateRemote(context.Background(), "<rss/>", v)
+	if err != nil || len(issues) != 1 || remotevalidate.HasErrors(issues) {
+		t.Fatalf("unexpected result: %v %v", issues, err)
+	}
+	if _, err := remotevalidate.ValidateRemote(context.Background(), " ", v); err == nil {
+		t.Fatalf("expected error for empty document")
+	}
+}
@@ -0,0 +1,311 @@
+package gofeedx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// RenderOptions tunes how XML feeds are serialized. The zero value produces the same
+// output as ToXML, ToRSS, ToAtom and ToPSP.
+type RenderOptions struct {
+	// StylesheetHref, when set, emits an <?xml-stylesheet?> processing instruction right after
+	// the XML declaration so browsers render the feed with the referenced XSLT or CSS file.
+	StylesheetHref string
+	// StylesheetType overrides the stylesheet MIME type. Defaults to "text/css" for hrefs
+	// ending in ".css" and "text/xsl" otherwise.
+	StylesheetType string
+
+	// Indent is the whitespace used per nesting level; empty means two spaces.
+	Indent string
+	// Compact disables indentation and line breaks for minimal output size.
+	Compact bool
+	// Newline selects the line break between elements: "\n" (default) or "\r\n". Line
+	// breaks inside text, CDATA sections and comments are written unchanged.
+	Newline string
+
+	// OmitXMLDeclaration drops the leading <?xml ...?> declaration, e.g. when the output is
+	// embedded in another document. Processing instructions are still written.
+	OmitXMLDeclaration bool
+	// Encoding selects the output encoding: "UTF-8" (default), "US-ASCII" or "ISO-8859-1".
+	// The output is transcoded, with character references for characters the encoding lacks;
+	// other labels are rejected.
+	Encoding string
+	// Standalone adds standalone="yes" or standalone="no" to the declaration when set.
+	Standalone string
+}
+
+// xmlStylesheetMarker carries a feed-level stylesheet configured via WithXSLStylesheet.
+const xmlStylesheetMarker = "_xml:stylesheet"
+
+// withFeedStylesheet fills the stylesheet options from the feed's stylesheet marker
+// unless opts already names a stylesheet.
+func (opts RenderOptions) withFeedStylesheet(feed *Feed) RenderOptions {
+	if feed == nil || strings.TrimSpace(opts.StylesheetHref) != "" {
+		return opts
+	}
+	for _, n := range feed.allExtensions() {
+		if strings.EqualFold(strings.TrimSpace(n.Name), xmlStylesheetMarker) && strings.TrimSpace(n.Text) != "" {
+			opts.StylesheetHref = n.Text
+			opts.StylesheetType = n.Attrs["type"]
+			break
+		}
+	}
+	return opts
+}
+
+// wrappedFeed returns the Feed behind the package's XML wrappers, or nil for other XmlFeeds.
+func wrappedFeed(x XmlFeed) *Feed {
+	switch v := x.(type) {
+	case *Rss:
+		return v.Feed
+	case *Atom:
+		return v.Feed
+	case *PSP:
+		return v.Feed
+	}
+	return nil
+}
+
+var encodingLabelPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9._-]*$`)
+
+// xmlDeclaration returns the XML declaration requested by opts, or "" when it is omitted.
+func (opts RenderOptions) xmlDeclaration() (string, error) {
+	enc := strings.TrimSpace(opts.Encoding)
+	standalone := strings.TrimSpace(opts.Standalone)
+	if opts.OmitXMLDeclaration {
+		if enc != "" || standalone != "" {
+			return "", errors.New("render: encoding and standalone require the XML declaration")
+		}
+		return "", nil
+	}
+	if enc == "" && standalone == "" {
+		return xml.Header[:len(xml.Header)-1], nil
+	}
+	if enc == "" {
+		enc = "UTF-8"
+	}
+	if !encodingLabelPattern.MatchString(enc) {
+		return "", fmt.Errorf("render: invalid encoding label %q", enc)
+	}
+	if _, err := opts.charset(); err != nil {
+		return "", err
+	}
+	decl := `<?xml version="1.0" encoding="` + enc + `"`
+	switch standalone {
+	case "":
+	case "yes", "no":
+		decl += ` standalone="` + standalone + `"`
+	default:
+		return "", fmt.Errorf("render: standalone must be \"yes\" or \"no\", got %q", standalone)
+	}
+	return decl + "?>", nil
+}
+
+// indent returns the per-level indentation, or "" for compact output.
+func (opts RenderOptions) indent() (string, error) {
+	if opts.Compact {
+		return "", nil
+	}
+	if opts.Indent == "" {
+		return "  ", nil
+	}
+	if strings.Trim(opts.Indent, " \t") != "" {
+		return "", errors.New("render: indent must contain only spaces and tabs")
+	}
+	return opts.Indent, nil
+}
+
+// crlf reports whether opts selects "\r\n" line breaks.
+func (opts RenderOptions) crlf() (bool, error) {
+	switch opts.Newline {
+	case "", "\n":
+		return false, nil
+	case "\r\n":
+		return true, nil
+	}
+	return false, fmt.Errorf("render: unsupported newline %q", opts.Newline)
+}
+
+// crlfMarkup rewrites the "\n" line breaks the encoder writes between markup to "\r\n".
+// Line breaks inside text, attribute values, CDATA sections and comments are kept.
+func crlfMarkup(data []byte) []byte {
+	out := make([]byte, 0, len(data)+bytes.Count(data, []byte("\n")))
+	var sc xmlScanner
+	for i := 0; i < len(data); {
+		if sc.state == scanText && data[i] != '<' {
+			end := bytes.IndexByte(data[i:], '<')
+			if end < 0 {
+				end = len(data) - i
+			}
+			run := data[i : i+end]
+			if len(bytes.Trim(run, " \t\r\n")) == 0 {
+				run = bytes.ReplaceAll(run, []byte("\n"), []byte("\r\n"))
+			}
+			out = append(out, run...)
+			i += end
+			continue
+		}
+		r, size := utf8.DecodeRune(data[i:])
+		n := sc.step(data[i:], r)
+		if n == 0 {
+			n = size
+		}
+		out = append(out, data[i:i+n]...)
+		i += n
+	}
+	return out
+}
+
+// xmlPrologue returns the XML declaration (without trailing newline) followed by any
+// processing instructions requested by opts.
+func (opts RenderOptions) xmlPrologue() (string, error) {
+	header, err := opts.xmlDeclaration()
+	if err != nil {
+		return "", err
+	}
+	href := strings.TrimSpace(opts.StylesheetHref)
+	if href == "" {
+		return header, nil
+	}
+	typ := strings.TrimSpace(opts.StylesheetType)
+	if typ == "" {
+		typ = "text/xsl"
+		if strings.HasSuffix(strings.ToLower(stripURLQuery(href)), ".css") {
+			typ = "text/css"
+		}
+	}
+	if strings.Contains(href, "?>") || strings.Contains(typ, "?>") {
+		return "", errors.New("render: stylesheet must not contain \"?>\"")
+	}
+	return fmt.Sprintf(`%s<?xml-stylesheet type="%s" href="%s"?>`, header, escapePseudoAttr(typ), escapePseudoAttr(href)), nil
+}
+
+// escapePseudoAttr escapes a value for use inside a double-quoted processing instruction pseudo-attribute.
+func escapePseudoAttr(s string) string {
+	return strings.NewReplacer(`&`, "&amp;", `"`, "&quot;", `<`, "&lt;").Replace(s)
+}
+
+// stripURLQuery drops any query string or fragment from a URL reference.
+func stripURLQuery(s string) string {
+	if i := strings.IndexAny(s, "?#"); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+// ToXMLWithOptions marshals a feed wrapper to an XML string like ToXML, applying opts.
+func ToXMLWithOptions(feed XmlFeed, opts RenderOptions) (string, error) {
+	var buf bytes.Buffer
+	if err := WriteXMLWithOptions(feed, &buf, opts); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// WriteXMLWithOptions writes a feed wrapper as XML to w like WriteXML, applying opts.
+func WriteXMLWithOptions(feed XmlFeed, w io.Writer, opts RenderOptions) error {
+	return writeXMLDocument(wrappedFeed(feed), feed.FeedXml, w, opts)
+}
+
+// writeXMLDocument writes the value built by build as an XML document; src supplies the
+// feed-level stylesheet and may be nil.
+func writeXMLDocument(src *Feed, build func() interface{}, w io.Writer, opts RenderOptions) error {
+	prologue, err := opts.withFeedStylesheet(src).xmlPrologue()
+	if err != nil {
+		return err
+	}
+	indent, err := opts.indent()
+	if err != nil {
+		return err
+	}
+	crlf, err := opts.crlf()
+	if err != nil {
+		return err
+	}
+	cs, err := opts.charset()
+	if err != nil {
+		return err
+	}
+	if !crlf && cs == charsetUTF8 {
+		return writeUTF8XMLDocument(prologue, build, w, indent)
+	}
+	// line breaks and transcoding need the whole document to track CDATA sections and markup
+	var buf bytes.Buffer
+	if err := writeUTF8XMLDocument(prologue, build, &buf, indent); err != nil {
+		return err
+	}
+	data := buf.Bytes()
+	if crlf {
+		data = crlfMarkup(data)
+	}
+	data, err = transcodeXML(data, cs)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// writeUTF8XMLDocument writes prologue and the value built by build to w.
+func writeUTF8XMLDocument(prologue string, build func() interface{}, w io.Writer, indent string) error {
+	x := build()
+	if _, err := io.WriteString(w, prologue); err != nil {
+		return err
+	}
+	e := xml.NewEncoder(w)
+	e.Indent("", indent)
+	if err := e.Encode(x); err != nil {
+		return err
+	}
+	return e.Flush()
+}
+
+// ToRSSWithOptions renders the feed to an RSS 2.0 string like ToRSS, applying opts.
+func ToRSSWithOptions(feed *Feed, opts RenderOptions) (string, error) {
+	if feed == nil {
+		return "", errors.New("nil feed")
+	}
+	return ToXMLWithOptions(&Rss{feed}, opts)
+}
+
+// ToAtomWithOptions renders the feed to an Atom 1.0 string like ToAtom, applying opts.
+func ToAtomWithOptions(feed *Feed, opts RenderOptions) (string, error) {
+	if feed == nil {
+		return "", errors.New("nil feed")
+	}
+	return ToXMLWithOptions(&Atom{feed}, opts)
+}
+
+// ToPSPWithOptions renders the feed to a PSP-1 RSS string like ToPSP, applying opts.
+func ToPSPWithOptions(feed *Feed, opts RenderOptions) (string, error) {
+	if feed == nil {
+		return "", errors.New("nil feed")
+	}
+	return ToXMLWithOptions(&PSP{feed}, opts)
+}
+
+// Render renders feed in the format of profile with default options, including profiles
+// added with RegisterProfile.
+func Render(feed *Feed, profile Profile) (string, error) {
+	switch profile {
+	case ProfileRSS:
+		return ToRSS(feed)
+	case ProfileAtom:
+		return ToAtom(feed)
+	case ProfilePSP:
+		return ToPSP(feed)
+	case ProfileJSON:
+		return ToJSON(feed)
+	}
+	out, err := renderCustom(feed, profile)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
@@ -0,0 +1,172 @@
+package gofeedx_test
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestRenderOptions_StylesheetHref(t *testing.T) {
+	f := newRSSBaseFeed()
+	f.Items = append(f.Items, newRSSBaseItem())
+	opts := gofeedx.RenderOptions{StylesheetHref: "/feed.xsl?v=1&x=2"}
+
+	rss, err := gofeedx.ToRSSWithOptions(f, opts)
+	mustNoErr(t, err, "ToRSSWithOptions failed")
+	want := `<?xml version="1.0" encoding="UTF-8"?><?xml-stylesheet type="text/xsl" href="/feed.xsl?v=1&amp;x=2"?><rss`
+	if !strings.HasPrefix(rss, want) {
+		t.Fatalf("expected stylesheet PI after XML declaration, got:\n%s", rss[:120])
+	}
+
+	atom, err := gofeedx.ToAtomWithOptions(f, gofeedx.RenderOptions{StylesheetHref: "https://example.org/feed.css"})
+	mustNoErr(t, err, "ToAtomWithOptions failed")
+	mustContain(t, atom, `<?xml-stylesheet type="text/css" href="https://example.org/feed.css"?><feed`, "expected css stylesheet PI in Atom output")
+
+	plain, err := gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS failed")
+	mustNotContain(t, plain, "xml-stylesheet", "did not expect stylesheet PI without options")
+}
+
+func TestRenderOptions_StylesheetRejectsPIClose(t *testing.T) {
+	f := newRSSBaseFeed()
+	_, err := gofeedx.ToRSSWithOptions(f, gofeedx.RenderOptions{StylesheetHref: "/x?>y"})
+	mustErr(t, err, "expected error for stylesheet href containing ?>")
+}
+
+func TestRenderOptions_Indentation(t *testing.T) {
+	f := newRSSBaseFeed()
+	f.Items = append(f.Items, newRSSBaseItem())
+
+	compact, err := gofeedx.ToRSSWithOptions(f, gofeedx.RenderOptions{Compact: true})
+	mustNoErr(t, err, "compact render failed")
+	mustNotContain(t, compact, "\n", "compact output must not contain line breaks")
+
+	tabbed, err := gofeedx.ToRSSWithOptions(f, gofeedx.RenderOptions{Indent: "\t"})
+	mustNoErr(t, err, "tab-indented render failed")
+	mustContain(t, tabbed, "\n\t<channel>", "expected tab indentation")
+
+	crlf, err := gofeedx.ToRSSWithOptions(f, gofeedx.RenderOptions{Newline: "\r\n"})
+	mustNoErr(t, err, "CRLF render failed")
+	mustContain(t, crlf, "\r\n  <channel>", "expected CRLF line breaks")
+	if strings.Count(crlf, "\n") != strings.Count(crlf, "\r\n") {
+		t.Fatalf("expected every line break between elements to be CRLF")
+	}
+	multiline := newRSSBaseFeed()
+	it := newRSSBaseItem()
+	it.Content = "<p>one\ntwo</p>"
+	multiline.Items = []*gofeedx.Item{it}
+	crlf, err = gofeedx.ToRSSWithOptions(multiline, gofeedx.RenderOptions{Newline: "\r\n"})
+	mustNoErr(t, err, "CRLF render with multi-line content failed")
+	mustContain(t, crlf, "<![CDATA[<p>one\ntwo</p>]]>", "line breaks inside CDATA must be kept")
+
+	_, err = gofeedx.ToRSSWithOptions(f, gofeedx.RenderOptions{Indent: "--"})
+	mustErr(t, err, "expected error for non-whitespace indent")
+	_, err = gofeedx.ToRSSWithOptions(f, gofeedx.RenderOptions{Newline: "\r"})
+	mustErr(t, err, "expected error for unsupported newline")
+}
+
+func TestRenderOptions_XMLDeclaration(t *testing.T) {
+	f := newRSSBaseFeed()
+
+	custom, err := gofeedx.ToRSSWithOptions(f, gofeedx.RenderOptions{Encoding: "US-ASCII", Standalone: "yes"})
+	mustNoErr(t, err, "custom declaration render failed")
+	if !strings.HasPrefix(custom, `<?xml version="1.0" encoding="US-ASCII" standalone="yes"?><rss`) {
+		t.Fatalf("unexpected declaration: %s", custom[:80])
+	}
+
+	bare, err := gofeedx.ToRSSWithOptions(f, gofeedx.RenderOptions{OmitXMLDeclaration: true, StylesheetHref: "/feed.xsl"})
+	mustNoErr(t, err, "render without declaration failed")
+	if !strings.HasPrefix(bare, `<?xml-stylesheet type="text/xsl" href="/feed.xsl"?><rss`) {
+		t.Fatalf("expected output to start with the stylesheet PI, got: %s", bare[:80])
+	}
+
+	_, err = gofeedx.ToRSSWithOptions(f, gofeedx.RenderOptions{Standalone: "maybe"})
+	mustErr(t, err, "expected error for invalid standalone value")
+	_, err = gofeedx.ToRSSWithOptions(f, gofeedx.RenderOptions{Encoding: `UTF-8" x="`})
+	mustErr(t, err, "expected error for invalid encoding label")
+	_, err = gofeedx.ToRSSWithOptions(f, gofeedx.RenderOptions{OmitXMLDeclaration: true, Encoding: "UTF-8"})
+	mustErr(t, err, "expected error for encoding without declaration")
+}
+
+func TestFeedBuilder_WithXSLStylesheet(t *testing.T) {
+	f, err := gofeedx.NewFeed("Styled").
+		WithLink("https://example.org/").
+		WithDescription("D").
+		WithAuthor("A", "a@example.org").
+		WithXSLStylesheet("/rss.xsl").
+		AddItem(gofeedx.NewItem("I").WithID("i1").WithCreated(time.Now().UTC())).
+		Build()
+	mustNoErr(t, err, "Build failed")
+
+	pi := `<?xml-stylesheet type="text/xsl" href="/rss.xsl"?>`
+	rss, err := gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS failed")
+	if !strings.HasPrefix(rss, `<?xml version="1.0" encoding="UTF-8"?>`+pi+`<rss`) {
+		t.Fatalf("expected stylesheet PI before <rss>, got:\n%s", rss[:120])
+	}
+	mustNotContain(t, rss, "_xml:stylesheet", "marker must not be rendered")
+
+	atom, err := gofeedx.ToAtom(f)
+	mustNoErr(t, err, "ToAtom failed")
+	mustContain(t, atom, pi+`<feed`, "expected stylesheet PI in Atom output")
+
+	override, err := gofeedx.ToRSSWithOptions(f, gofeedx.RenderOptions{StylesheetHref: "/other.css"})
+	mustNoErr(t, err, "ToRSSWithOptions failed")
+	mustContain(t, override, `href="/other.css"`, "explicit option should take precedence")
+	mustNotContain(t, override, "/rss.xsl", "feed stylesheet should be replaced by the option")
+
+	j, err := gofeedx.ToJSON(f)
+	mustNoErr(t, err, "ToJSON failed")
+	mustNotContain(t, j, "rss.xsl", "JSON Feed has no stylesheet")
+}
+
+func TestRenderOptions_EncodingTranscodes(t *testing.T) {
+	f := newRSSBaseFeed()
+	f.Title = "Café ☕"
+	it := newRSSBaseItem()
+	it.Content = "<p>Grüße ☕</p>"
+	f.Items = append(f.Items, it)
+
+	ascii, err := gofeedx.ToRSSWithOptions(f, gofeedx.RenderOptions{Encoding: "US-ASCII"})
+	mustNoErr(t, err, "US-ASCII render failed")
+	for i := 0; i < len(ascii); i++ {
+		if ascii[i] >= 0x80 {
+			t.Fatalf("US-ASCII output contains byte %#x at %d", ascii[i], i)
+		}
+	}
+	mustContain(t, ascii, "<title>Caf&#xE9; &#x2615;</title>", "expected character references in text")
+	mustContain(t, ascii, "<![CDATA[<p>Gr]]>&#xFC;&#xDF;<![CDATA[e ]]>&#x2615;<![CDATA[</p>]]>", "expected CDATA split around character references")
+
+	latin1, err := gofeedx.ToRSSWithOptions(f, gofeedx.RenderOptions{Encoding: "iso-8859-1"})
+	mustNoErr(t, err, "ISO-8859-1 render failed")
+	mustContain(t, latin1, "<title>Caf\xe9 &#x2615;</title>", "expected Latin-1 bytes and a reference for the rest")
+
+	dec := xml.NewDecoder(strings.NewReader(latin1))
+	dec.CharsetReader = func(label string, r io.Reader) (io.Reader, error) {
+		data, err := io.ReadAll(r)
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+		return strings.NewReader(string(runes)), err
+	}
+	var doc struct {
+		Channel struct {
+			Title string `xml:"title"`
+			Items []struct {
+				Content string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	mustNoErr(t, dec.Decode(&doc), "decode ISO-8859-1 output")
+	if doc.Channel.Title != f.Title || len(doc.Channel.Items) != 1 || doc.Channel.Items[0].Content != it.Content {
+		t.Errorf("ISO-8859-1 output does not round-trip: %+v", doc.Channel)
+	}
+
+	_, err = gofeedx.ToRSSWithOptions(f, gofeedx.RenderOptions{Encoding: "Shift_JIS"})
+	mustErr(t, err, "expected error for an encoding the writer cannot transcode to")
+}
@@ -0,0 +1,122 @@
+package gofeedx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EnclosureURLRewriter returns the enclosure URL to write for item; url is the current value.
+type EnclosureURLRewriter func(item *Item, url string) string
+
+// enclosureRewriter is one render-time step; p is the profile being written.
+type enclosureRewriter func(p Profile, item *Item, url string) string
+
+// WithEnclosureURLRewriter makes the built feed pass every enclosure URL through fn when it is
+// rendered, e.g. to add per-subscriber signed tokens to a premium feed. This covers every
+// feed format as well as ToHTML, ToActivityStreams, ToJSONLD and ToICal. Item.Enclosure
+// stays unchanged; validation sees the original URLs. Rewriters run in the order they were
+// added.
+func (b *FeedBuilder) WithEnclosureURLRewriter(fn EnclosureURLRewriter) *FeedBuilder {
+	if fn != nil {
+		b.feed.rewriters = append(b.feed.rewriters, func(_ Profile, it *Item, url string) string { return fn(it, url) })
+	}
+	return b
+}
+
+// WithEnclosureURLRewriter returns a shallow copy of f that renders with fn applied to every
+// enclosure URL, after any rewriters f already has. f is not modified, so one canonical feed
+// can be rendered per request with request-specific URLs.
+func (f *Feed) WithEnclosureURLRewriter(fn EnclosureURLRewriter) *Feed {
+	if f == nil {
+		return nil
+	}
+	c := *f
+	if fn != nil {
+		c.rewriters = append(f.rewriters[:len(f.rewriters):len(f.rewriters)], func(_ Profile, it *Item, url string) string { return fn(it, url) })
+	}
+	return &c
+}
+
+// profilePage is the profile renderView is given by the outputs that are not feed formats
+// (ToHTML, ToDigest, ToActivityStreams, ToJSONLD and ToICal): they get user rewriters and
+// the sanitizer, but not the RSS/PSP-only WithEnclosurePrefix.
+const profilePage Profile = -1
+
+// renderView returns f as the writer for p sees it: enclosure URLs rewritten (see
+// WithEnclosureURLRewriter) and item HTML sanitized (see WithHTMLSanitizer). Changed items
+// are shallow copies; f itself is returned when neither applies.
+func (f *Feed) renderView(p Profile) *Feed {
+	if f == nil || (len(f.rewriters) == 0 && f.sanitizer == nil) {
+		return f
+	}
+	c := *f
+	c.rewriters, c.sanitizer = nil, nil
+	c.Items = make([]*Item, len(f.Items))
+	for i, it := range f.Items {
+		c.Items[i] = it
+		if it == nil {
+			continue
+		}
+		copied := *it
+		if it.Enclosure != nil && len(f.rewriters) > 0 {
+			e := *it.Enclosure
+			for _, rw := range f.rewriters {
+				e.Url = rw(p, it, e.Url)
+			}
+			copied.Enclosure = &e
+		}
+		copied.Description = sanitizeWith(f.sanitizer, it.Description)
+		copied.Content = sanitizeWith(f.sanitizer, it.Content)
+		c.Items[i] = &copied
+	}
+	return &c
+}
+
+// enclosurePrefixOptOut marks items whose enclosure URLs WithEnclosurePrefix leaves alone.
+const enclosurePrefixOptOut = "_xml:no-enclosure-prefix"
+
+// WithEnclosurePrefix prepends an analytics redirect prefix (OP3, Podtrac and similar, e.g.
+// "https://op3.dev/e/") to enclosure URLs in RSS and PSP output. The enclosure URL is appended
+// without its http(s):// scheme, as these services expect; a missing trailing slash on prefix
+// is added, URLs that already carry the prefix are left alone, and items built with
+// WithoutEnclosurePrefix are skipped. Several prefixes chain in the order they are added.
+// A prefix that is not an absolute URL is reported by Build in strict mode.
+func (b *FeedBuilder) WithEnclosurePrefix(prefix string) *FeedBuilder {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return b
+	}
+	if !isAbsoluteURL(prefix) {
+		b.errs = append(b.errs, fmt.Errorf("enclosure prefix %q must be absolute", prefix))
+		return b
+	}
+	if !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	b.feed.rewriters = append(b.feed.rewriters, func(p Profile, it *Item, url string) string {
+		return prefixEnclosureURL(p, it, url, prefix)
+	})
+	return b
+}
+
+// WithoutEnclosurePrefix keeps this item's enclosure URL free of WithEnclosurePrefix prefixes.
+func (b *ItemBuilder) WithoutEnclosurePrefix() *ItemBuilder {
+	return b.withOption(ExtensionNode{Name: enclosurePrefixOptOut, Text: "true"})
+}
+
+func prefixEnclosureURL(p Profile, it *Item, url, prefix string) string {
+	if p != ProfileRSS && p != ProfilePSP {
+		return url
+	}
+	u := strings.TrimSpace(url)
+	if u == "" || strings.HasPrefix(u, prefix) || hasExtensionNamed(it.allExtensions(), enclosurePrefixOptOut) {
+		return url
+	}
+	for _, scheme := range []string{"https://", "http://"} {
+		if len(u) >= len(scheme) && strings.EqualFold(u[:len(scheme)], scheme) {
+			u = u[len(scheme):]
+			break
+		}
+	}
+	return prefix + u
+}
@@ -0,0 +1,93 @@
+package gofeedx_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func newRewriteFeed(t *testing.T, b *gofeedx.FeedBuilder) *gofeedx.Feed {
+	t.Helper()
+	b.WithLink("https://example.com/").
+		WithDescription("d").
+		WithLanguage("en").
+		WithFeedURL("https://example.com/podcast.rss").
+		WithImage("https://example.com/artwork.jpg", "", "").
+		WithCategories("Technology").
+		WithAuthor("Host", "host@example.com")
+	b.AddItem(gofeedx.NewItem("E1").WithID("1").WithCreated(time.Now()).
+		WithEnclosure("https://cdn.example.com/e1.mp3", 1, "audio/mpeg").WithDescription("e"))
+	f, err := b.Build()
+	mustNoErr(t, err, "Build failed")
+	return f
+}
+
+func TestEnclosureURLRewriter(t *testing.T) {
+	f := newRewriteFeed(t, gofeedx.NewFeed("Premium").
+		WithEnclosureURLRewriter(func(it *gofeedx.Item, url string) string { return url + "?item=" + it.ID }))
+	signed := f.WithEnclosureURLRewriter(func(_ *gofeedx.Item, url string) string { return url + "&token=abc" })
+
+	want := "https://cdn.example.com/e1.mp3?item=1&amp;token=abc"
+	for name, render := range map[string]func(*gofeedx.Feed) (string, error){
+		"rss": gofeedx.ToRSS, "psp": gofeedx.ToPSP, "atom": gofeedx.ToAtom,
+	} {
+		out, err := render(signed)
+		mustNoErr(t, err, name+" render failed")
+		mustContain(t, out, want, name+": expected rewritten enclosure url")
+	}
+	js, err := gofeedx.ToJSON(signed)
+	mustNoErr(t, err, "ToJSON failed")
+	mustContain(t, js, `"url": "https://cdn.example.com/e1.mp3?item=1\u0026token=abc"`, "expected rewritten JSON attachment")
+	var stream bytes.Buffer
+	mustNoErr(t, gofeedx.WriteJSONStream(signed, &stream), "WriteJSONStream failed")
+	if stream.String() != js {
+		t.Errorf("streamed JSON differs from ToJSON")
+	}
+
+	for name, render := range map[string]func(*gofeedx.Feed) (string, error){
+		"html":   func(f *gofeedx.Feed) (string, error) { return gofeedx.ToHTML(f, nil) },
+		"jsonld": gofeedx.ToJSONLD,
+		"ical":   gofeedx.ToICal,
+		"activitystreams": func(f *gofeedx.Feed) (string, error) {
+			return gofeedx.ToActivityStreams(f, gofeedx.ActivityStreamsOptions{ActorID: "https://example.com/actor"})
+		},
+	} {
+		out, err := render(signed)
+		mustNoErr(t, err, name+" render failed")
+		mustContain(t, out, "token=abc", name+": expected rewritten enclosure url")
+	}
+
+	if f.Items[0].Enclosure.Url != "https://cdn.example.com/e1.mp3" {
+		t.Errorf("canonical enclosure must not change, got %q", f.Items[0].Enclosure.Url)
+	}
+	out, err := gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS failed")
+	mustContain(t, out, "e1.mp3?item=1\"", "builder rewriter should apply without the per-request one")
+	mustNotContain(t, out, "token=abc", "per-request rewriter must not leak into the original feed")
+}
+
+func TestEnclosurePrefix(t *testing.T) {
+	b := gofeedx.NewFeed("Tracked").
+		WithEnclosurePrefix("https://op3.dev/e").
+		WithEnclosurePrefix("https://dts.podtrac.com/redirect.mp3/")
+	b.AddItem(gofeedx.NewItem("E2").WithID("2").WithCreated(time.Now()).
+		WithEnclosure("https://cdn.example.com/e2.mp3?a=1&b=2", 1, "audio/mpeg").WithDescription("e").
+		WithoutEnclosurePrefix())
+	f := newRewriteFeed(t, b)
+
+	for name, render := range map[string]func(*gofeedx.Feed) (string, error){"rss": gofeedx.ToRSS, "psp": gofeedx.ToPSP} {
+		out, err := render(f)
+		mustNoErr(t, err, name+" render failed")
+		mustContain(t, out, `url="https://dts.podtrac.com/redirect.mp3/op3.dev/e/cdn.example.com/e1.mp3"`, name+": expected chained prefixes")
+		mustContain(t, out, `url="https://cdn.example.com/e2.mp3?a=1&amp;b=2"`, name+": opted-out item must keep its url")
+	}
+	atom, err := gofeedx.ToAtom(f)
+	mustNoErr(t, err, "ToAtom failed")
+	mustNotContain(t, atom, "op3.dev", "Atom output must not be prefixed")
+	mustNotContain(t, atom, "no-enclosure-prefix", "opt-out marker must not be rendered")
+
+	_, err = gofeedx.NewFeed("Bad").WithEnclosurePrefix("op3.dev/e/").Build()
+	mustErr(t, err, "expected error for relative prefix")
+}
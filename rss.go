@@ -3,7 +3,6 @@ package gofeedx
 // RSS 2.0 encoder (with optional content:encoded for HTML content)
 import (
 	"encoding/xml"
-	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -15,9 +14,29 @@ type RssFeedXml struct {
 	XMLName          xml.Name `xml:"rss"`
 	Version          string   `xml:"version,attr"`
 	ContentNamespace string   `xml:"xmlns:content,attr,omitempty"`
+	SyNamespace      string   `xml:"xmlns:sy,attr,omitempty"`
+	GeoRSSNamespace  string   `xml:"xmlns:georss,attr,omitempty"`
+	DcNamespace      string   `xml:"xmlns:dc,attr,omitempty"`
+	AtomNamespace    string   `xml:"xmlns:atom,attr,omitempty"`
+	ThrNamespace     string   `xml:"xmlns:thr,attr,omitempty"`
+	SlashNamespace   string   `xml:"xmlns:slash,attr,omitempty"`
+	WfwNamespace     string   `xml:"xmlns:wfw,attr,omitempty"`
+	MediaNamespace   string   `xml:"xmlns:media,attr,omitempty"`
 	Channel          *RssFeed `xml:"channel"`
 }
 
+// xmlnsSy is the RSS 1.0 Syndication module namespace.
+const xmlnsSy = "http://purl.org/rss/1.0/modules/syndication/"
+
+// xmlnsDc is the Dublin Core elements namespace used for per-item dc:language.
+const xmlnsDc = "http://purl.org/dc/elements/1.1/"
+
+// xmlnsSlash and xmlnsWfw declare slash:comments and wfw:commentRss on items.
+const (
+	xmlnsSlash = "http://purl.org/rss/1.0/modules/slash/"
+	xmlnsWfw   = "http://wellformedweb.org/CommentAPI/"
+)
+
 // RssContent holds HTML content for content:encoded.
 type RssContent struct {
 	XMLName xml.Name `xml:"content:encoded"`
@@ -40,6 +59,37 @@ type RssEnclosure struct {
 	Type    string   `xml:"type,attr"`
 }
 
+// RssCloud describes the rssCloud endpoint clients register with for update notifications.
+// It is an empty element carrying only attributes.
+type RssCloud struct {
+	XMLName           xml.Name `xml:"cloud"`
+	Domain            string   `xml:"domain,attr"`
+	Port              int      `xml:"port,attr"`
+	Path              string   `xml:"path,attr"`
+	RegisterProcedure string   `xml:"registerProcedure,attr"`
+	Protocol          string   `xml:"protocol,attr"` // "xml-rpc" | "soap" | "http-post"
+}
+
+// RssSkipHours lists the hours (0-23, GMT) in which aggregators may skip polling.
+type RssSkipHours struct {
+	XMLName xml.Name `xml:"skipHours"`
+	Hours   []int    `xml:"hour"`
+}
+
+// RssSkipDays lists the days (Monday..Sunday) on which aggregators may skip polling.
+type RssSkipDays struct {
+	XMLName xml.Name `xml:"skipDays"`
+	Days    []string `xml:"day"`
+}
+
+// RssSource names the channel an item came from: the element text is the channel's title and
+// url points at its RSS document.
+type RssSource struct {
+	XMLName xml.Name `xml:"source"`
+	URL     string   `xml:"url,attr"`
+	Title   string   `xml:",chardata"`
+}
+
 type RssGuid struct {
 	XMLName     xml.Name `xml:"guid"`
 	ID          string   `xml:",chardata"`
@@ -49,7 +99,7 @@ type RssGuid struct {
 type RssItem struct {
 	Title       CData       `xml:"title"` // optional (spec requires title or description)
 	Link        string      `xml:"link"`  // optional
-	Source      string      `xml:"source,omitempty"`
+	Source      *RssSource  `xml:"source,omitempty"`
 	Author      CData       `xml:"author,omitempty"`
 	Description CData       `xml:"description"` // optional
 	Content     *RssContent `xml:"content:encoded,omitempty"`
@@ -59,6 +109,11 @@ type RssItem struct {
 	XMLName     xml.Name        `xml:"item"`
 	Category    CData           `xml:"category,omitempty"`
 	Comments    CData           `xml:"comments,omitempty"`
+	CommentRss  string          `xml:"wfw:commentRss,omitempty"`
+	SlashCount  string          `xml:"slash:comments,omitempty"`
+	Language    string          `xml:"dc:language,omitempty"`
+	Creator     string          `xml:"dc:creator,omitempty"` // author name when the email is withheld (see WithAuthorPrivacy)
+	Thumbnail   *MediaThumbnail // media:thumbnail from Item.ImageURL
 	Extra       []ExtensionNode `xml:",any"` // custom nodes at item scope
 }
 
@@ -80,12 +135,19 @@ type RssFeed struct {
 	WebMaster CData           `xml:"webMaster,omitempty"`
 	Generator CData           `xml:"generator,omitempty"`
 	Docs      CData           `xml:"docs,omitempty"`
-	Cloud     CData           `xml:"cloud,omitempty"`
+	Cloud     *RssCloud       `xml:"cloud,omitempty"`
 	Ttl       int             `xml:"ttl,omitempty"`
 	Rating    CData           `xml:"rating,omitempty"`
-	SkipHours CData           `xml:"skipHours,omitempty"`
-	SkipDays  CData           `xml:"skipDays,omitempty"`
+	SkipHours *RssSkipHours   `xml:"skipHours,omitempty"`
+	SkipDays  *RssSkipDays    `xml:"skipDays,omitempty"`
 	Extra     []ExtensionNode `xml:",any"` // custom nodes at channel scope
+
+	// Syndication module polling hints (sy:updatePeriod, sy:updateFrequency, sy:updateBase)
+	SyUpdatePeriod    string `xml:"sy:updatePeriod,omitempty"`
+	SyUpdateFrequency int    `xml:"sy:updateFrequency,omitempty"`
+	SyUpdateBase      string `xml:"sy:updateBase,omitempty"`
+
+	itemsPlaceholder string // set by parallel encoding in place of Items (see writeXMLParallel)
 }
 
 // Rss is a wrapper to marshal a Feed as RSS 2.0.
@@ -100,10 +162,7 @@ func (r *Rss) FeedXml() interface{} {
 
 // ToRSS renders the feed to an RSS 2.0 string after validating ProfileRSS.
 func ToRSS(feed *Feed) (string, error) {
-	if feed == nil {
-		return "", errors.New("nil feed")
-	}
-	return ToXML(&Rss{feed})
+	return ToRSSWithOptions(feed, RenderOptions{})
 }
 
 // rssAuthorString builds the RSS author string (email with optional name in parens).
@@ -118,12 +177,18 @@ func rssAuthorString(a *Author) string {
 }
 
 type rssChannelExtras struct {
-	imgW, imgH                        int
-	ttl                               int
-	catOverride                       string
-	webMaster, generator, docs, cloud string
-	rating, skipHours, skipDays       string
-	nonRSSExtras                      []ExtensionNode
+	imgW, imgH                 int
+	ttl                        int
+	catOverride                string
+	webMaster, generator, docs string
+	cloud                      *RssCloud
+	rating                     string
+	skipHours                  *RssSkipHours
+	skipDays                   *RssSkipDays
+	syPeriod, syBase           string
+	syFrequency                int
+	authorPrivacy              AuthorPrivacy
+	nonRSSExtras               []ExtensionNode
 }
 
 func parsePositiveInt(s string) (int, bool) {
@@ -171,14 +236,44 @@ func handleRSSWebMaster(out *rssChannelExtras, n ExtensionNode) {
 func handleRSSGenerator(out *rssChannelExtras, n ExtensionNode) {
 	out.generator = strings.TrimSpace(n.Text)
 }
-func handleRSSDocs(out *rssChannelExtras, n ExtensionNode)   { out.docs = strings.TrimSpace(n.Text) }
-func handleRSSCloud(out *rssChannelExtras, n ExtensionNode)  { out.cloud = strings.TrimSpace(n.Text) }
+func handleRSSDocs(out *rssChannelExtras, n ExtensionNode) { out.docs = strings.TrimSpace(n.Text) }
+func handleRSSCloud(out *rssChannelExtras, n ExtensionNode) {
+	port, ok := parsePositiveInt(attrTrim(n.Attrs, "port"))
+	if !ok {
+		return
+	}
+	out.cloud = &RssCloud{
+		Domain:            attrTrim(n.Attrs, "domain"),
+		Port:              port,
+		Path:              attrTrim(n.Attrs, "path"),
+		RegisterProcedure: attrTrim(n.Attrs, "registerProcedure"),
+		Protocol:          attrTrim(n.Attrs, "protocol"),
+	}
+}
 func handleRSSRating(out *rssChannelExtras, n ExtensionNode) { out.rating = strings.TrimSpace(n.Text) }
 func handleRSSSkipHours(out *rssChannelExtras, n ExtensionNode) {
-	out.skipHours = strings.TrimSpace(n.Text)
+	var hours []int
+	for _, f := range strings.Fields(n.Text) {
+		if h, err := strconv.Atoi(f); err == nil && h >= 0 && h <= 23 {
+			hours = append(hours, h)
+		}
+	}
+	if len(hours) > 0 {
+		out.skipHours = &RssSkipHours{Hours: hours}
+	}
 }
 func handleRSSSkipDays(out *rssChannelExtras, n ExtensionNode) {
-	out.skipDays = strings.TrimSpace(n.Text)
+	if days := strings.Fields(n.Text); len(days) > 0 {
+		out.skipDays = &RssSkipDays{Days: days}
+	}
+}
+
+func handleRSSSyndication(out *rssChannelExtras, n ExtensionNode) {
+	out.syPeriod = attrTrim(n.Attrs, "period")
+	out.syBase = attrTrim(n.Attrs, "base")
+	if v, ok := parsePositiveInt(attrTrim(n.Attrs, "frequency")); ok {
+		out.syFrequency = v
+	}
 }
 
 func extractRSSChannelExtras(exts []ExtensionNode) rssChannelExtras {
@@ -187,16 +282,18 @@ func extractRSSChannelExtras(exts []ExtensionNode) rssChannelExtras {
 		return out
 	}
 	handlers := map[string]rssChannelHandler{
-		"_rss:imageSize": handleRSSImageSize,
-		"_rss:ttl":       handleRSSTTL,
-		"_rss:category":  handleRSSCategory,
-		"_rss:webMaster": handleRSSWebMaster,
-		"_rss:generator": handleRSSGenerator,
-		"_rss:docs":      handleRSSDocs,
-		"_rss:cloud":     handleRSSCloud,
-		"_rss:rating":    handleRSSRating,
-		"_rss:skipHours": handleRSSSkipHours,
-		"_rss:skipDays":  handleRSSSkipDays,
+		"_rss:imageSize":    handleRSSImageSize,
+		"_rss:ttl":          handleRSSTTL,
+		"_rss:category":     handleRSSCategory,
+		"_rss:webMaster":    handleRSSWebMaster,
+		"_rss:generator":    handleRSSGenerator,
+		"_rss:docs":         handleRSSDocs,
+		"_rss:cloud":        handleRSSCloud,
+		"_rss:rating":       handleRSSRating,
+		"_rss:skipHours":    handleRSSSkipHours,
+		"_rss:skipDays":     handleRSSSkipDays,
+		"_rss:syndication":  handleRSSSyndication,
+		authorPrivacyMarker: handleRSSAuthorPrivacy,
 	}
 	for _, n := range exts {
 		if h, ok := handlers[n.Name]; ok {
@@ -212,17 +309,28 @@ func extractRSSChannelExtras(exts []ExtensionNode) rssChannelExtras {
 	return out
 }
 
-func rssImageFromFeed(img *Image, w, h int) *RssImage {
-	if img == nil {
-		return nil
+// rssImageFromFeed builds the channel <image> from Images.Logo or Feed.Image. RSS requires
+// title and link on <image>, so a logo without Feed.Image borrows the channel's.
+func rssImageFromFeed(f *Feed, w, h int) *RssImage {
+	logo := ""
+	if f.Images != nil {
+		logo = strings.TrimSpace(f.Images.Logo)
+	}
+	if logo == "" {
+		if f.Image == nil {
+			return nil
+		}
+		return &RssImage{Url: f.Image.Url, Title: f.Image.Title, Link: f.Image.Link, Width: w, Height: h}
+	}
+	img := &RssImage{Url: logo, Title: f.Title, Width: w, Height: h}
+	if f.Link != nil {
+		img.Link = f.Link.Href
 	}
-	return &RssImage{
-		Url:    img.Url,
-		Title:  img.Title,
-		Link:   img.Link,
-		Width:  w,
-		Height: h,
+	if f.Image != nil {
+		img.Title = firstNonEmpty(f.Image.Title, img.Title)
+		img.Link = firstNonEmpty(f.Image.Link, img.Link)
 	}
+	return img
 }
 
 func resolveChannelCategory(f *Feed, override string) string {
@@ -244,6 +352,10 @@ func itemRSSExtensions(exts []ExtensionNode) (category, comments string, extras
 			} else {
 				extras = append(extras, n)
 			}
+		case threadRepliesMarker:
+			if link, ok := repliesRSSNode(n); ok {
+				extras = append(extras, link)
+			}
 		case "_rss:comments":
 			if s := strings.TrimSpace(n.Text); s != "" {
 				comments = s
@@ -263,12 +375,12 @@ func itemRSSExtensions(exts []ExtensionNode) (category, comments string, extras
 
 // RssFeed builds the channel structure from the generic Feed.
 func (r *Rss) RssFeed() *RssFeed {
+	r = &Rss{r.renderView(ProfileRSS)}
 	pub := anyTimeFormat(time.RFC1123Z, r.Created, r.Updated)
 	build := anyTimeFormat(time.RFC1123Z, r.Updated)
-	author := rssAuthorString(r.Author)
-
 	// Extract unified RSS builder markers from feed extensions
-	extras := extractRSSChannelExtras(r.Extensions)
+	extras := extractRSSChannelExtras(r.allExtensions())
+	author := extras.authorPrivacy.rssAuthor(r.Author)
 
 	var href string
 	if r.Link != nil {
@@ -281,17 +393,21 @@ func (r *Rss) RssFeed() *RssFeed {
 		ManagingEditor: CData(author),
 		PubDate:        pub,
 		LastBuildDate:  build,
-		Copyright:      CData(r.Copyright),
-		Image:          rssImageFromFeed(r.Image, extras.imgW, extras.imgH),
+		Copyright:      CData(feedRights(r.Feed)),
+		Image:          rssImageFromFeed(r.Feed, extras.imgW, extras.imgH),
 		Language:       r.Language,
 		WebMaster:      CData(extras.webMaster),
-		Generator:      CData(extras.generator),
+		Generator:      CData(firstNonEmpty(extras.generator, r.Generator.String())),
 		Docs:           CData(extras.docs),
-		Cloud:          CData(extras.cloud),
+		Cloud:          extras.cloud,
 		Ttl:            extras.ttl,
 		Rating:         CData(extras.rating),
-		SkipHours:      CData(extras.skipHours),
-		SkipDays:       CData(extras.skipDays),
+		SkipHours:      extras.skipHours,
+		SkipDays:       extras.skipDays,
+
+		SyUpdatePeriod:    extras.syPeriod,
+		SyUpdateFrequency: extras.syFrequency,
+		SyUpdateBase:      extras.syBase,
 	}
 
 	// Category override or generic mapping
@@ -299,13 +415,17 @@ func (r *Rss) RssFeed() *RssFeed {
 
 	// append items
 	for _, it := range r.Items {
-		channel.Items = append(channel.Items, newRssItem(it))
+		item := newRssItem(it)
+		extras.authorPrivacy.applyToRSSItem(item, it.Author)
+		channel.Items = append(channel.Items, item)
 	}
 
 	// append non-RSS builder extensions
 	if len(extras.nonRSSExtras) > 0 {
 		channel.Extra = append(channel.Extra, extras.nonRSSExtras...)
 	}
+	// WebSub discovery links
+	channel.Extra = append(channel.Extra, websubLinkNodes(r.allExtensions(), r.FeedURL)...)
 	return channel
 }
 
@@ -319,11 +439,90 @@ func (r *RssFeed) FeedXml() interface{} {
 			break
 		}
 	}
+	syNS := ""
+	if r.SyUpdatePeriod != "" || r.SyUpdateFrequency > 0 || r.SyUpdateBase != "" {
+		syNS = xmlnsSy
+	}
+	// Only add the GeoRSS namespace if any item carries georss:* nodes
+	geoNS := ""
+	for _, it := range r.Items {
+		if hasGeoRSSNodes(it.Extra) {
+			geoNS = xmlnsGeoRSS
+			break
+		}
+	}
+	// Only add the Dublin Core namespace if any item has dc:language or dc:creator
+	dcNS := ""
+	for _, it := range r.Items {
+		if it.Language != "" || it.Creator != "" {
+			dcNS = xmlnsDc
+			break
+		}
+	}
+	// Only add the Atom namespace if the channel or an item carries atom:* nodes (e.g. WebSub
+	// or replies links), and the threading namespace if an item uses thr:*
+	atomNS := ""
+	if hasAtomNodes(r.Extra) {
+		atomNS = xmlnsAtom
+	}
+	thrNS, slashNS, wfwNS, mediaNS := "", "", "", ""
+	for _, it := range r.Items {
+		if it.Thumbnail != nil {
+			mediaNS = xmlnsMedia
+		}
+		if it.SlashCount != "" {
+			slashNS = xmlnsSlash
+		}
+		if it.CommentRss != "" {
+			wfwNS = xmlnsWfw
+		}
+		if hasAtomNodes(it.Extra) {
+			atomNS = xmlnsAtom
+		}
+		if usesThreadNamespace(it.Extra) {
+			thrNS = xmlnsThr
+		}
+	}
 	return &RssFeedXml{
 		Version:          "2.0",
 		Channel:          r,
 		ContentNamespace: contentNS,
+		SyNamespace:      syNS,
+		GeoRSSNamespace:  geoNS,
+		DcNamespace:      dcNS,
+		AtomNamespace:    atomNS,
+		ThrNamespace:     thrNS,
+		SlashNamespace:   slashNS,
+		WfwNamespace:     wfwNS,
+		MediaNamespace:   mediaNS,
+	}
+}
+
+// hasAtomNodes reports whether any node in exts is an atom:* element.
+func hasAtomNodes(exts []ExtensionNode) bool {
+	for _, n := range exts {
+		if strings.HasPrefix(textLowerTrim(n.Name), "atom:") {
+			return true
+		}
 	}
+	return false
+}
+
+// newRssSource maps Item.SourceFeed (title and feed URL) to <source url>; without a SourceFeed
+// feed URL, Item.Source.Href is used as url.
+func newRssSource(i *Item) *RssSource {
+	var src RssSource
+	if sf := i.SourceFeed; sf != nil {
+		src.URL = strings.TrimSpace(sf.FeedURL)
+		src.Title = strings.TrimSpace(sf.Title)
+	}
+	if src.URL == "" && i.Source != nil {
+		src.URL = strings.TrimSpace(i.Source.Href)
+	}
+	if src.URL == "" {
+		return nil
+	}
+	return &src
 }
 
 func newRssItem(i *Item) *RssItem {
@@ -331,6 +530,13 @@ func newRssItem(i *Item) *RssItem {
 		Title:       CData(i.Title),
 		Description: CData(i.Description),
 		PubDate:     anyTimeFormat(time.RFC1123Z, i.Created, i.Updated),
+		Language:    strings.TrimSpace(i.Language),
+		Comments:    CData(strings.TrimSpace(i.CommentsURL)),
+		CommentRss:  strings.TrimSpace(i.CommentsFeedURL),
+		Thumbnail:   newMediaThumbnail(strings.TrimSpace(i.ImageURL)),
+	}
+	if i.CommentCount != nil {
+		item.SlashCount = strconv.Itoa(*i.CommentCount)
 	}
 	if i.ID != "" {
 		item.Guid = &RssGuid{ID: i.ID, IsPermaLink: i.IsPermaLink}
@@ -341,9 +547,7 @@ func newRssItem(i *Item) *RssItem {
 	if len(i.Content) > 0 {
 		item.Content = &RssContent{Content: i.Content}
 	}
-	if i.Source != nil {
-		item.Source = i.Source.Href
-	}
+	item.Source = newRssSource(i)
 	if i.Enclosure != nil && i.Enclosure.Type != "" && i.Enclosure.Url != "" && i.Enclosure.Length > 0 {
 		item.Enclosure = &RssEnclosure{
 			Url:    i.Enclosure.Url,
@@ -359,22 +563,27 @@ func newRssItem(i *Item) *RssItem {
 		item.Author = CData(author)
 	}
 	// append extensions
-	if len(i.Extensions) > 0 {
-		cat, comments, extras := itemRSSExtensions(i.Extensions)
+	if exts := i.allExtensions(); len(exts) > 0 {
+		cat, comments, extras := itemRSSExtensions(exts)
 		item.Category = CData(cat)
-		item.Comments = CData(comments)
-		if len(extras) > 0 {
-			item.Extra = append(item.Extra, extras...)
+		if comments != "" {
+			item.Comments = CData(comments)
 		}
+		item.Extra = extras
 	}
 	return item
 }
 
 // MarshalXML customizes RSS item encoding to emit CDATA based on extensions (default on).
 func (it *RssItem) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return it.marshalXML(e, start, UseCDATAFromExtensions(it.Extra))
+}
+
+// marshalXML encodes the item with an explicit CDATA preference, so the channel can cascade its
+// preference without copying the item's extensions.
+func (it *RssItem) marshalXML(e *xml.Encoder, start xml.StartElement, itemUse bool) error {
 	// Force correct element name regardless of caller-provided start
 	start.Name.Local = "item"
-	itemUse := UseCDATAFromExtensions(it.Extra)
 	if err := e.EncodeToken(start); err != nil {
 		return err
 	}
@@ -385,8 +594,10 @@ func (it *RssItem) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		return err
 	}
 	// Source
-	if err := encodeElementIfSet(e, "source", it.Source); err != nil {
-		return err
+	if it.Source != nil {
+		if err := e.Encode(it.Source); err != nil {
+			return err
+		}
 	}
 	// Author
 	_ = encodeElementCDATA(e, "author", string(it.Author), itemUse)
@@ -415,19 +626,37 @@ func (it *RssItem) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	// Category, Comments
 	_ = encodeElementCDATA(e, "category", string(it.Category), itemUse)
 	_ = encodeElementCDATA(e, "comments", string(it.Comments), itemUse)
+	if err := encodeElementIfSet(e, "wfw:commentRss", it.CommentRss); err != nil {
+		return err
+	}
+	if err := encodeElementIfSet(e, "slash:comments", it.SlashCount); err != nil {
+		return err
+	}
+	// dc:language
+	if err := encodeElementIfSet(e, "dc:language", it.Language); err != nil {
+		return err
+	}
+	if err := encodeElementIfSet(e, "dc:creator", it.Creator); err != nil {
+		return err
+	}
+	if it.Thumbnail != nil {
+		if err := e.Encode(it.Thumbnail); err != nil {
+			return err
+		}
+	}
 	// Extra nodes
 	for _, n := range it.Extra {
 		if IsInternalExtensionName(n.Name) {
 			continue
 		}
-		if err := e.Encode(n); err != nil {
+		if err := n.MarshalXML(e, xml.StartElement{}); err != nil {
 			return err
 		}
 	}
 	if err := e.EncodeToken(start.End()); err != nil {
 		return err
 	}
-	return e.Flush()
+	return nil
 }
 
 // MarshalXML customizes RSS channel encoding to emit CDATA based on extensions (default on).
@@ -452,15 +681,17 @@ func (ch *RssFeed) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	if err := encodeElementIfSet(e, "pubDate", ch.PubDate); err != nil {
 		return err
 	}
+	if ch.itemsPlaceholder != "" {
+		if err := e.EncodeToken(xml.Comment(ch.itemsPlaceholder)); err != nil {
+			return err
+		}
+	}
 	for _, it := range ch.Items {
 		if it == nil {
 			continue
 		}
 		// Cascade channel preference to item (item may override via its own _xml:cdata extension)
-		itemUse := CDATAUseForItem(chUse, it.Extra)
-		tmp := *it
-		tmp.Extra = WithCDATAOverride(it.Extra, itemUse)
-		if err := tmp.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "item"}}); err != nil {
+		if err := it.marshalXML(e, xml.StartElement{}, CDATAUseForItem(chUse, it.Extra)); err != nil {
 			return err
 		}
 	}
@@ -478,19 +709,40 @@ func (ch *RssFeed) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	_ = encodeElementCDATA(e, "webMaster", string(ch.WebMaster), chUse)
 	_ = encodeElementCDATA(e, "generator", string(ch.Generator), chUse)
 	_ = encodeElementCDATA(e, "docs", string(ch.Docs), chUse)
-	_ = encodeElementCDATA(e, "cloud", string(ch.Cloud), chUse)
+	if ch.Cloud != nil {
+		if err := e.Encode(ch.Cloud); err != nil {
+			return err
+		}
+	}
 	if err := encodeIntElementIfPositive(e, "ttl", ch.Ttl); err != nil {
 		return err
 	}
 	_ = encodeElementCDATA(e, "rating", string(ch.Rating), chUse)
-	_ = encodeElementCDATA(e, "skipHours", string(ch.SkipHours), chUse)
-	_ = encodeElementCDATA(e, "skipDays", string(ch.SkipDays), chUse)
+	if ch.SkipHours != nil {
+		if err := e.Encode(ch.SkipHours); err != nil {
+			return err
+		}
+	}
+	if ch.SkipDays != nil {
+		if err := e.Encode(ch.SkipDays); err != nil {
+			return err
+		}
+	}
+	if err := encodeElementIfSet(e, "sy:updatePeriod", ch.SyUpdatePeriod); err != nil {
+		return err
+	}
+	if err := encodeIntElementIfPositive(e, "sy:updateFrequency", ch.SyUpdateFrequency); err != nil {
+		return err
+	}
+	if err := encodeElementIfSet(e, "sy:updateBase", ch.SyUpdateBase); err != nil {
+		return err
+	}
 
 	for _, n := range ch.Extra {
 		if IsInternalExtensionName(n.Name) {
 			continue
 		}
-		if err := e.Encode(n); err != nil {
+		if err := n.MarshalXML(e, xml.StartElement{}); err != nil {
 			return err
 		}
 	}
@@ -498,36 +750,42 @@ func (ch *RssFeed) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	if err := e.EncodeToken(start.End()); err != nil {
 		return err
 	}
-	return e.Flush()
+	return nil
 }
 
 // ValidateRSS enforces basic RSS 2.0.1 requirements on the generic Feed.
 func ValidateRSS(f *Feed) error {
 	// Channel-level required fields per RSS 2.0.1
 	if strings.TrimSpace(f.Title) == "" {
-		return errors.New("rss: channel title required")
+		return feedError(ProfileRSS, "Title", ErrMissingTitle, "rss: channel title required")
 	}
 	if f.Link == nil || strings.TrimSpace(f.Link.Href) == "" {
-		return errors.New("rss: channel link required")
+		return feedError(ProfileRSS, "Link", ErrMissingLink, "rss: channel link required").at("Link.Href")
 	}
 	if strings.TrimSpace(f.Description) == "" {
-		return errors.New("rss: channel description required")
+		return feedError(ProfileRSS, "Description", ErrMissingDescription, "rss: channel description required")
+	}
+	if err := validateLanguageIfSet(ProfileRSS, -1, f.Language); err != nil {
+		return err
 	}
 
 	for i, it := range f.Items {
 		// An item should have at least a title or a description
 		if strings.TrimSpace(it.Title) == "" && strings.TrimSpace(it.Description) == "" {
-			return fmt.Errorf("rss: item[%d] must include a title or a description", i)
+			return itemError(ProfileRSS, i, "Title", ErrMissingTitle, "rss: item[%d] must include a title or a description", i)
 		}
 		// If enclosure present, ensure required attributes are valid
 		if it.Enclosure != nil {
 			if strings.TrimSpace(it.Enclosure.Url) == "" || strings.TrimSpace(it.Enclosure.Type) == "" || it.Enclosure.Length <= 0 {
-				return fmt.Errorf("rss: item[%d] enclosure url/type/length required when enclosure present", i)
+				return itemError(ProfileRSS, i, "Enclosure", &ErrInvalidEnclosure{Index: i}, "rss: item[%d] enclosure url/type/length required when enclosure present", i).at(enclosurePath(it.Enclosure))
 			}
 		}
 		// RSS 2.0 author should be an email address when present
 		if it.Author != nil && strings.TrimSpace(it.Author.Email) == "" {
-			return fmt.Errorf("rss: item[%d] author must be an email address", i)
+			return itemError(ProfileRSS, i, "Author", ErrInvalidAuthor, "rss: item[%d] author must be an email address", i).at("Author.Email")
+		}
+		if err := validateLanguageIfSet(ProfileRSS, i, it.Language); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -540,7 +798,7 @@ func (b *FeedBuilder) WithRSSTTL(ttl int) *FeedBuilder {
 	if ttl <= 0 {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_rss:ttl", Text: strconv.Itoa(ttl)})
+	return b.withOption(ExtensionNode{Name: "_rss:ttl", Text: strconv.Itoa(ttl)})
 }
 
 func (b *FeedBuilder) WithRSSImageSize(width, height int) *FeedBuilder {
@@ -554,7 +812,7 @@ func (b *FeedBuilder) WithRSSImageSize(width, height int) *FeedBuilder {
 	if len(attrs) == 0 {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_rss:imageSize", Attrs: attrs})
+	return b.withOption(ExtensionNode{Name: "_rss:imageSize", Attrs: attrs})
 }
 
 func (b *FeedBuilder) WithRSSCategory(category string) *FeedBuilder {
@@ -562,7 +820,7 @@ func (b *FeedBuilder) WithRSSCategory(category string) *FeedBuilder {
 	if category == "" {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_rss:category", Text: category})
+	return b.withOption(ExtensionNode{Name: "_rss:category", Text: category})
 }
 
 func (b *FeedBuilder) WithRSSWebMaster(email string) *FeedBuilder {
@@ -570,7 +828,7 @@ func (b *FeedBuilder) WithRSSWebMaster(email string) *FeedBuilder {
 	if email == "" {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_rss:webMaster", Text: email})
+	return b.withOption(ExtensionNode{Name: "_rss:webMaster", Text: email})
 }
 
 func (b *FeedBuilder) WithRSSGenerator(gen string) *FeedBuilder {
@@ -578,7 +836,7 @@ func (b *FeedBuilder) WithRSSGenerator(gen string) *FeedBuilder {
 	if gen == "" {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_rss:generator", Text: gen})
+	return b.withOption(ExtensionNode{Name: "_rss:generator", Text: gen})
 }
 
 func (b *FeedBuilder) WithRSSDocs(url string) *FeedBuilder {
@@ -586,15 +844,51 @@ func (b *FeedBuilder) WithRSSDocs(url string) *FeedBuilder {
 	if url == "" {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_rss:docs", Text: url})
+	return b.withOption(ExtensionNode{Name: "_rss:docs", Text: url})
 }
 
+// WithRSSCloud used to emit <cloud> as text, which is not valid RSS.
+//
+// Deprecated: <cloud> carries only attributes; use WithRSSCloudEndpoint. A non-empty value is
+// reported by Build in strict mode and nothing is emitted.
 func (b *FeedBuilder) WithRSSCloud(cloud string) *FeedBuilder {
-	cloud = strings.TrimSpace(cloud)
-	if cloud == "" {
+	if strings.TrimSpace(cloud) == "" {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_rss:cloud", Text: cloud})
+	b.errs = append(b.errs, fmt.Errorf("rss: cloud %q: use WithRSSCloudEndpoint to set domain, port, path, procedure and protocol", cloud))
+	return b
+}
+
+// rssCloudProtocols lists the protocols an rssCloud endpoint may speak.
+var rssCloudProtocols = map[string]bool{"xml-rpc": true, "soap": true, "http-post": true}
+
+// WithRSSCloudEndpoint sets <cloud domain port path registerProcedure protocol/> so clients can
+// register for update notifications. protocol is xml-rpc, soap or http-post; procedure may be
+// empty for http-post and soap. Invalid input is reported by Build in strict mode.
+func (b *FeedBuilder) WithRSSCloudEndpoint(domain string, port int, path, procedure, protocol string) *FeedBuilder {
+	domain, path = strings.TrimSpace(domain), strings.TrimSpace(path)
+	protocol = textLowerTrim(protocol)
+	switch {
+	case domain == "":
+		b.errs = append(b.errs, fmt.Errorf("rss: cloud domain required"))
+	case port <= 0 || port > 65535:
+		b.errs = append(b.errs, fmt.Errorf("rss: cloud port %d out of range", port))
+	case !strings.HasPrefix(path, "/"):
+		b.errs = append(b.errs, fmt.Errorf("rss: cloud path %q must start with /", path))
+	case !rssCloudProtocols[protocol]:
+		b.errs = append(b.errs, fmt.Errorf("rss: cloud protocol %q must be xml-rpc, soap or http-post", protocol))
+	case protocol == "xml-rpc" && strings.TrimSpace(procedure) == "":
+		b.errs = append(b.errs, fmt.Errorf("rss: cloud registerProcedure required for xml-rpc"))
+	default:
+		return b.withOption(ExtensionNode{Name: "_rss:cloud", Attrs: map[string]string{
+			"domain":            domain,
+			"port":              strconv.Itoa(port),
+			"path":              path,
+			"registerProcedure": strings.TrimSpace(procedure),
+			"protocol":          protocol,
+		}})
+	}
+	return b
 }
 
 func (b *FeedBuilder) WithRSSRating(rating string) *FeedBuilder {
@@ -602,23 +896,74 @@ func (b *FeedBuilder) WithRSSRating(rating string) *FeedBuilder {
 	if rating == "" {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_rss:rating", Text: rating})
+	return b.withOption(ExtensionNode{Name: "_rss:rating", Text: rating})
 }
 
-func (b *FeedBuilder) WithRSSSkipHours(hours string) *FeedBuilder {
-	hours = strings.TrimSpace(hours)
-	if hours == "" {
+// WithRSSSkipHours sets <skipHours> with one <hour> per value (0-23, GMT), sorted and without
+// duplicates. Hours out of range are reported by Build in strict mode.
+func (b *FeedBuilder) WithRSSSkipHours(hours ...int) *FeedBuilder {
+	var seen [24]bool
+	for _, h := range hours {
+		if h < 0 || h > 23 {
+			b.errs = append(b.errs, fmt.Errorf("rss: skipHours hour %d out of range 0-23", h))
+			return b
+		}
+		seen[h] = true
+	}
+	var parts []string
+	for h, ok := range seen {
+		if ok {
+			parts = append(parts, strconv.Itoa(h))
+		}
+	}
+	if len(parts) == 0 {
+		return b
+	}
+	return b.withOption(ExtensionNode{Name: "_rss:skipHours", Text: strings.Join(parts, " ")})
+}
+
+// WithRSSSkipDays sets <skipDays> with one <day> per weekday (Monday..Sunday), in week order
+// from Sunday and without duplicates. Invalid weekdays are reported by Build in strict mode.
+func (b *FeedBuilder) WithRSSSkipDays(days ...time.Weekday) *FeedBuilder {
+	var seen [7]bool
+	for _, d := range days {
+		if d < time.Sunday || d > time.Saturday {
+			b.errs = append(b.errs, fmt.Errorf("rss: skipDays weekday %d out of range", int(d)))
+			return b
+		}
+		seen[d] = true
+	}
+	var names []string
+	for d, ok := range seen {
+		if ok {
+			names = append(names, time.Weekday(d).String())
+		}
+	}
+	if len(names) == 0 {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_rss:skipHours", Text: hours})
+	return b.withOption(ExtensionNode{Name: "_rss:skipDays", Text: strings.Join(names, " ")})
 }
 
-func (b *FeedBuilder) WithRSSSkipDays(days string) *FeedBuilder {
-	days = strings.TrimSpace(days)
-	if days == "" {
+// WithRSSSyndication sets Syndication module polling hints (sy:updatePeriod, sy:updateFrequency,
+// sy:updateBase) and declares xmlns:sy on the RSS root. period must be one of hourly, daily,
+// weekly, monthly or yearly; frequency (updates per period) and base are optional (0 / zero time).
+// Pairs with WithRSSTTL for aggregators that only understand one of the two hints.
+func (b *FeedBuilder) WithRSSSyndication(period string, frequency int, base time.Time) *FeedBuilder {
+	period = strings.ToLower(strings.TrimSpace(period))
+	switch period {
+	case "hourly", "daily", "weekly", "monthly", "yearly":
+	default:
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_rss:skipDays", Text: days})
+	attrs := map[string]string{"period": period}
+	if frequency > 0 {
+		attrs["frequency"] = strconv.Itoa(frequency)
+	}
+	if !base.IsZero() {
+		attrs["base"] = base.Format(time.RFC3339)
+	}
+	return b.withOption(ExtensionNode{Name: "_rss:syndication", Attrs: attrs})
 }
 
 // Item-level helpers:
@@ -628,7 +973,7 @@ func (b *ItemBuilder) WithRSSItemCategory(category string) *ItemBuilder {
 	if category == "" {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_rss:itemCategory", Text: category})
+	return b.withOption(ExtensionNode{Name: "_rss:itemCategory", Text: category})
 }
 
 func (b *ItemBuilder) WithRSSComments(url string) *ItemBuilder {
@@ -636,5 +981,5 @@ func (b *ItemBuilder) WithRSSComments(url string) *ItemBuilder {
 	if url == "" {
 		return b
 	}
-	return b.WithExtensions(ExtensionNode{Name: "_rss:comments", Text: url})
+	return b.withOption(ExtensionNode{Name: "_rss:comments", Text: url})
 }
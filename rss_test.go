@@ -355,10 +355,10 @@ func TestRSSBuilder_Helpers_ChannelAndItemFields_Moved(t *testing.T) {
 		WithRSSWebMaster("webmaster@example.org").
 		WithRSSGenerator("gofeedx").
 		WithRSSDocs("https://example.org/docs").
-		WithRSSCloud("cloud svc").
+		WithRSSCloudEndpoint("rpc.example.org", 80, "/RPC2", "myCloud.rssPleaseNotify", "xml-rpc").
 		WithRSSRating("PG").
-		WithRSSSkipHours("1 2").
-		WithRSSSkipDays("Mon Tue")
+		WithRSSSkipHours(2, 1, 2).
+		WithRSSSkipDays(time.Tuesday, time.Monday)
 
 	ib := gofeedx.NewItem("Item 1").
 		WithDescription("Item Desc").
@@ -379,10 +379,10 @@ func TestRSSBuilder_Helpers_ChannelAndItemFields_Moved(t *testing.T) {
 	mustContain(t, xml, "<webMaster>webmaster@example.org</webMaster>", "expected webMaster element")
 	mustContain(t, xml, "<generator>gofeedx</generator>", "expected generator element")
 	mustContain(t, xml, "<docs>https://example.org/docs</docs>", "expected docs element")
-	mustContain(t, xml, "<cloud>cloud svc</cloud>", "expected cloud element")
+	mustContain(t, xml, `<cloud domain="rpc.example.org" port="80" path="/RPC2" registerProcedure="myCloud.rssPleaseNotify" protocol="xml-rpc"></cloud>`, "expected cloud element")
 	mustContain(t, xml, "<rating>PG</rating>", "expected rating element")
-	mustContain(t, xml, "<skipHours>1 2</skipHours>", "expected skipHours element")
-	mustContain(t, xml, "<skipDays>Mon Tue</skipDays>", "expected skipDays element")
+	mustContain(t, xml, "<hour>1</hour>\n      <hour>2</hour>\n    </skipHours>", "expected skipHours element")
+	mustContain(t, xml, "<day>Monday</day>\n      <day>Tuesday</day>\n    </skipDays>", "expected skipDays element")
 
 	// Image size mapping
 	mustContain(t, xml, "<image>", "expected image element in channel")
@@ -414,8 +414,22 @@ func TestRSS_ItemSourceLinkMapped(t *testing.T) {
 	xmlStr, err := gofeedx.ToRSS(f)
 	mustNoErr(t, err, "ToRSS failed")
 
-	// Expect <source> element carrying the source href
-	mustContain(t, xmlStr, "<source>https://mirror.example.org/item1</source>", "expected source element from Item.Source")
+	// Expect <source> element carrying the source href as url
+	mustContain(t, xmlStr, `<source url="https://mirror.example.org/item1"></source>`, "expected source element from Item.Source")
+}
+
+func TestRSS_ItemSourceFeedTitleAndURL(t *testing.T) {
+	f := newRSSBaseFeed()
+	it := newRSSBaseItem()
+	it.Source = &gofeedx.Link{Href: "https://mirror.example.org/item1"}
+	it.SourceFeed = &gofeedx.SourceFeed{Title: "Origin & Co", FeedURL: "https://origin.example.org/rss.xml"}
+	f.Items = append(f.Items, it)
+	xmlStr, err := gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS failed")
+	mustContain(t, xmlStr, `<source url="https://origin.example.org/rss.xml">Origin &amp; Co</source>`, "expected source with url attribute and title text")
+	if len(gofeedx.LossReport(f, gofeedx.ProfileRSS)) != 0 {
+		t.Fatalf("expected no loss for SourceFeed with title and feed URL")
+	}
 }
 
 func TestRSS_ManagingEditorFromAuthorFormatting(t *testing.T) {
@@ -490,3 +504,119 @@ func TestRSSItemDescriptionOmittedWhenWhitespaceOnly(t *testing.T) {
 	itemBlock := rest[:end]
 	mustNotContain(t, itemBlock, "<description>", "did not expect item description element when whitespace-only")
 }
+
+func TestRSSSyndicationModule(t *testing.T) {
+	base := time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)
+	f, err := gofeedx.NewFeed("Site").
+		WithLink("https://example.org/").
+		WithDescription("d").
+		WithRSSTTL(60).
+		WithRSSSyndication("Hourly", 2, base).
+		Build()
+	if err != nil {
+		t.Fatalf("Build unexpected error: %v", err)
+	}
+	out, err := gofeedx.ToRSS(f)
+	if err != nil {
+		t.Fatalf("ToRSS unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		`xmlns:sy="http://purl.org/rss/1.0/modules/syndication/"`,
+		"<sy:updatePeriod>hourly</sy:updatePeriod>",
+		"<sy:updateFrequency>2</sy:updateFrequency>",
+		"<sy:updateBase>2000-01-01T12:00:00Z</sy:updateBase>",
+		"<ttl>60</ttl>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "_rss:") {
+		t.Errorf("internal marker leaked into output")
+	}
+
+	// Invalid period is ignored and no namespace is declared
+	f, _ = gofeedx.NewFeed("Site").WithLink("https://example.org/").WithDescription("d").
+		WithRSSSyndication("fortnightly", 1, time.Time{}).Build()
+	out, _ = gofeedx.ToRSS(f)
+	if strings.Contains(out, "xmlns:sy") || strings.Contains(out, "sy:update") {
+		t.Errorf("did not expect syndication output for invalid period:\n%s", out)
+	}
+}
+
+func TestRSSItemComments(t *testing.T) {
+	b := gofeedx.NewFeed("Blog").
+		WithLink("https://example.org/").
+		WithDescription("d")
+	b.AddItem(gofeedx.NewItem("Post").
+		WithLink("https://example.org/p/1").
+		WithCommentsURL("https://example.org/p/1#comments").
+		WithCommentsFeedURL("https://example.org/p/1/comments.xml").
+		WithCommentCount(0))
+	f, err := b.Build()
+	mustNoErr(t, err, "Build with comments")
+
+	xml, err := gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS failed")
+	mustContain(t, xml, `xmlns:slash="http://purl.org/rss/1.0/modules/slash/"`, "expected slash namespace")
+	mustContain(t, xml, `xmlns:wfw="http://wellformedweb.org/CommentAPI/"`, "expected wfw namespace")
+	mustContain(t, xml, "https://example.org/p/1#comments</comments>", "expected comments element")
+	mustContain(t, xml, "<wfw:commentRss>https://example.org/p/1/comments.xml</wfw:commentRss>", "expected wfw:commentRss")
+	mustContain(t, xml, "<slash:comments>0</slash:comments>", "expected slash:comments with zero count")
+
+	atom, err := gofeedx.ToAtom(f)
+	mustNoErr(t, err, "ToAtom failed")
+	mustContain(t, atom, `<link href="https://example.org/p/1#comments" rel="replies" type="text/html" thr:count="0"></link>`, "expected replies link with count")
+	mustContain(t, atom, `<link href="https://example.org/p/1/comments.xml" rel="replies"></link>`, "expected replies link to comments feed")
+
+	plain := newRSSBaseFeed()
+	plain.Items = append(plain.Items, newRSSBaseItem())
+	xml, err = gofeedx.ToRSS(plain)
+	mustNoErr(t, err, "ToRSS failed")
+	mustNotContain(t, xml, "xmlns:slash", "slash namespace only when used")
+	mustNotContain(t, xml, "xmlns:wfw", "wfw namespace only when used")
+
+	_, err = gofeedx.NewItem("Post").WithCommentCount(-1).Build()
+	mustErr(t, err, "expected error for negative comment count")
+}
+
+func TestRSSCloudEndpointValidation(t *testing.T) {
+	base := func() *gofeedx.FeedBuilder {
+		return gofeedx.NewFeed("T").WithLink("https://example.org/").WithDescription("d")
+	}
+	f, err := base().WithRSSCloudEndpoint("cloud.example.org", 443, "/notify", "", "HTTP-POST").Build()
+	mustNoErr(t, err, "valid http-post cloud")
+	xml, err := gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS failed")
+	mustContain(t, xml, `<cloud domain="cloud.example.org" port="443" path="/notify" registerProcedure="" protocol="http-post"></cloud>`, "expected http-post cloud")
+
+	for name, b := range map[string]*gofeedx.FeedBuilder{
+		"empty domain":     base().WithRSSCloudEndpoint("", 80, "/RPC2", "p", "xml-rpc"),
+		"bad port":         base().WithRSSCloudEndpoint("d", 70000, "/RPC2", "p", "xml-rpc"),
+		"relative path":    base().WithRSSCloudEndpoint("d", 80, "RPC2", "p", "xml-rpc"),
+		"unknown protocol": base().WithRSSCloudEndpoint("d", 80, "/RPC2", "p", "ftp"),
+		"missing proc":     base().WithRSSCloudEndpoint("d", 80, "/RPC2", "", "xml-rpc"),
+		"legacy text":      base().WithRSSCloud("cloud svc"),
+	} {
+		_, err := b.Build()
+		mustErr(t, err, "expected error for "+name)
+	}
+}
+
+func TestRSSSkipHoursAndDaysValidation(t *testing.T) {
+	base := func() *gofeedx.FeedBuilder {
+		return gofeedx.NewFeed("T").WithLink("https://example.org/").WithDescription("d")
+	}
+	_, err := base().WithRSSSkipHours(0, 24).Build()
+	mustErr(t, err, "expected error for hour 24")
+	_, err = base().WithRSSSkipDays(time.Weekday(7)).Build()
+	mustErr(t, err, "expected error for invalid weekday")
+
+	f, err := base().WithRSSSkipHours(0, 23).WithRSSSkipDays(time.Sunday).Build()
+	mustNoErr(t, err, "valid skipHours/skipDays")
+	xml, err := gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS failed")
+	mustContain(t, xml, "<hour>0</hour>", "expected hour 0")
+	mustContain(t, xml, "<hour>23</hour>", "expected hour 23")
+	mustContain(t, xml, "<day>Sunday</day>", "expected Sunday")
+}
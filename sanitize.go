@@ -0,0 +1,55 @@
+package gofeedx
+
+import (
+	"html/template"
+	"strings"
+)
+
+// HTMLSanitizer rewrites item HTML before it is written, e.g. to strip scripts and event
+// handler attributes from aggregated third-party content.
+type HTMLSanitizer func(html string) string
+
+// WithHTMLSanitizer makes the built feed run item HTML through s when it is rendered:
+// content:encoded and description in RSS and PSP, content and summary in Atom, content_html
+// and summary in JSON Feed, and the HTML of ToHTML, ToDigest and ToActivityStreams. The
+// item fields themselves are not modified. Without a sanitizer HTML is written unchanged
+// to feeds, and ToHTML and ToDigest fall back to escaped plain text.
+func (b *FeedBuilder) WithHTMLSanitizer(s HTMLSanitizer) *FeedBuilder {
+	b.feed.sanitizer = s
+	return b
+}
+
+// WithHTMLSanitizer returns a shallow copy of f that renders with s as its HTML sanitizer
+// (see FeedBuilder.WithHTMLSanitizer); nil removes it. f is not modified.
+func (f *Feed) WithHTMLSanitizer(s HTMLSanitizer) *Feed {
+	if f == nil {
+		return nil
+	}
+	c := *f
+	c.sanitizer = s
+	return &c
+}
+
+// safeHTML returns html for embedding in an HTML page when a sanitizer has run over it, or
+// its escaped plain text otherwise, one <p> per line of HTMLToText output (so paragraphs,
+// list items and <br>-separated lines stay apart).
+func safeHTML(html string, sanitized bool) template.HTML {
+	if sanitized {
+		return template.HTML(html)
+	}
+	var b strings.Builder
+	for _, line := range strings.Split(HTMLToText(html), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			b.WriteString("<p>" + template.HTMLEscapeString(line) + "</p>")
+		}
+	}
+	return template.HTML(b.String())
+}
+
+// sanitizeWith runs s over non-empty html.
+func sanitizeWith(s HTMLSanitizer, html string) string {
+	if s == nil || html == "" {
+		return html
+	}
+	return s(html)
+}
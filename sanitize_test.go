@@ -0,0 +1,59 @@
+package gofeedx_test
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestWithHTMLSanitizer(t *testing.T) {
+	script := regexp.MustCompile(`(?is)<script\b.*?</script>`)
+	strip := func(html string) string { return script.ReplaceAllString(html, "") }
+
+	f := newRSSBaseFeed()
+	it := newRSSBaseItem()
+	it.ID = "urn:uuid:1"
+	it.Description = `<p>desc</p><script>alert(1)</script>`
+	it.Content = `<p>body</p><script>alert(2)</script>`
+	f.Items = append(f.Items, it)
+	sanitized := f.WithHTMLSanitizer(strip)
+
+	rss, err := gofeedx.ToRSS(sanitized)
+	mustNoErr(t, err, "ToRSS failed")
+	atom, err := gofeedx.ToAtom(sanitized)
+	mustNoErr(t, err, "ToAtom failed")
+	json, err := gofeedx.ToJSON(sanitized)
+	mustNoErr(t, err, "ToJSON failed")
+	for name, out := range map[string]string{"rss": rss, "atom": atom, "json": json} {
+		mustNotContain(t, out, "alert(", name+": expected scripts to be stripped")
+	}
+	mustContain(t, rss, "<p>body</p>", "expected sanitized content to remain")
+	if it.Content != `<p>body</p><script>alert(2)</script>` {
+		t.Error("sanitizer must not modify the Feed")
+	}
+
+	rss, err = gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS failed")
+	mustContain(t, rss, "alert(2)", "expected the original feed to render without the sanitizer")
+
+	var buf bytes.Buffer
+	enc := gofeedx.NewEncoderWithOptions(gofeedx.EncodeOptions{HTMLSanitizer: strip})
+	mustNoErr(t, enc.EncodeRSS(f, &buf), "EncodeRSS failed")
+	mustNotContain(t, buf.String(), "alert(", "expected EncodeOptions.HTMLSanitizer to apply")
+}
+
+func TestFeedBuilderWithHTMLSanitizer(t *testing.T) {
+	f, err := gofeedx.NewFeed("Feed").
+		WithLink("https://example.org/").
+		WithDescription("desc").
+		WithHTMLSanitizer(func(string) string { return "<p>clean</p>" }).
+		AddItem(gofeedx.NewItem("Item").WithDescription("<p>raw</p>")).
+		Build()
+	mustNoErr(t, err, "Build failed")
+	rss, err := gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS failed")
+	mustContain(t, rss, "clean", "expected builder sanitizer to apply")
+	mustNotContain(t, rss, "raw", "expected raw HTML to be replaced")
+}
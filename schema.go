@@ -0,0 +1,260 @@
+package gofeedx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FeedSchemaID is the $id of the JSON Schema returned by FeedJSONSchema.
+const FeedSchemaID = "https://github.com/jo-hoe/gofeedx/feed.schema.json"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// FeedJSONSchema returns a JSON Schema (draft 2020-12) describing the canonical snapshot
+// written by Feed.MarshalCanonical (RFC 3339 timestamps). The schema is generated from the
+// snapshot structs so it stays in sync with the format; every nested struct is published
+// under $defs and unknown properties are rejected.
+func FeedJSONSchema() ([]byte, error) {
+	data, err := json.MarshalIndent(feedSchema(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// ValidateAgainstSchema checks that data is a JSON document matching FeedJSONSchema, e.g. a
+// snapshot received from another service before UnmarshalCanonical. Errors carry a
+// JSON-pointer-like path to the offending value (e.g. "/items/0/title").
+func ValidateAgainstSchema(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return fmt.Errorf("schema: invalid JSON: %w", err)
+	}
+	root := feedSchema()
+	defs, _ := root["$defs"].(map[string]any)
+	return validateSchemaValue(root, defs, doc, "")
+}
+
+// feedSchema builds the schema document as a generic map.
+func feedSchema() map[string]any {
+	defs := map[string]any{}
+	root := schemaForType(reflect.TypeOf(snapshotFeed{}), defs)
+	root["required"] = []any{"version"}
+	root["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	root["$id"] = FeedSchemaID
+	root["title"] = "gofeedx Feed"
+	root["$defs"] = defs
+	return root
+}
+
+// schemaForType returns the inline schema for t, registering struct types in defs.
+// The top-level struct is returned inline; nested structs are referenced via $ref.
+func schemaForType(t reflect.Type, defs map[string]any) map[string]any {
+	if t.Kind() == reflect.Struct && t != timeType {
+		return structSchema(t, defs)
+	}
+	return typeRef(t, defs)
+}
+
+func typeRef(t reflect.Type, defs map[string]any) map[string]any {
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t.Kind() == reflect.Pointer:
+		inner := typeRef(t.Elem(), defs)
+		return map[string]any{"anyOf": []any{inner, map[string]any{"type": "null"}}}
+	case t.Kind() == reflect.Struct:
+		name := strings.TrimPrefix(t.Name(), "snapshot")
+		if _, ok := defs[name]; !ok {
+			defs[name] = map[string]any{} // placeholder for recursive types
+			defs[name] = structSchema(t, defs)
+		}
+		return map[string]any{"$ref": "#/$defs/" + name}
+	case t.Kind() == reflect.Slice:
+		return map[string]any{"type": []any{"array", "null"}, "items": typeRef(t.Elem(), defs)}
+	case t.Kind() == reflect.Map:
+		return map[string]any{"type": []any{"object", "null"}, "additionalProperties": typeRef(t.Elem(), defs)}
+	case t.Kind() == reflect.String:
+		return map[string]any{"type": "string"}
+	case t.Kind() == reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return map[string]any{"type": "number"}
+	default:
+		return map[string]any{}
+	}
+}
+
+func structSchema(t reflect.Type, defs map[string]any) map[string]any {
+	props := map[string]any{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := f.Name
+		if tag, ok := f.Tag.Lookup("json"); ok {
+			n := strings.Split(tag, ",")[0]
+			if n == "-" {
+				continue
+			}
+			if n != "" {
+				name = n
+			}
+		}
+		prop := typeRef(f.Type, defs)
+		if format := f.Tag.Get("schema"); format != "" {
+			prop["format"] = format
+		}
+		props[name] = prop
+	}
+	return map[string]any{
+		"type":                 "object",
+		"properties":           props,
+		"additionalProperties": false,
+	}
+}
+
+// validateSchemaValue validates v against the subset of JSON Schema emitted by feedSchema:
+// type, format (date-time), properties, required, additionalProperties, items, anyOf and
+// local $ref.
+func validateSchemaValue(schema map[string]any, defs map[string]any, v any, path string) error {
+	if ref, ok := schema["$ref"].(string); ok {
+		target, _ := defs[strings.TrimPrefix(ref, "#/$defs/")].(map[string]any)
+		if target == nil {
+			return fmt.Errorf("schema: %s: unresolved reference %s", schemaPath(path), ref)
+		}
+		return validateSchemaValue(target, defs, v, path)
+	}
+	if anyOf, ok := schema["anyOf"].([]any); ok {
+		var firstErr error
+		for _, alt := range anyOf {
+			err := validateSchemaValue(alt.(map[string]any), defs, v, path)
+			if err == nil {
+				return nil
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		return firstErr
+	}
+	if err := checkSchemaType(schema["type"], v, path); err != nil {
+		return err
+	}
+	switch val := v.(type) {
+	case string:
+		if schema["format"] == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, val); err != nil {
+				return fmt.Errorf("schema: %s: %q is not an RFC 3339 date-time", schemaPath(path), val)
+			}
+		}
+	case []any:
+		items, _ := schema["items"].(map[string]any)
+		for i, el := range val {
+			if items == nil {
+				break
+			}
+			if err := validateSchemaValue(items, defs, el, fmt.Sprintf("%s/%d", path, i)); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		return validateSchemaObject(schema, defs, val, path)
+	}
+	return nil
+}
+
+func validateSchemaObject(schema map[string]any, defs map[string]any, obj map[string]any, path string) error {
+	props, _ := schema["properties"].(map[string]any)
+	required, _ := schema["required"].([]any)
+	for _, r := range required {
+		if _, ok := obj[fmt.Sprint(r)]; !ok {
+			return fmt.Errorf("schema: %s: missing required property %q", schemaPath(path), r)
+		}
+	}
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		sub, known := props[k].(map[string]any)
+		if !known {
+			switch ap := schema["additionalProperties"].(type) {
+			case bool:
+				if !ap {
+					return fmt.Errorf("schema: %s: unknown property %q", schemaPath(path), k)
+				}
+				continue
+			case map[string]any:
+				sub = ap
+			default:
+				continue
+			}
+		}
+		if err := validateSchemaValue(sub, defs, obj[k], path+"/"+k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkSchemaType(typ any, v any, path string) error {
+	var allowed []string
+	switch t := typ.(type) {
+	case string:
+		allowed = []string{t}
+	case []any:
+		for _, x := range t {
+			allowed = append(allowed, fmt.Sprint(x))
+		}
+	default:
+		return nil
+	}
+	actual := jsonTypeOf(v)
+	for _, a := range allowed {
+		if a == actual || (a == "number" && actual == "integer") {
+			return nil
+		}
+	}
+	return fmt.Errorf("schema: %s: expected %s, got %s", schemaPath(path), strings.Join(allowed, " or "), actual)
+}
+
+func jsonTypeOf(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := val.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+func schemaPath(p string) string {
+	if p == "" {
+		return "/"
+	}
+	return p
+}
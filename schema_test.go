@@ -0,0 +1,71 @@
+package gofeedx
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFeedJSONSchema_Structure(t *testing.T) {
+	data, err := FeedJSONSchema()
+	if err != nil {
+		t.Fatalf("FeedJSONSchema unexpected error: %v", err)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+	if m["$id"] != FeedSchemaID || m["type"] != "object" {
+		t.Errorf("unexpected schema root: %v", m)
+	}
+	props := m["properties"].(map[string]any)
+	for _, k := range []string{"version", "title", "items", "extensions", "options", "updated"} {
+		if _, ok := props[k]; !ok {
+			t.Errorf("schema missing Feed property %q", k)
+		}
+	}
+	defs := m["$defs"].(map[string]any)
+	for _, k := range []string{"Item", "Link", "Enclosure", "Node"} {
+		if _, ok := defs[k]; !ok {
+			t.Errorf("schema missing $defs/%s", k)
+		}
+	}
+}
+
+func TestValidateAgainstSchema(t *testing.T) {
+	f := &Feed{
+		Title:   "T",
+		Link:    &Link{Href: "https://example.org/"},
+		Updated: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Items: []*Item{{
+			Title:      "I",
+			Enclosure:  &Enclosure{Url: "https://example.org/a.mp3", Length: 10, Type: "audio/mpeg"},
+			Extensions: []ExtensionNode{{Name: "x:y", Attrs: map[string]string{"a": "b"}, Children: []ExtensionNode{{Name: "z"}}}},
+		}},
+	}
+	f.Items[0].options = []ExtensionNode{{Name: "_xml:flag", Text: "true"}}
+	data, err := f.MarshalCanonical()
+	if err != nil {
+		t.Fatalf("marshal feed: %v", err)
+	}
+	if err := ValidateAgainstSchema(data); err != nil {
+		t.Fatalf("canonical snapshot should validate: %v", err)
+	}
+
+	bad := map[string]string{
+		`{"version": 1, "title": 5}`:                           "/title: expected string",
+		`{"version": 1, "title": "t", "Bogus": 1}`:             `unknown property "Bogus"`,
+		`{"version": 1, "items": [{"title": "a", "Nope": 1}]}`: "/items/0",
+		`{"version": 1, "updated": "yesterday"}`:               "RFC 3339",
+		`{"version": 1, "items": [{"durationSeconds": 1.5}]}`:  "/items/0/durationSeconds",
+		`{"title": "t"}`: `missing required property "version"`,
+		`not json`:       "invalid JSON",
+	}
+	for doc, want := range bad {
+		err := ValidateAgainstSchema([]byte(doc))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ValidateAgainstSchema(%s) error = %v, want containing %q", doc, err, want)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package gofeedx
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SitemapMaxURLs is the sitemaps.org limit of URLs per sitemap file.
+const SitemapMaxURLs = 50000
+
+const xmlnsSitemap = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	Lastmod string `xml:"lastmod,omitempty"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	Xmlns    string       `xml:"xmlns,attr"`
+	Sitemaps []sitemapURL `xml:"sitemap"`
+}
+
+// sitemapDoc adapts a sitemap value to XmlFeed so it is written like the feed formats.
+type sitemapDoc struct{ v interface{} }
+
+func (d sitemapDoc) FeedXml() interface{} { return d.v }
+
+// ToSitemap renders the item links of feed as a sitemaps.org urlset: one <url> per distinct
+// absolute link, with <lastmod> from the item's latest Updated or Created time. Items without
+// a link are skipped. Feeds with more than SitemapMaxURLs links need ToSitemapIndex.
+func ToSitemap(feed *Feed) (string, error) {
+	urls, err := sitemapURLs(feed)
+	if err != nil {
+		return "", err
+	}
+	if len(urls) > SitemapMaxURLs {
+		return "", fmt.Errorf("sitemap: %d urls exceed the limit of %d; use ToSitemapIndex", len(urls), SitemapMaxURLs)
+	}
+	return ToXML(sitemapDoc{&sitemapURLSet{Xmlns: xmlnsSitemap, URLs: urls}})
+}
+
+// ToSitemapIndex splits the item links of feed into sitemap files of at most SitemapMaxURLs
+// URLs each and returns them with a sitemap index listing them. locFor returns the absolute
+// URL where the n-th sitemap (starting at 1) will be served; each index entry's <lastmod> is
+// the latest lastmod of its sitemap.
+func ToSitemapIndex(feed *Feed, locFor func(n int) string) (index string, sitemaps []string, err error) {
+	if locFor == nil {
+		return "", nil, errors.New("sitemap: nil locFor")
+	}
+	urls, err := sitemapURLs(feed)
+	if err != nil {
+		return "", nil, err
+	}
+	idx := &sitemapIndex{Xmlns: xmlnsSitemap}
+	for n := 1; len(urls) > 0 || n == 1; n++ {
+		chunk := urls[:min(len(urls), SitemapMaxURLs)]
+		urls = urls[len(chunk):]
+		out, err := ToXML(sitemapDoc{&sitemapURLSet{Xmlns: xmlnsSitemap, URLs: chunk}})
+		if err != nil {
+			return "", nil, err
+		}
+		sitemaps = append(sitemaps, out)
+		loc := strings.TrimSpace(locFor(n))
+		if !isAbsoluteURL(loc) {
+			return "", nil, fmt.Errorf("sitemap: location %q of sitemap %d must be absolute", loc, n)
+		}
+		lastmod := ""
+		for _, u := range chunk {
+			lastmod = max(lastmod, u.Lastmod) // same format and zone, so strings compare like times
+		}
+		idx.Sitemaps = append(idx.Sitemaps, sitemapURL{Loc: loc, Lastmod: lastmod})
+	}
+	index, err = ToXML(sitemapDoc{idx})
+	if err != nil {
+		return "", nil, err
+	}
+	return index, sitemaps, nil
+}
+
+// sitemapURLs collects the distinct item links of feed in item order.
+func sitemapURLs(feed *Feed) ([]sitemapURL, error) {
+	if feed == nil {
+		return nil, errors.New("nil feed")
+	}
+	var out []sitemapURL
+	seen := map[string]int{}
+	for i, it := range feed.Items {
+		if it == nil || it.Link == nil || strings.TrimSpace(it.Link.Href) == "" {
+			continue
+		}
+		loc := strings.TrimSpace(it.Link.Href)
+		if !isAbsoluteURL(loc) {
+			return nil, fmt.Errorf("sitemap: item[%d] link %q must be absolute", i, loc)
+		}
+		lastmod := anyTimeFormat(time.RFC3339, maxTime(it.Created, it.Updated).UTC())
+		if j, ok := seen[loc]; ok {
+			out[j].Lastmod = max(out[j].Lastmod, lastmod)
+			continue
+		}
+		seen[loc] = len(out)
+		out = append(out, sitemapURL{Loc: loc, Lastmod: lastmod})
+	}
+	return out, nil
+}
@@ -0,0 +1,52 @@
+package gofeedx_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestToSitemap(t *testing.T) {
+	t1 := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	f := &gofeedx.Feed{Title: "Blog", Items: []*gofeedx.Item{
+		{Title: "a", Link: &gofeedx.Link{Href: "https://example.org/a"}, Created: t1},
+		{Title: "b", Link: &gofeedx.Link{Href: "https://example.org/b"}},
+		{Title: "no link"},
+		{Title: "a again", Link: &gofeedx.Link{Href: "https://example.org/a"}, Updated: t1.Add(time.Hour)},
+	}}
+	out, err := gofeedx.ToSitemap(f)
+	mustNoErr(t, err, "ToSitemap failed")
+	mustContain(t, out, `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`, "expected urlset")
+	mustContain(t, out, "<loc>https://example.org/a</loc>\n    <lastmod>2024-01-02T04:04:05Z</lastmod>", "expected newest lastmod for duplicate link")
+	mustContain(t, out, "<loc>https://example.org/b</loc>\n  </url>", "expected url without lastmod")
+	if strings.Count(out, "<url>") != 2 {
+		t.Errorf("expected 2 urls:\n%s", out)
+	}
+
+	f.Items[1].Link.Href = "/b"
+	_, err = gofeedx.ToSitemap(f)
+	mustErr(t, err, "expected error for relative link")
+}
+
+func TestToSitemapIndex_Splits(t *testing.T) {
+	f := &gofeedx.Feed{Title: "Big"}
+	for i := 0; i <= gofeedx.SitemapMaxURLs; i++ {
+		f.Items = append(f.Items, &gofeedx.Item{Link: &gofeedx.Link{Href: fmt.Sprintf("https://example.org/p/%d", i)}})
+	}
+	_, err := gofeedx.ToSitemap(f)
+	mustErr(t, err, "expected ToSitemap to refuse more than SitemapMaxURLs")
+
+	index, sitemaps, err := gofeedx.ToSitemapIndex(f, func(n int) string { return fmt.Sprintf("https://example.org/sitemap-%d.xml", n) })
+	mustNoErr(t, err, "ToSitemapIndex failed")
+	if len(sitemaps) != 2 || strings.Count(sitemaps[1], "<url>") != 1 {
+		t.Fatalf("expected a full sitemap and one with a single url, got %d files", len(sitemaps))
+	}
+	mustContain(t, index, "<sitemapindex", "expected sitemap index")
+	mustContain(t, index, "<loc>https://example.org/sitemap-2.xml</loc>", "expected second sitemap in index")
+
+	_, _, err = gofeedx.ToSitemapIndex(f, func(n int) string { return "sitemap.xml" })
+	mustErr(t, err, "expected error for relative sitemap location")
+}
@@ -0,0 +1,366 @@
+package gofeedx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SnapshotVersion is the version of the canonical snapshot format written by MarshalCanonical.
+const SnapshotVersion = 1
+
+// MarshalCanonical serializes the generic Feed model itself (not a JSON Feed document), so a
+// feed can move between services or processes before an output format is chosen. The snapshot
+// keeps every exported field, Extensions and the builder-recorded format options (PSP flags,
+// RSS/Atom settings and the like); times keep their instant and UTC offset. Enclosure rewriters
+// are functions and are not part of the snapshot. The output is deterministic for equal feeds.
+func (f *Feed) MarshalCanonical() ([]byte, error) {
+	if f == nil {
+		return nil, errors.New("snapshot: nil feed")
+	}
+	return json.Marshal(newSnapshotFeed(f))
+}
+
+// UnmarshalCanonical parses a snapshot written by MarshalCanonical back into a Feed.
+func UnmarshalCanonical(data []byte) (*Feed, error) {
+	var sf snapshotFeed
+	if err := json.Unmarshal(data, &sf); err != nil {
+		return nil, fmt.Errorf("snapshot: %w", err)
+	}
+	if sf.Version != SnapshotVersion {
+		return nil, fmt.Errorf("snapshot: unsupported version %d", sf.Version)
+	}
+	feed, err := sf.feed()
+	if err != nil {
+		return nil, fmt.Errorf("snapshot: %w", err)
+	}
+	return feed, nil
+}
+
+type (
+	snapshotFeed struct {
+		Version     int                 `json:"version"`
+		Title       string              `json:"title,omitempty"`
+		Link        *snapshotLink       `json:"link,omitempty"`
+		Description string              `json:"description,omitempty"`
+		Author      *snapshotAuthor     `json:"author,omitempty"`
+		Updated     string              `json:"updated,omitempty" schema:"date-time"`
+		Created     string              `json:"created,omitempty" schema:"date-time"`
+		ID          string              `json:"id,omitempty"`
+		Copyright   string              `json:"copyright,omitempty"`
+		License     *snapshotLicense    `json:"license,omitempty"`
+		Image       *snapshotImage      `json:"image,omitempty"`
+		Images      *snapshotImages     `json:"images,omitempty"`
+		Language    string              `json:"language,omitempty"`
+		Generator   *snapshotGenerator  `json:"generator,omitempty"`
+		FeedURL     string              `json:"feedURL,omitempty"`
+		Categories  []*snapshotCategory `json:"categories,omitempty"`
+		Extensions  []snapshotNode      `json:"extensions,omitempty"`
+		Options     []snapshotNode      `json:"options,omitempty"`
+		Items       []*snapshotItem     `json:"items,omitempty"`
+	}
+	snapshotItem struct {
+		Title           string              `json:"title,omitempty"`
+		Link            *snapshotLink       `json:"link,omitempty"`
+		Source          *snapshotLink       `json:"source,omitempty"`
+		Author          *snapshotAuthor     `json:"author,omitempty"`
+		Description     string              `json:"description,omitempty"`
+		ID              string              `json:"id,omitempty"`
+		IsPermaLink     string              `json:"isPermaLink,omitempty"`
+		Updated         string              `json:"updated,omitempty" schema:"date-time"`
+		Created         string              `json:"created,omitempty" schema:"date-time"`
+		Enclosure       *snapshotEnclosure  `json:"enclosure,omitempty"`
+		Content         string              `json:"content,omitempty"`
+		Language        string              `json:"language,omitempty"`
+		SourceFeed      *snapshotSourceFeed `json:"sourceFeed,omitempty"`
+		ImageURL        string              `json:"imageURL,omitempty"`
+		CommentsURL     string              `json:"commentsURL,omitempty"`
+		CommentsFeedURL string              `json:"commentsFeedURL,omitempty"`
+		CommentCount    *int                `json:"commentCount,omitempty"`
+		DurationSeconds int                 `json:"durationSeconds,omitempty"`
+		Extensions      []snapshotNode      `json:"extensions,omitempty"`
+		Options         []snapshotNode      `json:"options,omitempty"`
+	}
+	snapshotLink struct {
+		Href string `json:"href"`
+	}
+	snapshotAuthor struct {
+		Name  string `json:"name,omitempty"`
+		Email string `json:"email,omitempty"`
+	}
+	snapshotLicense struct {
+		Name string `json:"name,omitempty"`
+		URL  string `json:"url,omitempty"`
+	}
+	snapshotImage struct {
+		URL   string `json:"url,omitempty"`
+		Title string `json:"title,omitempty"`
+		Link  string `json:"link,omitempty"`
+	}
+	snapshotImages struct {
+		Icon    string `json:"icon,omitempty"`
+		Logo    string `json:"logo,omitempty"`
+		Artwork string `json:"artwork,omitempty"`
+	}
+	snapshotGenerator struct {
+		Name    string `json:"name,omitempty"`
+		Version string `json:"version,omitempty"`
+		URL     string `json:"url,omitempty"`
+	}
+	snapshotCategory struct {
+		Text   string `json:"text,omitempty"`
+		Scheme string `json:"scheme,omitempty"`
+		Label  string `json:"label,omitempty"`
+	}
+	snapshotEnclosure struct {
+		URL    string `json:"url,omitempty"`
+		Length int64  `json:"length,omitempty"`
+		Type   string `json:"type,omitempty"`
+	}
+	snapshotSourceFeed struct {
+		ID      string `json:"id,omitempty"`
+		Title   string `json:"title,omitempty"`
+		Link    string `json:"link,omitempty"`
+		FeedURL string `json:"feedURL,omitempty"`
+		Updated string `json:"updated,omitempty" schema:"date-time"`
+	}
+	snapshotNode struct {
+		Name     string            `json:"name"`
+		Attrs    map[string]string `json:"attrs,omitempty"`
+		Text     string            `json:"text,omitempty"`
+		Children []snapshotNode    `json:"children,omitempty"`
+	}
+)
+
+func newSnapshotFeed(f *Feed) *snapshotFeed {
+	sf := &snapshotFeed{
+		Version:     SnapshotVersion,
+		Title:       f.Title,
+		Link:        newSnapshotLink(f.Link),
+		Description: f.Description,
+		Author:      newSnapshotAuthor(f.Author),
+		Updated:     formatSnapshotTime(f.Updated),
+		Created:     formatSnapshotTime(f.Created),
+		ID:          f.ID,
+		Copyright:   f.Copyright,
+		Language:    f.Language,
+		FeedURL:     f.FeedURL,
+		Extensions:  newSnapshotNodes(f.Extensions),
+		Options:     newSnapshotNodes(f.options),
+	}
+	if f.License != nil {
+		sf.License = &snapshotLicense{Name: f.License.Name, URL: f.License.URL}
+	}
+	if f.Image != nil {
+		sf.Image = &snapshotImage{URL: f.Image.Url, Title: f.Image.Title, Link: f.Image.Link}
+	}
+	if f.Images != nil {
+		sf.Images = &snapshotImages{Icon: f.Images.Icon, Logo: f.Images.Logo, Artwork: f.Images.Artwork}
+	}
+	if f.Generator != nil {
+		sf.Generator = &snapshotGenerator{Name: f.Generator.Name, Version: f.Generator.Version, URL: f.Generator.URL}
+	}
+	for _, c := range f.Categories {
+		if c != nil {
+			sf.Categories = append(sf.Categories, &snapshotCategory{Text: c.Text, Scheme: c.Scheme, Label: c.Label})
+		}
+	}
+	for _, it := range f.Items {
+		if it != nil {
+			sf.Items = append(sf.Items, newSnapshotItem(it))
+		}
+	}
+	return sf
+}
+
+func newSnapshotItem(it *Item) *snapshotItem {
+	si := &snapshotItem{
+		Title:           it.Title,
+		Link:            newSnapshotLink(it.Link),
+		Source:          newSnapshotLink(it.Source),
+		Author:          newSnapshotAuthor(it.Author),
+		Description:     it.Description,
+		ID:              it.ID,
+		IsPermaLink:     it.IsPermaLink,
+		Updated:         formatSnapshotTime(it.Updated),
+		Created:         formatSnapshotTime(it.Created),
+		Content:         it.Content,
+		Language:        it.Language,
+		ImageURL:        it.ImageURL,
+		CommentsURL:     it.CommentsURL,
+		CommentsFeedURL: it.CommentsFeedURL,
+		DurationSeconds: it.DurationSeconds,
+		Extensions:      newSnapshotNodes(it.Extensions),
+		Options:         newSnapshotNodes(it.options),
+	}
+	if it.Enclosure != nil {
+		si.Enclosure = &snapshotEnclosure{URL: it.Enclosure.Url, Length: it.Enclosure.Length, Type: it.Enclosure.Type}
+	}
+	if sf := it.SourceFeed; sf != nil {
+		si.SourceFeed = &snapshotSourceFeed{ID: sf.ID, Title: sf.Title, Link: sf.Link, FeedURL: sf.FeedURL, Updated: formatSnapshotTime(sf.Updated)}
+	}
+	if it.CommentCount != nil {
+		n := *it.CommentCount
+		si.CommentCount = &n
+	}
+	return si
+}
+
+func newSnapshotLink(l *Link) *snapshotLink {
+	if l == nil {
+		return nil
+	}
+	return &snapshotLink{Href: l.Href}
+}
+
+func newSnapshotAuthor(a *Author) *snapshotAuthor {
+	if a == nil {
+		return nil
+	}
+	return &snapshotAuthor{Name: a.Name, Email: a.Email}
+}
+
+func newSnapshotNodes(nodes []ExtensionNode) []snapshotNode {
+	if len(nodes) == 0 {
+		return nil
+	}
+	out := make([]snapshotNode, 0, len(nodes))
+	for _, n := range nodes {
+		out = append(out, snapshotNode{Name: n.Name, Attrs: n.Attrs, Text: n.Text, Children: newSnapshotNodes(n.Children)})
+	}
+	return out
+}
+
+// formatSnapshotTime keeps the instant to the nanosecond and the UTC offset; "" is the zero time.
+func formatSnapshotTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+func parseSnapshotTime(field, s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", field, err)
+	}
+	return t, nil
+}
+
+func (sf *snapshotFeed) feed() (*Feed, error) {
+	f := &Feed{
+		Title:       sf.Title,
+		Link:        sf.Link.link(),
+		Description: sf.Description,
+		Author:      sf.Author.author(),
+		ID:          sf.ID,
+		Copyright:   sf.Copyright,
+		Language:    sf.Language,
+		FeedURL:     sf.FeedURL,
+		Extensions:  snapshotExtensions(sf.Extensions),
+		options:     snapshotExtensions(sf.Options),
+	}
+	var err error
+	if f.Updated, err = parseSnapshotTime("updated", sf.Updated); err != nil {
+		return nil, err
+	}
+	if f.Created, err = parseSnapshotTime("created", sf.Created); err != nil {
+		return nil, err
+	}
+	if l := sf.License; l != nil {
+		f.License = &License{Name: l.Name, URL: l.URL}
+	}
+	if im := sf.Image; im != nil {
+		f.Image = &Image{Url: im.URL, Title: im.Title, Link: im.Link}
+	}
+	if im := sf.Images; im != nil {
+		f.Images = &Images{Icon: im.Icon, Logo: im.Logo, Artwork: im.Artwork}
+	}
+	if g := sf.Generator; g != nil {
+		f.Generator = &Generator{Name: g.Name, Version: g.Version, URL: g.URL}
+	}
+	for _, c := range sf.Categories {
+		if c != nil {
+			f.Categories = append(f.Categories, &Category{Text: c.Text, Scheme: c.Scheme, Label: c.Label})
+		}
+	}
+	for i, si := range sf.Items {
+		if si == nil {
+			continue
+		}
+		it, err := si.item()
+		if err != nil {
+			return nil, fmt.Errorf("items[%d]: %w", i, err)
+		}
+		f.Items = append(f.Items, it)
+	}
+	return f, nil
+}
+
+func (si *snapshotItem) item() (*Item, error) {
+	it := &Item{
+		Title:           si.Title,
+		Link:            si.Link.link(),
+		Source:          si.Source.link(),
+		Author:          si.Author.author(),
+		Description:     si.Description,
+		ID:              si.ID,
+		IsPermaLink:     si.IsPermaLink,
+		Content:         si.Content,
+		Language:        si.Language,
+		ImageURL:        si.ImageURL,
+		CommentsURL:     si.CommentsURL,
+		CommentsFeedURL: si.CommentsFeedURL,
+		CommentCount:    si.CommentCount,
+		DurationSeconds: si.DurationSeconds,
+		Extensions:      snapshotExtensions(si.Extensions),
+		options:         snapshotExtensions(si.Options),
+	}
+	var err error
+	if it.Updated, err = parseSnapshotTime("updated", si.Updated); err != nil {
+		return nil, err
+	}
+	if it.Created, err = parseSnapshotTime("created", si.Created); err != nil {
+		return nil, err
+	}
+	if e := si.Enclosure; e != nil {
+		it.Enclosure = &Enclosure{Url: e.URL, Length: e.Length, Type: e.Type}
+	}
+	if s := si.SourceFeed; s != nil {
+		updated, err := parseSnapshotTime("sourceFeed.updated", s.Updated)
+		if err != nil {
+			return nil, err
+		}
+		it.SourceFeed = &SourceFeed{ID: s.ID, Title: s.Title, Link: s.Link, FeedURL: s.FeedURL, Updated: updated}
+	}
+	return it, nil
+}
+
+func (l *snapshotLink) link() *Link {
+	if l == nil {
+		return nil
+	}
+	return &Link{Href: l.Href}
+}
+
+func (a *snapshotAuthor) author() *Author {
+	if a == nil {
+		return nil
+	}
+	return &Author{Name: a.Name, Email: a.Email}
+}
+
+func snapshotExtensions(nodes []snapshotNode) []ExtensionNode {
+	if len(nodes) == 0 {
+		return nil
+	}
+	out := make([]ExtensionNode, 0, len(nodes))
+	for _, n := range nodes {
+		out = append(out, ExtensionNode{Name: n.Name, Attrs: n.Attrs, Text: n.Text, Children: snapshotExtensions(n.Children)})
+	}
+	return out
+}
@@ -0,0 +1,65 @@
+package gofeedx_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestCanonicalSnapshot_RoundTrip(t *testing.T) {
+	created := time.Date(2024, 5, 1, 8, 0, 0, 123456789, time.FixedZone("CEST", 2*3600))
+	b := gofeedx.NewFeed("Snapshot").
+		WithLink("https://example.com/").
+		WithDescription("d").
+		WithLanguage("en").
+		WithFeedURL("https://example.com/podcast.rss").
+		WithImage("https://example.com/artwork.jpg", "", "").
+		WithCategories("Technology").
+		WithAuthor("Host", "host@example.com").
+		WithLicense("CC BY 4.0", "https://creativecommons.org/licenses/by/4.0/").
+		WithPSPExplicit(true).
+		WithExtensions(gofeedx.ExtensionNode{Name: "podcast:locked", Attrs: map[string]string{"owner": "host@example.com"}, Text: "yes"})
+	b.AddItem(gofeedx.NewItem("E1").WithID("1").WithCreated(created).
+		WithEnclosure("https://example.com/e1.mp3", 1, "audio/mpeg").WithDescription("e").
+		WithDurationSeconds(61).
+		WithPSPEpisodeType("trailer"))
+	f, err := b.Build()
+	mustNoErr(t, err, "Build failed")
+
+	data, err := f.MarshalCanonical()
+	mustNoErr(t, err, "MarshalCanonical failed")
+	got, err := gofeedx.UnmarshalCanonical(data)
+	mustNoErr(t, err, "UnmarshalCanonical failed")
+
+	again, err := got.MarshalCanonical()
+	mustNoErr(t, err, "MarshalCanonical of the round-tripped feed failed")
+	if !bytes.Equal(data, again) {
+		t.Fatalf("snapshot is not stable\n got %s\nwant %s", again, data)
+	}
+	if c := got.Items[0].Created; !c.Equal(created) || c.Format(time.RFC3339Nano) != created.Format(time.RFC3339Nano) {
+		t.Errorf("created time must keep instant and offset, got %v", c)
+	}
+	for _, p := range []gofeedx.Profile{gofeedx.ProfileRSS, gofeedx.ProfileAtom, gofeedx.ProfileJSON, gofeedx.ProfilePSP} {
+		want, err := gofeedx.Render(f, p)
+		mustNoErr(t, err, "Render original failed")
+		have, err := gofeedx.Render(got, p)
+		mustNoErr(t, err, "Render round-tripped failed")
+		if have != want {
+			t.Errorf("%s output differs after a snapshot round trip\n got %s\nwant %s", p, have, want)
+		}
+	}
+}
+
+func TestCanonicalSnapshot_Errors(t *testing.T) {
+	var nilFeed *gofeedx.Feed
+	_, err := nilFeed.MarshalCanonical()
+	mustErr(t, err, "expected error for nil feed")
+	_, err = gofeedx.UnmarshalCanonical([]byte(`{"version":2}`))
+	mustErr(t, err, "expected error for unknown version")
+	_, err = gofeedx.UnmarshalCanonical([]byte(`{"version":1,"items":[{"created":"yesterday"}]}`))
+	mustErr(t, err, "expected error for malformed time")
+	_, err = gofeedx.UnmarshalCanonical([]byte(`not json`))
+	mustErr(t, err, "expected error for malformed JSON")
+}
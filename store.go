@@ -0,0 +1,127 @@
+package gofeedx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// FeedStore persists a canonical Feed between process runs, so publishers can add items over
+// time and re-render every format on demand (see RenderFromStore).
+type FeedStore interface {
+	// Load returns the stored feed; an error wrapping fs.ErrNotExist means nothing is stored yet.
+	Load() (*Feed, error)
+	// Save replaces the stored feed.
+	Save(feed *Feed) error
+	// AppendItem adds it to the stored feed, replacing an item with the same ID.
+	AppendItem(it *Item) error
+}
+
+// FileStore is a FeedStore keeping the feed in a single file, in the canonical snapshot format
+// (see MarshalCanonical). Builder options (e.g. WithPSPExplicit) are stored with the feed;
+// enclosure rewriters are not. Save writes a
+// temporary file and renames it over Path, so readers never see a partial feed. A FileStore
+// is safe for concurrent use within one process.
+type FileStore struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+// NewFileStore returns a FileStore for the file at path.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{Path: path}
+}
+
+// Load reads the stored feed.
+func (s *FileStore) Load() (*Feed, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load()
+}
+
+// Save writes feed to the file.
+func (s *FileStore) Save(feed *Feed) error {
+	if feed == nil {
+		return errors.New("store: nil feed")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.save(feed)
+}
+
+// AppendItem loads the feed, adds it (replacing an item with the same ID) and saves the feed.
+func (s *FileStore) AppendItem(it *Item) error {
+	if it == nil {
+		return errors.New("store: nil item")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	feed, err := s.load()
+	if err != nil {
+		return err
+	}
+	if id := strings.TrimSpace(it.ID); id != "" {
+		for i, old := range feed.Items {
+			if old != nil && strings.TrimSpace(old.ID) == id {
+				feed.Items[i] = it
+				return s.save(feed)
+			}
+		}
+	}
+	feed.Items = append(feed.Items, it)
+	return s.save(feed)
+}
+
+func (s *FileStore) load() (*Feed, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, fmt.Errorf("store: %w", err)
+	}
+	feed, err := UnmarshalCanonical(data)
+	if err != nil {
+		return nil, fmt.Errorf("store: %s: %w", s.Path, err)
+	}
+	return feed, nil
+}
+
+func (s *FileStore) save(feed *Feed) error {
+	compact, err := feed.MarshalCanonical()
+	if err != nil {
+		return fmt.Errorf("store: %w", err)
+	}
+	var data bytes.Buffer
+	if err := json.Indent(&data, compact, "", "  "); err != nil {
+		return fmt.Errorf("store: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("store: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // no-op after a successful rename
+	if _, err := tmp.Write(data.Bytes()); err != nil {
+		tmp.Close()
+		return fmt.Errorf("store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.Path); err != nil {
+		return fmt.Errorf("store: %w", err)
+	}
+	return nil
+}
+
+// RenderFromStore loads the stored feed and renders it for profile (see Render).
+func RenderFromStore(s FeedStore, profile Profile) (string, error) {
+	feed, err := s.Load()
+	if err != nil {
+		return "", err
+	}
+	return Render(feed, profile)
+}
@@ -0,0 +1,62 @@
+package gofeedx_test
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestFileStore_RoundTripAndAppend(t *testing.T) {
+	store := gofeedx.NewFileStore(filepath.Join(t.TempDir(), "feed.json"))
+	if _, err := store.Load(); !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("expected fs.ErrNotExist before the first Save, got %v", err)
+	}
+
+	created := time.Date(2024, 5, 1, 8, 0, 0, 0, time.FixedZone("CEST", 2*3600))
+	b := gofeedx.NewFeed("Stored").
+		WithLink("https://example.com/").
+		WithDescription("d").
+		WithLanguage("en").
+		WithFeedURL("https://example.com/podcast.rss").
+		WithImage("https://example.com/artwork.jpg", "", "").
+		WithCategories("Technology").
+		WithAuthor("Host", "host@example.com").
+		WithPSPExplicit(true)
+	b.AddItem(gofeedx.NewItem("E1").WithID("1").WithCreated(created).
+		WithEnclosure("https://example.com/e1.mp3", 1, "audio/mpeg").WithDescription("e").
+		WithPSPEpisodeType("trailer"))
+	f, err := b.Build()
+	mustNoErr(t, err, "Build failed")
+	want, err := gofeedx.ToPSP(f)
+	mustNoErr(t, err, "ToPSP failed")
+
+	mustNoErr(t, store.Save(f), "Save failed")
+	got, err := gofeedx.RenderFromStore(store, gofeedx.ProfilePSP)
+	mustNoErr(t, err, "RenderFromStore failed")
+	if got != want {
+		t.Fatalf("stored feed renders differently\n got %s\nwant %s", got, want)
+	}
+
+	mustNoErr(t, store.AppendItem(&gofeedx.Item{Title: "E2", ID: "2", Created: created.Add(time.Hour),
+		Enclosure: &gofeedx.Enclosure{Url: "https://example.com/e2.mp3", Length: 2, Type: "audio/mpeg"}}), "AppendItem failed")
+	mustNoErr(t, store.AppendItem(&gofeedx.Item{Title: "E1 fixed", ID: "1", Created: created,
+		Enclosure: &gofeedx.Enclosure{Url: "https://example.com/e1.mp3", Length: 1, Type: "audio/mpeg"}}), "AppendItem failed")
+	loaded, err := store.Load()
+	mustNoErr(t, err, "Load failed")
+	if len(loaded.Items) != 2 || loaded.Items[0].Title != "E1 fixed" || loaded.Items[1].ID != "2" {
+		t.Fatalf("unexpected items after AppendItem: %+v", loaded.Items)
+	}
+	if !loaded.Items[0].Created.Equal(created) {
+		t.Errorf("created time changed: %v", loaded.Items[0].Created)
+	}
+	if e := loaded.PSPOptions().Explicit; e == nil || !*e {
+		t.Errorf("builder options must survive a round trip")
+	}
+
+	mustErr(t, store.Save(nil), "expected error for nil feed")
+	mustErr(t, store.AppendItem(nil), "expected error for nil item")
+}
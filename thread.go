@@ -0,0 +1,98 @@
+package gofeedx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// xmlnsThr is the Atom Threading Extensions namespace (RFC 4685).
+const xmlnsThr = "http://purl.org/syndication/thread/1.0"
+
+// threadRepliesMarker carries a replies link configured via WithRepliesLink. RSS and Atom
+// render it as a rel="replies" link with thr:count/thr:updated.
+const threadRepliesMarker = "_xml:replies"
+
+// Item-level helpers:
+
+// WithInReplyTo marks the item as a response to another resource (thr:in-reply-to in RSS and
+// Atom). ref is the id (Atom id / RSS guid) of the parent entry; href optionally links to it.
+// Call it once per parent. An empty ref is reported by Build in strict mode.
+func (b *ItemBuilder) WithInReplyTo(ref, href string) *ItemBuilder {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		b.errs = append(b.errs, fmt.Errorf("thr: in-reply-to ref required"))
+		return b
+	}
+	attrs := map[string]string{"ref": ref}
+	if s := strings.TrimSpace(href); s != "" {
+		attrs["href"] = s
+	}
+	return b.WithExtensions(ExtensionNode{Name: "thr:in-reply-to", Attrs: attrs})
+}
+
+// WithRepliesLink points at the item's replies, e.g. a comment feed, as a rel="replies" link
+// (RFC 4685 section 4). typ is the media type of the replies resource; count (the number of
+// replies, omitted when negative) and updated (omitted when zero) are advisory. A non-absolute
+// href is reported by Build in strict mode.
+func (b *ItemBuilder) WithRepliesLink(href, typ string, count int, updated time.Time) *ItemBuilder {
+	href = strings.TrimSpace(href)
+	if !isAbsoluteURL(href) {
+		b.errs = append(b.errs, fmt.Errorf("thr: replies href %q must be an absolute URL", href))
+		return b
+	}
+	attrs := map[string]string{"href": href}
+	if s := strings.TrimSpace(typ); s != "" {
+		attrs["type"] = s
+	}
+	if count >= 0 {
+		attrs["thr:count"] = strconv.Itoa(count)
+	}
+	if !updated.IsZero() {
+		attrs["thr:updated"] = updated.Format(time.RFC3339)
+	}
+	return b.withOption(ExtensionNode{Name: threadRepliesMarker, Attrs: attrs})
+}
+
+// repliesAtomLink converts a replies marker to an Atom link.
+func repliesAtomLink(n ExtensionNode) (AtomLink, bool) {
+	l := AtomLink{
+		Href:       attrTrim(n.Attrs, "href"),
+		Rel:        "replies",
+		Type:       attrTrim(n.Attrs, "type"),
+		ThrCount:   attrTrim(n.Attrs, "thr:count"),
+		ThrUpdated: attrTrim(n.Attrs, "thr:updated"),
+	}
+	return l, l.Href != ""
+}
+
+// repliesRSSNode converts a replies marker to an atom:link node for RSS items.
+func repliesRSSNode(n ExtensionNode) (ExtensionNode, bool) {
+	l, ok := repliesAtomLink(n)
+	if !ok {
+		return ExtensionNode{}, false
+	}
+	attrs := map[string]string{"href": l.Href, "rel": l.Rel}
+	for k, v := range map[string]string{"type": l.Type, "thr:count": l.ThrCount, "thr:updated": l.ThrUpdated} {
+		if v != "" {
+			attrs[k] = v
+		}
+	}
+	return ExtensionNode{Name: "atom:link", Attrs: attrs}, true
+}
+
+// usesThreadNamespace reports whether any node is a thr:* element or carries thr:* attributes.
+func usesThreadNamespace(exts []ExtensionNode) bool {
+	for _, n := range exts {
+		if strings.HasPrefix(textLowerTrim(n.Name), "thr:") {
+			return true
+		}
+		for k := range n.Attrs {
+			if strings.HasPrefix(strings.ToLower(k), "thr:") {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package gofeedx_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func newThreadFeed() (*gofeedx.Feed, error) {
+	updated := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	b := gofeedx.NewFeed("Comments").
+		WithID("urn:uuid:comments").
+		WithLink("https://example.com/").
+		WithDescription("Comment feed").
+		WithAuthor("Jane", "jane@example.com")
+	b.AddItem(gofeedx.NewItem("Re: Hello").
+		WithID("urn:uuid:reply-1").
+		WithLink("https://example.com/c/1").
+		WithCreated(updated).
+		WithDescription("a reply").
+		WithInReplyTo("urn:uuid:post-1", "https://example.com/p/1").
+		WithRepliesLink("https://example.com/c/1/replies.xml", "application/atom+xml", 3, updated))
+	return b.Build()
+}
+
+func TestThreadAtom(t *testing.T) {
+	f, err := newThreadFeed()
+	mustNoErr(t, err, "Build with threading")
+	xml, err := gofeedx.ToAtom(f)
+	mustNoErr(t, err, "ToAtom failed")
+	mustContain(t, xml, `xmlns:thr="http://purl.org/syndication/thread/1.0"`, "expected thr namespace")
+	mustContain(t, xml, `<thr:in-reply-to href="https://example.com/p/1" ref="urn:uuid:post-1"></thr:in-reply-to>`, "expected thr:in-reply-to")
+	mustContain(t, xml, `<link href="https://example.com/c/1/replies.xml" rel="replies" type="application/atom+xml" thr:count="3" thr:updated="2024-03-01T12:00:00Z"></link>`, "expected replies link")
+	mustNotContain(t, xml, "_xml:replies", "marker must not leak")
+}
+
+func TestThreadRSS(t *testing.T) {
+	f, err := newThreadFeed()
+	mustNoErr(t, err, "Build with threading")
+	xml, err := gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS failed")
+	mustContain(t, xml, `xmlns:thr="http://purl.org/syndication/thread/1.0"`, "expected thr namespace")
+	mustContain(t, xml, `xmlns:atom="http://www.w3.org/2005/Atom"`, "expected atom namespace for replies link")
+	mustContain(t, xml, `<thr:in-reply-to href="https://example.com/p/1" ref="urn:uuid:post-1"></thr:in-reply-to>`, "expected thr:in-reply-to")
+	mustContain(t, xml, `<atom:link href="https://example.com/c/1/replies.xml" rel="replies" thr:count="3" thr:updated="2024-03-01T12:00:00Z" type="application/atom+xml"></atom:link>`, "expected replies atom:link")
+
+	plain := newRSSBaseFeed()
+	plain.Items = append(plain.Items, newRSSBaseItem())
+	xml, err = gofeedx.ToRSS(plain)
+	mustNoErr(t, err, "ToRSS failed")
+	mustNotContain(t, xml, "xmlns:thr", "thr namespace only when used")
+}
+
+func TestThreadInvalidInput(t *testing.T) {
+	_, err := gofeedx.NewItem("x").WithInReplyTo(" ", "").Build()
+	mustErr(t, err, "expected error for empty ref")
+	_, err = gofeedx.NewItem("x").WithRepliesLink("/replies", "", -1, time.Time{}).Build()
+	mustErr(t, err, "expected error for relative replies href")
+}
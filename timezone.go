@@ -0,0 +1,54 @@
+package gofeedx
+
+import "time"
+
+// WithTimeLocation makes Build convert the feed, item and source-feed dates to loc, so
+// pubDate, lastBuildDate, updated and date_published are written in the same zone whatever
+// location the input times carry. The instants are unchanged; nil disables the conversion.
+func (b *FeedBuilder) WithTimeLocation(loc *time.Location) *FeedBuilder {
+	b.timeLocation = loc
+	return b
+}
+
+// WithUTCDates is WithTimeLocation(time.UTC), for byte-identical output across servers.
+func (b *FeedBuilder) WithUTCDates() *FeedBuilder {
+	return b.WithTimeLocation(time.UTC)
+}
+
+// inLocation converts every non-zero date of f and its items to loc in place. Source feeds
+// are copied before conversion.
+func inLocation(f *Feed, loc *time.Location) {
+	conv := func(t *time.Time) {
+		if !t.IsZero() {
+			*t = t.In(loc)
+		}
+	}
+	conv(&f.Created)
+	conv(&f.Updated)
+	for _, it := range f.Items {
+		if it == nil {
+			continue
+		}
+		conv(&it.Created)
+		conv(&it.Updated)
+		if it.SourceFeed != nil {
+			sf := *it.SourceFeed
+			conv(&sf.Updated)
+			it.SourceFeed = &sf
+		}
+	}
+}
+
+// localize returns feed with its dates in enc's TimeLocation and enc's HTMLSanitizer, copying
+// it so the caller's feed is left untouched, or feed itself when neither is set.
+func (enc *Encoder) localize(feed *Feed) *Feed {
+	if feed != nil && enc.opts.HTMLSanitizer != nil {
+		feed = feed.WithHTMLSanitizer(enc.opts.HTMLSanitizer)
+	}
+	if feed == nil || enc.opts.TimeLocation == nil {
+		return feed
+	}
+	c := feed.Clone()
+	inLocation(c, enc.opts.TimeLocation)
+	return c
+}
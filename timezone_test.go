@@ -0,0 +1,48 @@
+package gofeedx_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestEncoder_TimeLocation(t *testing.T) {
+	berlin := time.FixedZone("CET", 3600)
+	f := newRSSBaseFeed()
+	f.Created = time.Date(2024, 3, 5, 15, 30, 0, 0, berlin)
+	f.Items = []*gofeedx.Item{newRSSBaseItem()}
+	f.Items[0].Created = time.Date(2024, 3, 5, 10, 0, 0, 0, berlin)
+
+	enc := gofeedx.NewEncoderWithOptions(gofeedx.EncodeOptions{TimeLocation: time.UTC})
+	var rss, js bytes.Buffer
+	mustNoErr(t, enc.EncodeRSS(f, &rss), "EncodeRSS")
+	mustContain(t, rss.String(), "<pubDate>Tue, 05 Mar 2024 14:30:00 +0000</pubDate>", "expected channel pubDate in UTC")
+	mustContain(t, rss.String(), "<pubDate>Tue, 05 Mar 2024 09:00:00 +0000</pubDate>", "expected item pubDate in UTC")
+	mustNoErr(t, enc.EncodeJSON(f, &js), "EncodeJSON")
+	mustContain(t, js.String(), `"date_published": "2024-03-05T09:00:00Z"`, "expected JSON date in UTC")
+	if f.Created.Location() != berlin {
+		t.Errorf("encoder must not modify the caller's feed")
+	}
+}
+
+func TestBuilder_WithUTCDates(t *testing.T) {
+	berlin := time.FixedZone("CET", 3600)
+	b := gofeedx.NewFeed("Zones").WithLink("https://example.org/").WithDescription("d").
+		WithCreated(time.Date(2024, 3, 5, 15, 30, 0, 0, berlin)).
+		WithUTCDates()
+	ib := gofeedx.NewItem("one").WithCreated(time.Date(2024, 3, 5, 10, 0, 0, 0, berlin))
+	b.AddItem(ib)
+	f, err := b.Build()
+	mustNoErr(t, err, "Build")
+	if f.Created.Location() != time.UTC || f.Items[0].Created.Location() != time.UTC {
+		t.Fatalf("expected UTC dates, got %v and %v", f.Created, f.Items[0].Created)
+	}
+	out, err := gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS")
+	mustContain(t, out, "<pubDate>Tue, 05 Mar 2024 14:30:00 +0000</pubDate>", "expected UTC pubDate")
+	if it, _ := ib.Build(); it.Created.Location() != berlin {
+		t.Errorf("conversion leaked into the ItemBuilder: %v", it.Created)
+	}
+}
@@ -0,0 +1,225 @@
+// Package transcripts describes podcast:transcript files and converts a simple cue model to
+// and from SRT and WebVTT, for publishers that host transcripts alongside their feeds.
+package transcripts
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+// Transcript MIME types recognized by the podcast namespace.
+const (
+	TypeVTT  = "text/vtt"
+	TypeSRT  = "application/srt"
+	TypeJSON = "application/json"
+	TypeHTML = "text/html"
+)
+
+// ErrUnknownType is returned by Validate for a Format that is not a transcript MIME type.
+var ErrUnknownType = errors.New("transcripts: unknown transcript type")
+
+// Transcript is one hosted transcript file of an episode.
+type Transcript struct {
+	Format   string // MIME type, one of the Type constants
+	URL      string // absolute http(s) URL
+	Language string // optional language tag, e.g. "en"
+	Rel      string // optional; "captions" marks timed captions
+}
+
+// KnownType reports whether typ is a transcript MIME type (parameters such as charset are ignored).
+func KnownType(typ string) bool {
+	typ, _, _ = strings.Cut(typ, ";")
+	switch strings.ToLower(strings.TrimSpace(typ)) {
+	case TypeVTT, TypeSRT, TypeJSON, TypeHTML:
+		return true
+	}
+	return false
+}
+
+// Validate checks that t has an absolute http(s) URL and a known Format.
+func (t Transcript) Validate() error {
+	u, err := url.Parse(strings.TrimSpace(t.URL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("transcripts: url %q must be an absolute http(s) URL", t.URL)
+	}
+	if !KnownType(t.Format) {
+		return fmt.Errorf("%w %q", ErrUnknownType, t.Format)
+	}
+	return nil
+}
+
+// Apply adds t to b as a podcast:transcript element; it leaves b unchanged when t is invalid.
+func (t Transcript) Apply(b *gofeedx.ItemBuilder) *gofeedx.ItemBuilder {
+	if t.Validate() != nil {
+		return b
+	}
+	return b.WithPSPTranscript(t.URL, t.Format, t.Language, t.Rel)
+}
+
+// Cue is one timed segment of a transcript.
+type Cue struct {
+	Start   time.Duration
+	End     time.Duration
+	Speaker string // optional; written as a WebVTT voice tag, ignored by SRT
+	Text    string // may span several lines
+}
+
+// FormatSRT renders cues as a SubRip file.
+func FormatSRT(cues []Cue) string {
+	var sb strings.Builder
+	for i, c := range cues {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "%d\n%s --> %s\n%s\n", i+1, timestamp(c.Start, ','), timestamp(c.End, ','), strings.TrimSpace(c.Text))
+	}
+	return sb.String()
+}
+
+// FormatVTT renders cues as a WebVTT file.
+func FormatVTT(cues []Cue) string {
+	var sb strings.Builder
+	sb.WriteString("WEBVTT\n")
+	for _, c := range cues {
+		text := strings.TrimSpace(c.Text)
+		if s := strings.TrimSpace(c.Speaker); s != "" {
+			text = "<v " + s + ">" + text
+		}
+		fmt.Fprintf(&sb, "\n%s --> %s\n%s\n", timestamp(c.Start, '.'), timestamp(c.End, '.'), text)
+	}
+	return sb.String()
+}
+
+// ParseSRT reads a SubRip file; cue numbers are optional.
+func ParseSRT(s string) ([]Cue, error) {
+	var out []Cue
+	for _, block := range blocks(s) {
+		if _, err := strconv.Atoi(block[0]); err == nil && len(block) > 1 {
+			block = block[1:]
+		}
+		c, err := parseCue(block)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+// ParseVTT reads a WebVTT file, skipping NOTE, STYLE and REGION blocks and cue settings. A
+// leading voice tag ("<v Name>") becomes the cue's Speaker.
+func ParseVTT(s string) ([]Cue, error) {
+	bs := blocks(s)
+	if len(bs) == 0 || !strings.HasPrefix(bs[0][0], "WEBVTT") {
+		return nil, errors.New("transcripts: missing WEBVTT header")
+	}
+	var out []Cue
+	for _, block := range bs[1:] {
+		switch strings.SplitN(block[0], " ", 2)[0] {
+		case "NOTE", "STYLE", "REGION":
+			continue
+		}
+		if !strings.Contains(block[0], "-->") && len(block) > 1 {
+			block = block[1:] // cue identifier
+		}
+		c, err := parseCue(block)
+		if err != nil {
+			return nil, err
+		}
+		if rest, ok := strings.CutPrefix(c.Text, "<v "); ok {
+			if name, text, ok := strings.Cut(rest, ">"); ok {
+				c.Speaker = strings.TrimSpace(name)
+				c.Text = strings.TrimSpace(strings.TrimSuffix(text, "</v>"))
+			}
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+// blocks splits s into blank-line separated blocks of trimmed, non-empty lines.
+func blocks(s string) [][]string {
+	s = strings.TrimPrefix(strings.ReplaceAll(s, "\r\n", "\n"), "\ufeff")
+	var out [][]string
+	var cur []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line == "" {
+			if len(cur) > 0 {
+				out = append(out, cur)
+				cur = nil
+			}
+			continue
+		}
+		cur = append(cur, line)
+	}
+	if len(cur) > 0 {
+		out = append(out, cur)
+	}
+	return out
+}
+
+// parseCue reads a timing line followed by the cue text.
+func parseCue(block []string) (Cue, error) {
+	start, end, ok := strings.Cut(block[0], "-->")
+	if !ok {
+		return Cue{}, fmt.Errorf("transcripts: expected timing line, got %q", block[0])
+	}
+	if f := strings.Fields(end); len(f) > 0 {
+		end = f[0] // drop WebVTT cue settings
+	}
+	var c Cue
+	var err error
+	if c.Start, err = parseTimestamp(start); err != nil {
+		return Cue{}, err
+	}
+	if c.End, err = parseTimestamp(end); err != nil {
+		return Cue{}, err
+	}
+	if c.End < c.Start {
+		return Cue{}, fmt.Errorf("transcripts: cue ends before it starts: %q", block[0])
+	}
+	c.Text = strings.Join(block[1:], "\n")
+	return c, nil
+}
+
+// timestamp formats d as HH:MM:SS followed by sep and milliseconds.
+func timestamp(d time.Duration, sep byte) string {
+	if d < 0 {
+		d = 0
+	}
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d%c%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
+
+// parseTimestamp reads [HH:]MM:SS(,|.)mmm.
+func parseTimestamp(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	bad := fmt.Errorf("transcripts: invalid timestamp %q", s)
+	clock, frac, ok := strings.Cut(strings.Replace(s, ",", ".", 1), ".")
+	if !ok || len(frac) != 3 {
+		return 0, bad
+	}
+	parts := strings.Split(clock, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, bad
+	}
+	var total time.Duration
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || (i > 0 && n > 59) {
+			return 0, bad
+		}
+		total = total*60 + time.Duration(n)*time.Second
+	}
+	ms, err := strconv.Atoi(frac)
+	if err != nil {
+		return 0, bad
+	}
+	return total + time.Duration(ms)*time.Millisecond, nil
+}
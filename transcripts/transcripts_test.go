@@ -0,0 +1,86 @@
+package transcripts_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+	"github.com/jo-hoe/gofeedx/transcripts"
+)
+
+var sampleCues = []transcripts.Cue{
+	{Start: 0, End: 2500 * time.Millisecond, Speaker: "Alice", Text: "Welcome to the show."},
+	{Start: 3 * time.Second, End: time.Hour + 2*time.Minute + 3*time.Second + 4*time.Millisecond, Text: "Line one\nLine two"},
+}
+
+func TestFormatAndParseSRT(t *testing.T) {
+	srt := transcripts.FormatSRT(sampleCues)
+	want := "1\n00:00:00,000 --> 00:00:02,500\nWelcome to the show.\n\n2\n00:00:03,000 --> 01:02:03,004\nLine one\nLine two\n"
+	if srt != want {
+		t.Fatalf("FormatSRT:\n%q\nwant\n%q", srt, want)
+	}
+	cues, err := transcripts.ParseSRT(strings.ReplaceAll(srt, "\n", "\r\n"))
+	if err != nil {
+		t.Fatalf("ParseSRT: %v", err)
+	}
+	expected := append([]transcripts.Cue(nil), sampleCues...)
+	expected[0].Speaker = "" // SRT has no speakers
+	if !reflect.DeepEqual(cues, expected) {
+		t.Errorf("ParseSRT = %+v, want %+v", cues, expected)
+	}
+}
+
+func TestFormatAndParseVTT(t *testing.T) {
+	vtt := transcripts.FormatVTT(sampleCues)
+	if !strings.HasPrefix(vtt, "WEBVTT\n\n00:00:00.000 --> 00:00:02.500\n<v Alice>Welcome to the show.\n") {
+		t.Fatalf("unexpected VTT:\n%s", vtt)
+	}
+	cues, err := transcripts.ParseVTT(vtt)
+	if err != nil {
+		t.Fatalf("ParseVTT: %v", err)
+	}
+	if !reflect.DeepEqual(cues, sampleCues) {
+		t.Errorf("ParseVTT = %+v, want %+v", cues, sampleCues)
+	}
+
+	cues, err = transcripts.ParseVTT("WEBVTT - podcast\n\nNOTE recorded live\n\nintro\n00:01.000 --> 00:02.000 align:start\n<v Bob>Hi</v>\n")
+	if err != nil {
+		t.Fatalf("ParseVTT: %v", err)
+	}
+	if len(cues) != 1 || cues[0].Speaker != "Bob" || cues[0].Text != "Hi" || cues[0].Start != time.Second {
+		t.Errorf("unexpected cues %+v", cues)
+	}
+
+	for _, bad := range []string{"00:00:01.000 --> 00:00:02.000\nno header", "WEBVTT\n\n00:00:02.000 --> 00:00:01.000\nbackwards", "WEBVTT\n\n1:2 --> 3:4\nx"} {
+		if _, err := transcripts.ParseVTT(bad); err == nil {
+			t.Errorf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestTranscript_ValidateAndApply(t *testing.T) {
+	ok := transcripts.Transcript{Format: transcripts.TypeVTT, URL: "https://example.org/e1.vtt", Language: "en", Rel: "captions"}
+	if err := ok.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if !transcripts.KnownType("application/json; charset=utf-8") || transcripts.KnownType("text/plain") {
+		t.Errorf("unexpected KnownType results")
+	}
+	if err := (transcripts.Transcript{Format: "text/plain", URL: ok.URL}).Validate(); !errors.Is(err, transcripts.ErrUnknownType) {
+		t.Errorf("expected ErrUnknownType, got %v", err)
+	}
+	if err := (transcripts.Transcript{Format: transcripts.TypeSRT, URL: "/e1.srt"}).Validate(); err == nil {
+		t.Errorf("expected relative url to fail")
+	}
+
+	it, err := ok.Apply(gofeedx.NewItem("Episode")).Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if len(it.Extensions) != 1 || it.Extensions[0].Name != "podcast:transcript" || it.Extensions[0].Attrs["rel"] != "captions" {
+		t.Errorf("expected podcast:transcript extension, got %+v", it.Extensions)
+	}
+}
@@ -3,12 +3,82 @@ package gofeedx
 import (
 	"crypto/rand"
 	"crypto/sha1"
+	"encoding/hex"
 	"fmt"
+	"strings"
 )
 
-// UUID is a 16-byte RFC 4122 universally unique identifier.
+// UUID is a 16-byte RFC 4122 universally unique identifier, e.g. for item GUIDs
+// ("urn:uuid:" + u.String()).
 type UUID [16]byte
 
+// RFC 4122 appendix C namespaces for NewUUIDv5.
+var (
+	NamespaceDNS = UUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+	NamespaceURL = UUID{0x6b, 0xa7, 0xb8, 0x11, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+)
+
+// ParseUUID parses the canonical 8-4-4-4-12 hex form, optionally wrapped in braces or
+// prefixed with "urn:uuid:", as well as the 32-digit form without hyphens. Hex digits may
+// be upper or lower case.
+func ParseUUID(s string) (UUID, error) {
+	in := s
+	s = strings.TrimSpace(s)
+	if len(s) > 9 && strings.EqualFold(s[:9], "urn:uuid:") {
+		s = s[9:]
+	} else if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+		s = s[1 : len(s)-1]
+	}
+	if len(s) == 36 {
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return UUID{}, fmt.Errorf("uuid: invalid format %q", in)
+		}
+		s = s[:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	}
+	var u UUID
+	if len(s) != 32 {
+		return UUID{}, fmt.Errorf("uuid: invalid length %q", in)
+	}
+	if _, err := hex.Decode(u[:], []byte(s)); err != nil {
+		return UUID{}, fmt.Errorf("uuid: invalid hex in %q", in)
+	}
+	return u, nil
+}
+
+// MustParseUUID is like ParseUUID but panics on invalid input; use it for constants.
+func MustParseUUID(s string) UUID {
+	u, err := ParseUUID(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// Version returns the UUID version (4 for random, 5 for name-based).
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// IsZero reports whether u is the nil UUID.
+func (u UUID) IsZero() bool {
+	return u == UUID{}
+}
+
+// MarshalText implements encoding.TextMarshaler using the canonical string form.
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler; it accepts the forms ParseUUID accepts.
+func (u *UUID) UnmarshalText(b []byte) error {
+	v, err := ParseUUID(string(b))
+	if err != nil {
+		return err
+	}
+	*u = v
+	return nil
+}
+
 // String returns the canonical RFC 4122 string form: 8-4-4-4-12 lowercase hex.
 func (u UUID) String() string {
 	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x",
@@ -33,9 +103,9 @@ func NewUUIDv4() (UUID, error) {
 	return u, nil
 }
 
-// UUIDv5 generates a name-based (version 5) UUID using SHA-1 over the namespace
-// UUID and name per RFC 4122 section 4.3.
-func UUIDv5(namespace UUID, name []byte) UUID {
+// NewUUIDv5 generates a name-based (version 5) UUID using SHA-1 over the namespace
+// UUID and name per RFC 4122 section 4.3. The same namespace and name always give the same UUID.
+func NewUUIDv5(namespace UUID, name []byte) UUID {
 	h := sha1.New()
 	_, _ = h.Write(namespace[:])
 	_, _ = h.Write(name)
@@ -49,6 +119,13 @@ func UUIDv5(namespace UUID, name []byte) UUID {
 	return u
 }
 
+// UUIDv5 is the former name of NewUUIDv5.
+//
+// Deprecated: use NewUUIDv5.
+func UUIDv5(namespace UUID, name []byte) UUID {
+	return NewUUIDv5(namespace, name)
+}
+
 // MustUUIDv4 is a helper that panics if NewUUIDv4 fails (should not happen).
 func MustUUIDv4() UUID {
 	u, err := NewUUIDv4()
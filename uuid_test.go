@@ -28,10 +28,10 @@ func TestUUIDv4_StringFormatAndBits(t *testing.T) {
 func TestUUIDv5_DeterministicAndBits(t *testing.T) {
 	ns := PodcastNamespaceUUID
 	name := []byte("example.com/podcast.rss")
-	u1 := UUIDv5(ns, name)
-	u2 := UUIDv5(ns, name)
+	u1 := NewUUIDv5(ns, name)
+	u2 := NewUUIDv5(ns, name)
 	if u1 != u2 {
-		t.Errorf("UUIDv5 should be deterministic for same namespace+name")
+		t.Errorf("NewUUIDv5 should be deterministic for same namespace+name")
 	}
 	if (u1[6]>>4)&0x0f != 5 {
 		t.Errorf("expected version 5, got byte %02x", u1[6])
@@ -50,3 +50,44 @@ func TestMustUUIDv4_StringFormat(t *testing.T) {
 		t.Errorf("MustUUIDv4 string not in canonical v4 form: %q", s)
 	}
 }
+
+func TestNewUUIDv5_KnownVectors(t *testing.T) {
+	if got := NewUUIDv5(NamespaceDNS, []byte("www.example.com")).String(); got != "2ed6657d-e927-568b-95e1-2665a8aea6a2" {
+		t.Errorf("DNS vector: got %s", got)
+	}
+	if got := NewUUIDv5(NamespaceURL, []byte("https://example.com/")).String(); got != "dd2c1780-811a-5296-81c5-178a0ef488bc" {
+		t.Errorf("URL vector: got %s", got)
+	}
+}
+
+func TestParseUUID(t *testing.T) {
+	want := "2ed6657d-e927-568b-95e1-2665a8aea6a2"
+	for _, in := range []string{
+		want,
+		"2ED6657D-E927-568B-95E1-2665A8AEA6A2",
+		"{" + want + "}",
+		"urn:uuid:" + want,
+		"2ed6657de927568b95e12665a8aea6a2",
+	} {
+		u, err := ParseUUID(in)
+		if err != nil {
+			t.Fatalf("ParseUUID(%q): %v", in, err)
+		}
+		if u.String() != want || u.Version() != 5 {
+			t.Errorf("ParseUUID(%q) = %s (v%d)", in, u, u.Version())
+		}
+	}
+	for _, in := range []string{"", "2ed6657d-e927-568b-95e1", "2ed6657d+e927-568b-95e1-2665a8aea6a2", "zed6657d-e927-568b-95e1-2665a8aea6a2"} {
+		if _, err := ParseUUID(in); err == nil {
+			t.Errorf("ParseUUID(%q) expected error", in)
+		}
+	}
+
+	var u UUID
+	if err := u.UnmarshalText([]byte(want)); err != nil || u.IsZero() {
+		t.Fatalf("UnmarshalText: %v", err)
+	}
+	if b, _ := u.MarshalText(); string(b) != want {
+		t.Errorf("MarshalText = %s", b)
+	}
+}
@@ -0,0 +1,100 @@
+package gofeedx
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Variant describes a derived feed rendered from the same builder, e.g. a public "preview"
+// feed and a "premium" feed with full episodes.
+type Variant struct {
+	// FeedURL replaces Feed.FeedURL when set.
+	FeedURL string
+	// Filter keeps only the items for which it returns true; nil keeps all items.
+	Filter func(*Item) bool
+	// Modify makes final, possibly profile-specific, edits to the variant's copy of the feed.
+	Modify func(f *Feed, profile Profile)
+}
+
+// WithVariant registers (or replaces) the named variant; see BuildVariant and RenderVariant.
+func (b *FeedBuilder) WithVariant(name string, v Variant) *FeedBuilder {
+	if b.variants == nil {
+		b.variants = map[string]Variant{}
+	}
+	b.variants[name] = v
+	return b
+}
+
+// BuildVariant builds the feed, applies the named variant to a deep copy and validates the
+// result for profile. The base feed is not modified by the variant. Use BuildVariants to
+// derive several variants from a single Build.
+func (b *FeedBuilder) BuildVariant(name string, profile Profile) (*Feed, error) {
+	v, ok := b.variants[name]
+	if !ok {
+		return nil, fmt.Errorf("variant %q not defined", name)
+	}
+	base, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	return applyVariant(base, name, v, profile)
+}
+
+// BuildVariants builds the feed once and derives every registered variant from its own deep
+// copy, validated for profile, keyed by variant name.
+func (b *FeedBuilder) BuildVariants(profile Profile) (map[string]*Feed, error) {
+	base, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(b.variants))
+	for name := range b.variants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	out := make(map[string]*Feed, len(names))
+	for _, name := range names {
+		f, err := applyVariant(base, name, b.variants[name], profile)
+		if err != nil {
+			return nil, err
+		}
+		out[name] = f
+	}
+	return out, nil
+}
+
+// applyVariant applies v to a deep copy of base and validates the result for profile.
+func applyVariant(base *Feed, name string, v Variant, profile Profile) (*Feed, error) {
+	f := base.Clone()
+	if v.FeedURL != "" {
+		f.FeedURL = v.FeedURL
+	}
+	if v.Filter != nil {
+		kept := f.Items[:0]
+		for _, it := range f.Items {
+			if v.Filter(it) {
+				kept = append(kept, it)
+			}
+		}
+		f.Items = kept
+	}
+	if v.Modify != nil {
+		v.Modify(f, profile)
+	}
+	if containsAnyProfile([]Profile{profile}, ProfileAtom, ProfileJSON, ProfilePSP) {
+		ensureItemIDs(f.Items)
+	}
+	if err := runProfileValidations(f, []Profile{profile}); err != nil {
+		return nil, fmt.Errorf("variant %q: %w", name, err)
+	}
+	return f, nil
+}
+
+// RenderVariant builds the named variant for profile and renders it (see BuildVariant and Render).
+func (b *FeedBuilder) RenderVariant(name string, profile Profile) (string, error) {
+	f, err := b.BuildVariant(name, profile)
+	if err != nil {
+		return "", err
+	}
+	return Render(f, profile)
+}
@@ -0,0 +1,77 @@
+package gofeedx_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func newVariantBuilder() *gofeedx.FeedBuilder {
+	b := gofeedx.NewFeed("Show").
+		WithLink("https://example.com/").
+		WithDescription("d").
+		WithFeedURL("https://example.com/feed.json").
+		WithVariant("preview", gofeedx.Variant{
+			FeedURL: "https://example.com/preview.json",
+			Filter:  func(it *gofeedx.Item) bool { return !strings.HasPrefix(it.Title, "Premium") },
+		}).
+		WithVariant("premium", gofeedx.Variant{
+			FeedURL: "https://example.com/premium.json",
+			Modify: func(f *gofeedx.Feed, p gofeedx.Profile) {
+				f.Title += " (Members)"
+				if p == gofeedx.ProfileRSS {
+					f.Description = "members only"
+				}
+			},
+		})
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	b.AddItem(gofeedx.NewItem("Free episode").WithID("1").WithCreated(created))
+	b.AddItem(gofeedx.NewItem("Premium episode").WithID("2").WithCreated(created))
+	return b
+}
+
+func TestRenderVariant(t *testing.T) {
+	b := newVariantBuilder()
+
+	preview, err := b.RenderVariant("preview", gofeedx.ProfileJSON)
+	mustNoErr(t, err, "RenderVariant preview")
+	mustContain(t, preview, `"feed_url": "https://example.com/preview.json"`, "expected preview feed URL")
+	mustContain(t, preview, "Free episode", "expected free item in preview")
+	mustNotContain(t, preview, "Premium episode", "premium item must be filtered from preview")
+
+	premium, err := b.RenderVariant("premium", gofeedx.ProfileRSS)
+	mustNoErr(t, err, "RenderVariant premium")
+	mustContain(t, premium, "Show (Members)", "expected modified title")
+	mustContain(t, premium, "members only", "expected RSS-specific modification")
+	mustContain(t, premium, "Premium episode", "expected all items in premium")
+
+	base, err := b.Build()
+	mustNoErr(t, err, "Build")
+	if base.Title != "Show" || base.FeedURL != "https://example.com/feed.json" || len(base.Items) != 2 {
+		t.Errorf("variants must not modify the base feed: %+v", base)
+	}
+
+	_, err = b.RenderVariant("missing", gofeedx.ProfileRSS)
+	mustErr(t, err, "expected error for unknown variant")
+}
+
+func TestBuildVariants(t *testing.T) {
+	builds := 0
+	b := newVariantBuilder().WithFilter(func(*gofeedx.Item) bool {
+		builds++
+		return true
+	})
+	variants, err := b.BuildVariants(gofeedx.ProfileRSS)
+	mustNoErr(t, err, "BuildVariants")
+	if builds != 2 {
+		t.Errorf("expected a single Build over 2 items, filter ran %d times", builds)
+	}
+	if len(variants) != 2 || len(variants["preview"].Items) != 1 || len(variants["premium"].Items) != 2 {
+		t.Fatalf("unexpected variants: %+v", variants)
+	}
+	if variants["premium"].Title != "Show (Members)" || variants["preview"].Title != "Show" {
+		t.Errorf("variants must be derived from separate copies: %q, %q", variants["premium"].Title, variants["preview"].Title)
+	}
+}
@@ -0,0 +1,88 @@
+package gofeedx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// websubHubMarker carries a WebSub hub URL configured via WithHub.
+const websubHubMarker = "_websub:hub"
+
+// WithHub declares a WebSub (formerly PubSubHubbub) hub for the feed. It is advertised as
+// <atom:link rel="hub"> in RSS, Atom and PSP output (together with rel="self" from FeedURL
+// where the format has no self link yet) and as a "WebSub" entry in JSON Feed hubs.
+// Call it once per hub; non-absolute URLs are reported by Build in strict mode.
+func (b *FeedBuilder) WithHub(hubURL string) *FeedBuilder {
+	hubURL = strings.TrimSpace(hubURL)
+	if !isAbsoluteURL(hubURL) {
+		b.errs = append(b.errs, fmt.Errorf("websub: hub url %q must be absolute", hubURL))
+		return b
+	}
+	return b.withOption(ExtensionNode{Name: websubHubMarker, Text: hubURL})
+}
+
+// hubURLs returns the hub URLs configured on exts, in order.
+func hubURLs(exts []ExtensionNode) []string {
+	var out []string
+	for _, n := range exts {
+		if strings.EqualFold(strings.TrimSpace(n.Name), websubHubMarker) {
+			if s := strings.TrimSpace(n.Text); s != "" {
+				out = append(out, s)
+			}
+		}
+	}
+	return out
+}
+
+// websubLinkNodes returns atom:link extension nodes for the configured hubs. When selfURL is
+// set and at least one hub exists, a rel="self" link is added as WebSub discovery requires.
+func websubLinkNodes(exts []ExtensionNode, selfURL string) []ExtensionNode {
+	hubs := hubURLs(exts)
+	if len(hubs) == 0 {
+		return nil
+	}
+	var out []ExtensionNode
+	for _, h := range hubs {
+		out = append(out, ExtensionNode{Name: "atom:link", Attrs: map[string]string{"href": h, "rel": "hub"}})
+	}
+	if s := strings.TrimSpace(selfURL); s != "" {
+		out = append(out, ExtensionNode{Name: "atom:link", Attrs: map[string]string{"href": s, "rel": "self"}})
+	}
+	return out
+}
+
+// PublishPing notifies a WebSub hub that the feed at feedURL has changed
+// (hub.mode=publish), so the hub can fetch it and push the update to subscribers.
+// Any 2xx response counts as success. A nil client uses http.DefaultClient.
+func PublishPing(ctx context.Context, client *http.Client, hubURL, feedURL string) error {
+	if !isAbsoluteURL(hubURL) {
+		return fmt.Errorf("websub: hub url %q must be absolute", hubURL)
+	}
+	if !isAbsoluteURL(feedURL) {
+		return fmt.Errorf("websub: feed url %q must be absolute", feedURL)
+	}
+	form := url.Values{"hub.mode": {"publish"}, "hub.url": {strings.TrimSpace(feedURL)}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSpace(hubURL), strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("websub: publish ping: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.New("websub: hub responded " + resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package gofeedx_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+func TestWithHub_AdvertisedInAllFormats(t *testing.T) {
+	f, err := gofeedx.NewFeed("Hubbed").
+		WithLink("https://example.org/").
+		WithDescription("D").
+		WithAuthor("A", "a@example.org").
+		WithFeedURL("https://example.org/feed.xml").
+		WithHub("https://hub.example.com/").
+		AddItem(gofeedx.NewItem("I").WithID("i1").WithCreated(time.Now().UTC())).
+		Build()
+	mustNoErr(t, err, "Build failed")
+
+	rss, err := gofeedx.ToRSS(f)
+	mustNoErr(t, err, "ToRSS failed")
+	mustContain(t, rss, `xmlns:atom="http://www.w3.org/2005/Atom"`, "expected atom namespace on rss root")
+	mustContain(t, rss, `<atom:link href="https://hub.example.com/" rel="hub"></atom:link>`, "expected hub link in RSS")
+	mustContain(t, rss, `<atom:link href="https://example.org/feed.xml" rel="self"></atom:link>`, "expected self link in RSS")
+
+	atom, err := gofeedx.ToAtom(f)
+	mustNoErr(t, err, "ToAtom failed")
+	mustContain(t, atom, `href="https://hub.example.com/" rel="hub"`, "expected hub link in Atom")
+	mustContain(t, atom, `href="https://example.org/feed.xml" rel="self"`, "expected self link in Atom")
+
+	psp, err := gofeedx.ToPSP(f)
+	mustNoErr(t, err, "ToPSP failed")
+	mustContain(t, psp, `<atom:link href="https://hub.example.com/" rel="hub"></atom:link>`, "expected hub link in PSP")
+
+	js, err := gofeedx.ToJSON(f)
+	mustNoErr(t, err, "ToJSON failed")
+	mustContain(t, js, `"type": "WebSub"`, "expected WebSub hub in JSON Feed")
+	mustContain(t, js, `"url": "https://hub.example.com/"`, "expected hub url in JSON Feed")
+}
+
+func TestWithHub_RejectsRelativeURLInStrictMode(t *testing.T) {
+	_, err := gofeedx.NewFeed("Hubbed").WithHub("/hub").Build()
+	mustErr(t, err, "expected error for relative hub url")
+}
+
+func TestPublishPing(t *testing.T) {
+	var gotMode, gotURL, gotCT string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotCT = r.Header.Get("Content-Type")
+		_ = r.ParseForm()
+		gotMode, gotURL = r.PostForm.Get("hub.mode"), r.PostForm.Get("hub.url")
+		if gotURL == "https://example.org/broken.xml" {
+			http.Error(w, "nope", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	err := gofeedx.PublishPing(context.Background(), srv.Client(), srv.URL, "https://example.org/feed.xml")
+	mustNoErr(t, err, "PublishPing failed")
+	if gotMode != "publish" || gotURL != "https://example.org/feed.xml" || gotCT != "application/x-www-form-urlencoded" {
+		t.Errorf("unexpected ping: mode=%q url=%q content-type=%q", gotMode, gotURL, gotCT)
+	}
+
+	err = gofeedx.PublishPing(context.Background(), nil, srv.URL, "https://example.org/broken.xml")
+	mustErr(t, err, "expected error for non-2xx hub response")
+	err = gofeedx.PublishPing(context.Background(), srv.Client(), srv.URL, "feed.xml")
+	mustErr(t, err, "expected error for relative feed url")
+}
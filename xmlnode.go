@@ -2,6 +2,7 @@ package gofeedx
 
 import (
 	"encoding/xml"
+	"fmt"
 	"sort"
 	"strings"
 )
@@ -65,7 +66,7 @@ func (n ExtensionNode) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
 
 	// Write children
 	for _, c := range n.Children {
-		if err := e.Encode(c); err != nil {
+		if err := c.MarshalXML(e, xml.StartElement{}); err != nil {
 			return err
 		}
 	}
@@ -74,7 +75,7 @@ func (n ExtensionNode) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
 	if err := e.EncodeToken(start.End()); err != nil {
 		return err
 	}
-	return e.Flush()
+	return nil
 }
 
 // encodeElementIfSet encodes an element <name>value</name> when value is non-empty (after trimming).
@@ -99,10 +100,54 @@ func encodeIntElementIfPositive(e *xml.Encoder, name string, n int) error {
 //   - _xml:...   (shared XML controls like CDATA preferences)
 //   - _rss:...   (RSS-specific helpers/control)
 //   - _atom:...  (Atom-specific helpers/control)
+//   - _psp:...   (PSP-specific helpers/control)
 func IsInternalExtensionName(name string) bool {
 	s := strings.ToLower(strings.TrimSpace(name))
 	return strings.HasPrefix(s, "_json:") ||
 		strings.HasPrefix(s, "_xml:") ||
 		strings.HasPrefix(s, "_rss:") ||
-		strings.HasPrefix(s, "_atom:")
+		strings.HasPrefix(s, "_atom:") ||
+		strings.HasPrefix(s, "_psp:") ||
+		strings.HasPrefix(s, "_websub:")
+}
+
+// booleanExtensionNames lists the extension node names whose text is parsed with ParseFeedBool.
+var booleanExtensionNames = map[string]bool{
+	"itunes:explicit": true,
+	"itunes:complete": true,
+	"itunes:block":    true,
+	"podcast:locked":  true,
+	"_json:expired":   true,
+	"_xml:cdata":      true,
+}
+
+// ParseFeedBool parses boolean extension text. Matching is case-insensitive and ignores
+// surrounding whitespace. Accepted tokens:
+//   - true:  "true", "yes", "1"
+//   - false: "false", "no", "0"
+//
+// ok is false for any other value, including empty text.
+func ParseFeedBool(s string) (value bool, ok bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "true", "yes", "1":
+		return true, true
+	case "false", "no", "0":
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// validateBooleanExtensions returns an error for the first boolean extension node
+// (see booleanExtensionNames) whose text is not accepted by ParseFeedBool.
+func validateBooleanExtensions(scope string, exts []ExtensionNode) error {
+	for _, n := range exts {
+		if !booleanExtensionNames[strings.ToLower(strings.TrimSpace(n.Name))] {
+			continue
+		}
+		if _, ok := ParseFeedBool(n.Text); !ok {
+			return fmt.Errorf("%s extension %s value %q is not a boolean (accepted: true/false, yes/no, 1/0)", scope, strings.TrimSpace(n.Name), n.Text)
+		}
+	}
+	return nil
 }
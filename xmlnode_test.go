@@ -86,3 +86,46 @@ func TestEncodeElementIfSet_And_EncodeIntElementIfPositive(t *testing.T) {
 		}
 	}
 }
+
+func TestParseFeedBool(t *testing.T) {
+	trueTokens := []string{"true", "TRUE", " Yes ", "yes", "1"}
+	for _, s := range trueTokens {
+		if v, ok := ParseFeedBool(s); !ok || !v {
+			t.Errorf("ParseFeedBool(%q) = %v, %v; want true, true", s, v, ok)
+		}
+	}
+	falseTokens := []string{"false", "False", "NO", "0"}
+	for _, s := range falseTokens {
+		if v, ok := ParseFeedBool(s); !ok || v {
+			t.Errorf("ParseFeedBool(%q) = %v, %v; want false, true", s, v, ok)
+		}
+	}
+	for _, s := range []string{"", "maybe", "2", "y"} {
+		if _, ok := ParseFeedBool(s); ok {
+			t.Errorf("ParseFeedBool(%q) expected ok=false", s)
+		}
+	}
+}
+
+func TestBuilderStrict_RejectsUnrecognizedBooleanExtension(t *testing.T) {
+	_, err := NewFeed("t").
+		WithExtensions(ExtensionNode{Name: "podcast:locked", Text: "perhaps"}).
+		Build()
+	if err == nil || !strings.Contains(err.Error(), "podcast:locked") {
+		t.Fatalf("expected strict boolean extension error, got %v", err)
+	}
+
+	_, err = NewFeed("t").
+		AddItem(NewItem("i").WithExtensions(ExtensionNode{Name: "itunes:explicit", Text: "clean"})).
+		Build()
+	if err == nil || !strings.Contains(err.Error(), "builder: item[0]") {
+		t.Fatalf("expected item boolean extension error, got %v", err)
+	}
+
+	// Lenient mode leaves unrecognized values to the writers
+	if _, err := NewFeed("t").WithLenient().
+		WithExtensions(ExtensionNode{Name: "podcast:locked", Text: "perhaps"}).
+		Build(); err != nil {
+		t.Fatalf("lenient build unexpected error: %v", err)
+	}
+}
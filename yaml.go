@@ -0,0 +1,790 @@
+package gofeedx
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LoadFeedFromYAML reads a feed description in YAML, e.g. kept next to the episode files of a
+// static site, and maps it into the Feed model without third-party dependencies. Keys follow
+// the Feed and Item fields; every key below is optional:
+//
+//	title: My Podcast
+//	link: https://example.com/
+//	feedURL: https://example.com/podcast.xml
+//	description: A show about things.
+//	language: en
+//	id: urn:uuid:60a76c80-d399-11d9-b93C-0003939e0af6
+//	copyright: 2024 Example
+//	created: 2024-01-01T08:00:00Z
+//	updated: 2024-05-01T08:00:00Z
+//	author:                  # or a plain name: "author: Host"
+//	  name: Host
+//	  email: host@example.com
+//	image:                   # or a plain URL: "image: https://..."
+//	  url: https://example.com/artwork.jpg
+//	  title: My Podcast
+//	  link: https://example.com/
+//	images:
+//	  icon: https://example.com/icon.png
+//	  logo: https://example.com/logo.png
+//	  artwork: https://example.com/artwork.jpg
+//	license:                 # or a plain name
+//	  name: CC BY 4.0
+//	  url: https://creativecommons.org/licenses/by/4.0/
+//	generator:
+//	  name: site-build
+//	  version: "1.0"
+//	  url: https://example.com/tools
+//	categories:              # or a flow list of names: [Technology, News]
+//	  - Technology
+//	  - text: News
+//	    scheme: https://example.com/categories
+//	    label: News
+//	extensions:
+//	  - name: podcast:locked
+//	    text: "yes"
+//	    attrs:
+//	      owner: host@example.com
+//	    children:            # nested extensions, same keys
+//	      - name: x:child
+//	items:
+//	  - title: Episode 1
+//	    id: ep-1
+//	    isPermaLink: "false"
+//	    link: https://example.com/ep-1
+//	    source: https://example.org/original
+//	    author: Host
+//	    language: en
+//	    created: 2024-05-01T08:00:00Z
+//	    updated: 2024-05-02T08:00:00Z
+//	    description: |
+//	      Show notes.
+//	    content: <p>Show notes.</p>
+//	    imageURL: https://example.com/ep-1.jpg
+//	    commentsURL: https://example.com/ep-1#comments
+//	    commentsFeedURL: https://example.com/ep-1/comments.xml
+//	    commentCount: 3
+//	    enclosure:
+//	      url: https://example.com/ep-1.mp3
+//	      length: 12345
+//	      type: audio/mpeg
+//	    duration: "00:30:01"  # seconds, MM:SS or HH:MM:SS
+//	    extensions:
+//	      - name: itunes:episode
+//	        text: "1"
+//
+// Times accept everything ParseFeedTime does. Unknown keys are errors, so typos do
+// not go unnoticed.
+// The loader does not validate the feed; build or render it for a profile to do so.
+//
+// Only the YAML subset such descriptions need is supported: block mappings and sequences,
+// plain, single- and double-quoted scalars, literal (|) and folded (>) block scalars, flow
+// sequences of scalars ([a, b]) and comments. Anchors, aliases, tags and flow mappings
+// ({a: b}) are rejected.
+func LoadFeedFromYAML(r io.Reader) (*Feed, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("yaml: %w", err)
+	}
+	root, err := parseYAML(string(data))
+	if err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, errors.New("yaml: empty document")
+	}
+	d := &yamlDecoder{}
+	f := d.feed(root)
+	if d.err != nil {
+		return nil, d.err
+	}
+	return f, nil
+}
+
+// yamlDecoder maps parsed YAML nodes onto the Feed model, keeping the first error.
+type yamlDecoder struct {
+	err error
+}
+
+func (d *yamlDecoder) fail(n *yamlNode, format string, args ...any) {
+	if d.err == nil {
+		d.err = fmt.Errorf("yaml: line %d: %s", n.line, fmt.Sprintf(format, args...))
+	}
+}
+
+func (d *yamlDecoder) feed(n *yamlNode) *Feed {
+	f := &Feed{}
+	d.fields(n, "feed", func(key string, v *yamlNode) {
+		switch key {
+		case "title":
+			f.Title = d.str(v)
+		case "link":
+			f.Link = &Link{Href: d.str(v)}
+		case "description":
+			f.Description = d.str(v)
+		case "author":
+			f.Author = d.author(v)
+		case "updated":
+			f.Updated = d.time(v)
+		case "created":
+			f.Created = d.time(v)
+		case "id":
+			f.ID = d.str(v)
+		case "copyright":
+			f.Copyright = d.str(v)
+		case "license":
+			f.License = &License{}
+			if v.kind == yamlScalar {
+				f.License.Name = v.value
+				return
+			}
+			d.fields(v, "license", func(key string, v *yamlNode) {
+				switch key {
+				case "name":
+					f.License.Name = d.str(v)
+				case "url":
+					f.License.URL = d.str(v)
+				default:
+					d.fail(v, "unknown license key %q", key)
+				}
+			})
+		case "image":
+			f.Image = &Image{}
+			if v.kind == yamlScalar {
+				f.Image.Url = v.value
+				return
+			}
+			d.fields(v, "image", func(key string, v *yamlNode) {
+				switch key {
+				case "url":
+					f.Image.Url = d.str(v)
+				case "title":
+					f.Image.Title = d.str(v)
+				case "link":
+					f.Image.Link = d.str(v)
+				default:
+					d.fail(v, "unknown image key %q", key)
+				}
+			})
+		case "images":
+			f.Images = &Images{}
+			d.fields(v, "images", func(key string, v *yamlNode) {
+				switch key {
+				case "icon":
+					f.Images.Icon = d.str(v)
+				case "logo":
+					f.Images.Logo = d.str(v)
+				case "artwork":
+					f.Images.Artwork = d.str(v)
+				default:
+					d.fail(v, "unknown images key %q", key)
+				}
+			})
+		case "language":
+			f.Language = d.str(v)
+		case "generator":
+			f.Generator = &Generator{}
+			d.fields(v, "generator", func(key string, v *yamlNode) {
+				switch key {
+				case "name":
+					f.Generator.Name = d.str(v)
+				case "version":
+					f.Generator.Version = d.str(v)
+				case "url":
+					f.Generator.URL = d.str(v)
+				default:
+					d.fail(v, "unknown generator key %q", key)
+				}
+			})
+		case "feedURL":
+			f.FeedURL = d.str(v)
+		case "categories":
+			d.list(v, "categories", func(v *yamlNode) {
+				f.Categories = append(f.Categories, d.category(v))
+			})
+		case "extensions":
+			f.Extensions = d.extensions(v)
+		case "items":
+			d.list(v, "items", func(v *yamlNode) {
+				f.Items = append(f.Items, d.item(v))
+			})
+		default:
+			d.fail(v, "unknown feed key %q", key)
+		}
+	})
+	return f
+}
+
+func (d *yamlDecoder) item(n *yamlNode) *Item {
+	it := &Item{}
+	d.fields(n, "item", func(key string, v *yamlNode) {
+		switch key {
+		case "title":
+			it.Title = d.str(v)
+		case "link":
+			it.Link = &Link{Href: d.str(v)}
+		case "source":
+			it.Source = &Link{Href: d.str(v)}
+		case "author":
+			it.Author = d.author(v)
+		case "description":
+			it.Description = d.str(v)
+		case "id":
+			it.ID = d.str(v)
+		case "isPermaLink":
+			it.IsPermaLink = d.str(v)
+		case "updated":
+			it.Updated = d.time(v)
+		case "created":
+			it.Created = d.time(v)
+		case "enclosure":
+			it.Enclosure = &Enclosure{}
+			d.fields(v, "enclosure", func(key string, v *yamlNode) {
+				switch key {
+				case "url":
+					it.Enclosure.Url = d.str(v)
+				case "length":
+					it.Enclosure.Length = int64(d.int(v))
+				case "type":
+					it.Enclosure.Type = d.str(v)
+				default:
+					d.fail(v, "unknown enclosure key %q", key)
+				}
+			})
+		case "content":
+			it.Content = d.str(v)
+		case "language":
+			it.Language = d.str(v)
+		case "imageURL":
+			it.ImageURL = d.str(v)
+		case "commentsURL":
+			it.CommentsURL = d.str(v)
+		case "commentsFeedURL":
+			it.CommentsFeedURL = d.str(v)
+		case "commentCount":
+			n := d.int(v)
+			it.CommentCount = &n
+		case "duration":
+			sec, err := ParseItunesDuration(d.str(v))
+			if err != nil {
+				d.fail(v, "%v", err)
+			}
+			it.DurationSeconds = sec
+		case "extensions":
+			it.Extensions = d.extensions(v)
+		default:
+			d.fail(v, "unknown item key %q", key)
+		}
+	})
+	return it
+}
+
+func (d *yamlDecoder) author(n *yamlNode) *Author {
+	a := &Author{}
+	if n.kind == yamlScalar {
+		a.Name = n.value
+		return a
+	}
+	d.fields(n, "author", func(key string, v *yamlNode) {
+		switch key {
+		case "name":
+			a.Name = d.str(v)
+		case "email":
+			a.Email = d.str(v)
+		default:
+			d.fail(v, "unknown author key %q", key)
+		}
+	})
+	return a
+}
+
+func (d *yamlDecoder) category(n *yamlNode) *Category {
+	c := &Category{}
+	if n.kind == yamlScalar {
+		c.Text = n.value
+		return c
+	}
+	d.fields(n, "category", func(key string, v *yamlNode) {
+		switch key {
+		case "text":
+			c.Text = d.str(v)
+		case "scheme":
+			c.Scheme = d.str(v)
+		case "label":
+			c.Label = d.str(v)
+		default:
+			d.fail(v, "unknown category key %q", key)
+		}
+	})
+	return c
+}
+
+func (d *yamlDecoder) extensions(n *yamlNode) []ExtensionNode {
+	var out []ExtensionNode
+	d.list(n, "extensions", func(v *yamlNode) {
+		var e ExtensionNode
+		d.fields(v, "extension", func(key string, v *yamlNode) {
+			switch key {
+			case "name":
+				e.Name = d.str(v)
+			case "text":
+				e.Text = d.str(v)
+			case "attrs":
+				e.Attrs = map[string]string{}
+				d.fields(v, "attrs", func(key string, v *yamlNode) {
+					e.Attrs[key] = d.str(v)
+				})
+			case "children":
+				e.Children = d.extensions(v)
+			default:
+				d.fail(v, "unknown extension key %q", key)
+			}
+		})
+		if strings.TrimSpace(e.Name) == "" {
+			d.fail(v, "extension without name")
+		}
+		out = append(out, e)
+	})
+	return out
+}
+
+// fields calls fn for each key of mapping n.
+func (d *yamlDecoder) fields(n *yamlNode, what string, fn func(key string, v *yamlNode)) {
+	if n.kind != yamlMapping {
+		d.fail(n, "%s must be a mapping", what)
+		return
+	}
+	for _, p := range n.pairs {
+		fn(p.key, p.value)
+	}
+}
+
+// list calls fn for each entry of sequence n; an empty value is an empty list.
+func (d *yamlDecoder) list(n *yamlNode, what string, fn func(v *yamlNode)) {
+	if n.kind == yamlScalar && n.value == "" {
+		return
+	}
+	if n.kind != yamlSequence {
+		d.fail(n, "%s must be a list", what)
+		return
+	}
+	for _, v := range n.items {
+		fn(v)
+	}
+}
+
+func (d *yamlDecoder) str(n *yamlNode) string {
+	if n.kind != yamlScalar {
+		d.fail(n, "expected a single value")
+		return ""
+	}
+	return n.value
+}
+
+func (d *yamlDecoder) int(n *yamlNode) int {
+	s := d.str(n)
+	if s == "" {
+		return 0
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		d.fail(n, "invalid number %q", s)
+	}
+	return v
+}
+
+func (d *yamlDecoder) time(n *yamlNode) time.Time {
+	s := d.str(n)
+	if s == "" {
+		return time.Time{}
+	}
+	t, err := ParseFeedTime(s)
+	if err != nil {
+		d.fail(n, "%v", err)
+	}
+	return t
+}
+
+type yamlKind int
+
+const (
+	yamlScalar yamlKind = iota
+	yamlMapping
+	yamlSequence
+)
+
+// yamlNode is a parsed YAML value; scalars keep their text, mappings their key order.
+type yamlNode struct {
+	kind  yamlKind
+	line  int
+	value string
+	pairs []yamlPair
+	items []*yamlNode
+}
+
+type yamlPair struct {
+	key   string
+	value *yamlNode
+}
+
+// yamlLine is a source line; text excludes the indentation but is otherwise raw, since
+// block scalars keep comments and spacing.
+type yamlLine struct {
+	num    int
+	indent int
+	text   string
+}
+
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+// parseYAML parses a single-document YAML subset (see LoadFeedFromYAML); nil means empty.
+func parseYAML(src string) (*yamlNode, error) {
+	p := &yamlParser{}
+	for i, raw := range strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n") {
+		text := strings.TrimLeft(raw, " ")
+		if strings.HasPrefix(text, "\t") {
+			return nil, fmt.Errorf("yaml: line %d: tabs are not allowed in indentation", i+1)
+		}
+		p.lines = append(p.lines, yamlLine{num: i + 1, indent: len(raw) - len(text), text: strings.TrimRight(text, " \t")})
+	}
+	p.skipBlank()
+	if p.pos < len(p.lines) && p.lines[p.pos].indent == 0 && p.lines[p.pos].text == "---" {
+		p.pos++
+		p.skipBlank()
+	}
+	if p.pos >= len(p.lines) {
+		return nil, nil
+	}
+	root, err := p.block(p.lines[p.pos].indent)
+	if err != nil {
+		return nil, err
+	}
+	p.skipBlank()
+	if p.pos < len(p.lines) && p.lines[p.pos].indent == 0 && p.lines[p.pos].text == "..." {
+		p.pos++
+		p.skipBlank()
+	}
+	if p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		if isYAMLDocumentMarker(l) {
+			return nil, fmt.Errorf("yaml: line %d: multiple documents are not supported", l.num)
+		}
+		return nil, fmt.Errorf("yaml: line %d: unexpected indentation", l.num)
+	}
+	return root, nil
+}
+
+// isYAMLDocumentMarker reports whether l starts ("---") or ends ("...") a document.
+func isYAMLDocumentMarker(l yamlLine) bool {
+	return l.indent == 0 && (l.text == "---" || l.text == "...")
+}
+
+// skipBlank moves past empty and comment-only lines.
+func (p *yamlParser) skipBlank() {
+	for p.pos < len(p.lines) && stripYAMLComment(p.lines[p.pos].text) == "" {
+		p.pos++
+	}
+}
+
+// block parses the mapping, sequence or scalar starting at the current line, at indent.
+func (p *yamlParser) block(indent int) (*yamlNode, error) {
+	l := p.lines[p.pos]
+	text := stripYAMLComment(l.text)
+	if text == "-" || strings.HasPrefix(text, "- ") {
+		return p.sequence(indent)
+	}
+	if _, _, ok := splitYAMLKey(text); ok {
+		return p.mapping(indent)
+	}
+	p.pos++
+	return scalarYAML(text, l.num)
+}
+
+func (p *yamlParser) sequence(indent int) (*yamlNode, error) {
+	n := &yamlNode{kind: yamlSequence, line: p.lines[p.pos].num}
+	for p.skipBlank(); p.pos < len(p.lines); p.skipBlank() {
+		l := p.lines[p.pos]
+		text := stripYAMLComment(l.text)
+		if l.indent != indent || (text != "-" && !strings.HasPrefix(text, "- ")) || isYAMLDocumentMarker(l) {
+			if l.indent > indent {
+				return nil, fmt.Errorf("yaml: line %d: unexpected indentation", l.num)
+			}
+			break
+		}
+		rest := strings.TrimLeft(strings.TrimPrefix(text, "-"), " ")
+		var (
+			item *yamlNode
+			err  error
+		)
+		if rest == "" {
+			p.pos++
+			item, err = p.child(indent, l.num)
+		} else {
+			// Re-read the entry as a block indented to where its content starts, so that
+			// "- key: value" continues as a mapping on the following lines.
+			after := l.text[1:]
+			content := strings.TrimLeft(after, " ")
+			p.lines[p.pos] = yamlLine{num: l.num, indent: l.indent + 1 + len(after) - len(content), text: content}
+			item, err = p.block(p.lines[p.pos].indent)
+		}
+		if err != nil {
+			return nil, err
+		}
+		n.items = append(n.items, item)
+	}
+	return n, nil
+}
+
+func (p *yamlParser) mapping(indent int) (*yamlNode, error) {
+	n := &yamlNode{kind: yamlMapping, line: p.lines[p.pos].num}
+	seen := map[string]bool{}
+	for p.skipBlank(); p.pos < len(p.lines); p.skipBlank() {
+		l := p.lines[p.pos]
+		if l.indent < indent {
+			break
+		}
+		text := stripYAMLComment(l.text)
+		if l.indent > indent {
+			return nil, fmt.Errorf("yaml: line %d: unexpected indentation", l.num)
+		}
+		if text == "-" || strings.HasPrefix(text, "- ") || isYAMLDocumentMarker(l) {
+			break
+		}
+		rawKey, rest, ok := splitYAMLKey(text)
+		if !ok {
+			return nil, fmt.Errorf("yaml: line %d: expected \"key: value\"", l.num)
+		}
+		keyNode, err := scalarYAML(rawKey, l.num)
+		if err != nil {
+			return nil, err
+		}
+		key := keyNode.value
+		if seen[key] {
+			return nil, fmt.Errorf("yaml: line %d: duplicate key %q", l.num, key)
+		}
+		seen[key] = true
+		p.pos++
+		var value *yamlNode
+		switch {
+		case rest == "":
+			value, err = p.child(indent, l.num)
+		case rest[0] == '|' || rest[0] == '>':
+			value, err = p.blockScalar(indent, rest, l.num)
+		default:
+			value, err = scalarYAML(rest, l.num)
+		}
+		if err != nil {
+			return nil, err
+		}
+		n.pairs = append(n.pairs, yamlPair{key: key, value: value})
+	}
+	return n, nil
+}
+
+// child parses the value following a "key:" or "-" line: a deeper block, a sequence at the
+// same indent (allowed for mapping values), or an empty scalar.
+func (p *yamlParser) child(indent, num int) (*yamlNode, error) {
+	p.skipBlank()
+	if p.pos < len(p.lines) {
+		l := p.lines[p.pos]
+		text := stripYAMLComment(l.text)
+		if l.indent > indent || (l.indent == indent && (text == "-" || strings.HasPrefix(text, "- "))) {
+			return p.block(l.indent)
+		}
+	}
+	return &yamlNode{kind: yamlScalar, line: num}, nil
+}
+
+// blockScalar reads a literal (|) or folded (>) scalar with optional chomping indicator.
+func (p *yamlParser) blockScalar(indent int, header string, num int) (*yamlNode, error) {
+	header = stripYAMLComment(header)
+	folded := header[0] == '>'
+	chomp := header[1:]
+	if chomp != "" && chomp != "-" && chomp != "+" {
+		return nil, fmt.Errorf("yaml: line %d: unsupported block scalar header %q", num, header)
+	}
+	var lines []string
+	blockIndent := -1
+	for ; p.pos < len(p.lines); p.pos++ {
+		l := p.lines[p.pos]
+		if l.text == "" {
+			lines = append(lines, "")
+			continue
+		}
+		if l.indent <= indent {
+			break
+		}
+		if blockIndent < 0 {
+			blockIndent = l.indent
+		}
+		if l.indent < blockIndent {
+			return nil, fmt.Errorf("yaml: line %d: block scalar indentation decreased", l.num)
+		}
+		lines = append(lines, strings.Repeat(" ", l.indent-blockIndent)+l.text)
+	}
+	// Trailing blank lines belong to the following structure unless kept by "+".
+	trailing := 0
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+		trailing++
+	}
+	var text string
+	if folded {
+		var b strings.Builder
+		for i, s := range lines {
+			switch {
+			case i == 0:
+			case s == "" || lines[i-1] == "" || strings.HasPrefix(s, " ") || strings.HasPrefix(lines[i-1], " "):
+				b.WriteByte('\n')
+			default:
+				b.WriteByte(' ')
+			}
+			b.WriteString(s)
+		}
+		text = b.String()
+	} else {
+		text = strings.Join(lines, "\n")
+	}
+	switch {
+	case len(lines) == 0:
+	case chomp == "-":
+	case chomp == "+":
+		text += "\n" + strings.Repeat("\n", trailing)
+	default:
+		text += "\n"
+	}
+	return &yamlNode{kind: yamlScalar, line: num, value: text}, nil
+}
+
+// scalarYAML parses an inline value: a quoted or plain scalar or a flow sequence of scalars.
+func scalarYAML(s string, num int) (*yamlNode, error) {
+	s = strings.TrimSpace(s)
+	n := &yamlNode{kind: yamlScalar, line: num}
+	if s == "" {
+		return n, nil
+	}
+	switch s[0] {
+	case '"':
+		v, err := strconv.Unquote(s)
+		if err != nil {
+			return nil, fmt.Errorf("yaml: line %d: invalid double-quoted string %s", num, s)
+		}
+		n.value = v
+	case '\'':
+		if len(s) < 2 || s[len(s)-1] != '\'' {
+			return nil, fmt.Errorf("yaml: line %d: unterminated single-quoted string %s", num, s)
+		}
+		inner := s[1 : len(s)-1]
+		if strings.Count(inner, "'") != 2*strings.Count(inner, "''") {
+			return nil, fmt.Errorf("yaml: line %d: invalid single-quoted string %s", num, s)
+		}
+		n.value = strings.ReplaceAll(inner, "''", "'")
+	case '[':
+		if s[len(s)-1] != ']' {
+			return nil, fmt.Errorf("yaml: line %d: unterminated flow sequence %s", num, s)
+		}
+		n.kind = yamlSequence
+		for _, part := range splitYAMLFlow(s[1 : len(s)-1]) {
+			if strings.ContainsAny(part, "[]{}") {
+				return nil, fmt.Errorf("yaml: line %d: nested flow collections are not supported", num)
+			}
+			item, err := scalarYAML(part, num)
+			if err != nil {
+				return nil, err
+			}
+			n.items = append(n.items, item)
+		}
+	case '{':
+		if s != "{}" {
+			return nil, fmt.Errorf("yaml: line %d: flow mappings are not supported", num)
+		}
+		n.kind = yamlMapping
+	case '&', '*', '!':
+		return nil, fmt.Errorf("yaml: line %d: anchors, aliases and tags are not supported", num)
+	default:
+		if s == "~" || s == "null" {
+			return n, nil
+		}
+		n.value = s
+	}
+	return n, nil
+}
+
+// splitYAMLFlow splits the inside of a flow sequence at commas outside quotes.
+func splitYAMLFlow(s string) []string {
+	var parts []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ',':
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	if last := strings.TrimSpace(s[start:]); last != "" || len(parts) > 0 {
+		parts = append(parts, s[start:])
+	}
+	return parts
+}
+
+// splitYAMLKey splits "key: value" (or "key:") at the first colon outside quotes that is
+// followed by a space or ends the line.
+func splitYAMLKey(s string) (key, rest string, ok bool) {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case (c == '"' || c == '\'') && i == 0:
+			quote = c
+		case c == '[' || c == '{':
+			if i == 0 {
+				return "", "", false
+			}
+		case c == ':' && (i+1 == len(s) || s[i+1] == ' '):
+			return strings.TrimSpace(s[:i]), strings.TrimSpace(s[i+1:]), i > 0
+		}
+	}
+	return "", "", false
+}
+
+// stripYAMLComment removes a trailing "# comment" outside quotes.
+func stripYAMLComment(s string) string {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case (c == '"' || c == '\'') && (i == 0 || strings.ContainsRune(" [,:-", rune(s[i-1]))):
+			quote = c
+		case c == '#' && (i == 0 || s[i-1] == ' '):
+			return strings.TrimRight(s[:i], " ")
+		}
+	}
+	return s
+}
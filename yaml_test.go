@@ -0,0 +1,125 @@
+package gofeedx_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jo-hoe/gofeedx"
+)
+
+const podcastYAML = `---
+# Channel metadata
+title: "Folder Cast"
+link: https://example.com/
+feedURL: https://example.com/podcast.xml
+description: >
+  A show about
+  folders.
+language: en
+updated: Wed, 01 May 2024 10:00:00 +0200
+author:
+  name: Host
+  email: host@example.com
+image: https://example.com/artwork.jpg
+categories: [Technology, 'News & Politics']
+extensions:
+  - name: itunes:explicit
+    text: "false"
+  - name: podcast:locked
+    attrs:
+      owner: host@example.com
+    text: "yes"
+items:
+- title: Episode 1
+  id: ep-1
+  created: 2024-05-01T08:00:00Z
+  description: |
+    Show notes.
+
+    With a #hashtag.
+  enclosure:
+    url: https://example.com/ep-1.mp3#t=0   # comment after a fragment
+    length: 12345
+    type: audio/mpeg
+  duration: "00:30:01"
+- title: It's Episode 2
+  id: ep-2
+  created: 2024-05-08T08:00:00Z
+  enclosure: {}
+  extensions: []
+`
+
+func TestLoadFeedFromYAML(t *testing.T) {
+	f, err := gofeedx.LoadFeedFromYAML(strings.NewReader(podcastYAML))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if f.Title != "Folder Cast" || f.Link.Href != "https://example.com/" || f.Language != "en" {
+		t.Errorf("unexpected channel metadata: %+v", f)
+	}
+	if f.Description != "A show about folders.\n" {
+		t.Errorf("folded description = %q", f.Description)
+	}
+	if want := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC); !f.Updated.Equal(want) {
+		t.Errorf("updated = %v, want %v", f.Updated, want)
+	}
+	if f.Author.Email != "host@example.com" || f.Image.Url != "https://example.com/artwork.jpg" {
+		t.Errorf("unexpected author/image: %+v %+v", f.Author, f.Image)
+	}
+	if len(f.Categories) != 2 || f.Categories[1].Text != "News & Politics" {
+		t.Errorf("unexpected categories: %+v", f.Categories)
+	}
+	if len(f.Extensions) != 2 || f.Extensions[1].Attrs["owner"] != "host@example.com" || f.Extensions[0].Text != "false" {
+		t.Errorf("unexpected extensions: %+v", f.Extensions)
+	}
+	if f.Generator != nil {
+		t.Errorf("expected no generator without a generator key, got %+v", f.Generator)
+	}
+	if len(f.Items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(f.Items))
+	}
+	e1 := f.Items[0]
+	if e1.Description != "Show notes.\n\nWith a #hashtag.\n" {
+		t.Errorf("literal description = %q", e1.Description)
+	}
+	if e1.Enclosure.Url != "https://example.com/ep-1.mp3#t=0" || e1.Enclosure.Length != 12345 || e1.DurationSeconds != 1801 {
+		t.Errorf("unexpected enclosure/duration: %+v %d", e1.Enclosure, e1.DurationSeconds)
+	}
+	if f.Items[1].Title != "It's Episode 2" {
+		t.Errorf("title = %q", f.Items[1].Title)
+	}
+
+	out, err := gofeedx.ToPSP(f)
+	if err != nil {
+		t.Fatalf("ToPSP failed: %v", err)
+	}
+	if !strings.Contains(out, `<itunes:duration>1801</itunes:duration>`) {
+		t.Errorf("expected duration from YAML in PSP output:\n%s", out)
+	}
+}
+
+func TestLoadFeedFromYAML_Errors(t *testing.T) {
+	tests := map[string]string{
+		"":                             "empty document",
+		"title: a\ntitel: b\n":         `line 2: unknown feed key "titel"`,
+		"title: a\ntitle: b\n":         `line 2: duplicate key "title"`,
+		"items:\n  - duration: soon\n": "invalid itunes:duration",
+		"items:\n  - enclosure:\n      length: big\n": `line 3: invalid number "big"`,
+		"updated: someday\n":                          "invalid feed time",
+		"title:\n\t- a\n":                             "tabs are not allowed",
+		"author: &host Host\n":                        "anchors, aliases and tags",
+		"link: {href: x}\n":                           "flow mappings are not supported",
+		"title: a\n  b: c\n":                          "line 2: unexpected indentation",
+		"categories: Technology\n":                    "categories must be a list",
+		"extensions:\n  - text: x\n":                  "extension without name",
+		"title: a\n---\ntitle: b\n":                   "multiple documents",
+	}
+	for in, want := range tests {
+		_, err := gofeedx.LoadFeedFromYAML(strings.NewReader(in))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("LoadFeedFromYAML(%q) error = %v, want it to contain %q", in, err, want)
+		}
+	}
+}